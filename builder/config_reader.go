@@ -16,15 +16,16 @@ import (
 
 // Config is a builder configuration file
 type Config struct {
-	Description     string           `toml:"description"`
-	Buildpacks      ModuleCollection `toml:"buildpacks"`
-	Extensions      ModuleCollection `toml:"extensions"`
-	Order           dist.Order       `toml:"order"`
-	OrderExtensions dist.Order       `toml:"order-extensions"`
-	Stack           StackConfig      `toml:"stack"`
-	Lifecycle       LifecycleConfig  `toml:"lifecycle"`
-	Run             RunConfig        `toml:"run"`
-	Build           BuildConfig      `toml:"build"`
+	Description     string            `toml:"description"`
+	Buildpacks      ModuleCollection  `toml:"buildpacks"`
+	Extensions      ModuleCollection  `toml:"extensions"`
+	Order           dist.Order        `toml:"order"`
+	OrderExtensions dist.Order        `toml:"order-extensions"`
+	Stack           StackConfig       `toml:"stack"`
+	Lifecycle       LifecycleConfig   `toml:"lifecycle"`
+	Run             RunConfig         `toml:"run"`
+	Build           BuildConfig       `toml:"build"`
+	BaseBuilder     BaseBuilderConfig `toml:"base-builder"`
 }
 
 // ModuleCollection is a list of ModuleConfigs
@@ -69,6 +70,31 @@ type RunImageConfig struct {
 	Mirrors []string `toml:"mirrors,omitempty"`
 }
 
+// BaseBuilderConfig details an existing builder to extend. The buildpacks, extensions, order, lifecycle, run images
+// and build environment of the base builder are inherited unless the builder config overrides them.
+type BaseBuilderConfig struct {
+	Image string `toml:"image"`
+
+	// MergeOrder decides how the order of the builder config is merged with the order of the base builder. It is one
+	// of "prepend" (the default), "append" or "replace".
+	MergeOrder string `toml:"merge-order,omitempty"`
+
+	// RemoveBuildpacks are IDs of buildpacks removed from the base builder and its order. Order groups that still use
+	// them through a composite buildpack are dropped.
+	RemoveBuildpacks []string `toml:"remove-buildpacks,omitempty"`
+}
+
+const (
+	// MergeOrderPrepend places the order groups of the builder config before those of the base builder
+	MergeOrderPrepend = "prepend"
+
+	// MergeOrderAppend places the order groups of the builder config after those of the base builder
+	MergeOrderAppend = "append"
+
+	// MergeOrderReplace replaces the order of the base builder with the order of the builder config
+	MergeOrderReplace = "replace"
+)
+
 // BuildConfig build image configuration
 type BuildConfig struct {
 	Image string           `toml:"image"`
@@ -106,7 +132,7 @@ func ReadConfig(path string) (config Config, warnings []string, err error) {
 		return Config{}, nil, errors.Wrapf(err, "parse contents of '%s'", path)
 	}
 
-	if len(config.Order) == 0 {
+	if len(config.Order) == 0 && config.BaseBuilder.Image == "" {
 		warnings = append(warnings, fmt.Sprintf("empty %s definition", style.Symbol("order")))
 	}
 
@@ -117,6 +143,10 @@ func ReadConfig(path string) (config Config, warnings []string, err error) {
 
 // ValidateConfig validates the config
 func ValidateConfig(c Config) error {
+	if c.BaseBuilder.Image != "" {
+		return validateBaseBuilderConfig(c)
+	}
+
 	if c.Build.Image == "" && c.Stack.BuildImage == "" {
 		return errors.New("build.image is required")
 	} else if c.Build.Image != "" && c.Stack.BuildImage != "" && c.Build.Image != c.Stack.BuildImage {
@@ -140,6 +170,26 @@ func ValidateConfig(c Config) error {
 	return nil
 }
 
+func validateBaseBuilderConfig(c Config) error {
+	if c.Build.Image != "" || c.Stack.BuildImage != "" {
+		return errors.New("build.image and base-builder.image cannot both be set")
+	}
+
+	switch c.BaseBuilder.MergeOrder {
+	case "", MergeOrderPrepend, MergeOrderAppend, MergeOrderReplace:
+	default:
+		return errors.Errorf("base-builder.merge-order must be one of %s, %s or %s", MergeOrderPrepend, MergeOrderAppend, MergeOrderReplace)
+	}
+
+	for _, runImage := range c.Run.Images {
+		if runImage.Image == "" {
+			return errors.New("run.images.image is required")
+		}
+	}
+
+	return nil
+}
+
 func (c *Config) mergeStackWithImages() {
 	// RFC-0096
 	if c.Build.Image != "" {
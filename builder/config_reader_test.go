@@ -79,6 +79,32 @@ func testConfig(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("a base builder is extended", func() {
+			it.Before(func() {
+				h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(`
+[base-builder]
+  image = "paketobuildpacks/builder-jammy-base"
+  merge-order = "append"
+  remove-buildpacks = ["paketo-buildpacks/php"]
+
+[[buildpacks]]
+  uri = "https://example.com/buildpack-1.tgz"
+`), 0666))
+			})
+
+			it("returns the base builder config without warning about the order", func() {
+				builderConfig, warns, err := builder.ReadConfig(builderConfigPath)
+				h.AssertNil(t, err)
+				h.AssertEq(t, len(warns), 0)
+
+				h.AssertEq(t, builderConfig.BaseBuilder, builder.BaseBuilderConfig{
+					Image:            "paketobuildpacks/builder-jammy-base",
+					MergeOrder:       builder.MergeOrderAppend,
+					RemoveBuildpacks: []string{"paketo-buildpacks/php"},
+				})
+			})
+		})
+
 		when("an error occurs while reading", func() {
 			it("bubbles up the error", func() {
 				_, _, err := builder.ReadConfig(builderConfigPath)
@@ -228,6 +254,30 @@ uri = "noop-buildpack.tgz"
 			config := builder.Config{}
 			h.AssertError(t, builder.ValidateConfig(config), "build.image is required")
 		})
+
+		when("extending a base builder", func() {
+			it("does not require build or run images", func() {
+				config := builder.Config{
+					BaseBuilder: builder.BaseBuilderConfig{Image: "some/base-builder"},
+				}
+				h.AssertNil(t, builder.ValidateConfig(config))
+			})
+
+			it("returns error if a build image is set", func() {
+				config := builder.Config{
+					BaseBuilder: builder.BaseBuilderConfig{Image: "some/base-builder"},
+					Build:       builder.BuildConfig{Image: testBuildImage},
+				}
+				h.AssertError(t, builder.ValidateConfig(config), "build.image and base-builder.image cannot both be set")
+			})
+
+			it("returns error for an unknown merge order", func() {
+				config := builder.Config{
+					BaseBuilder: builder.BaseBuilderConfig{Image: "some/base-builder", MergeOrder: "shuffle"},
+				}
+				h.AssertError(t, builder.ValidateConfig(config), "base-builder.merge-order must be one of prepend, append or replace")
+			})
+		})
 	})
 	when("#ParseBuildConfigEnv()", func() {
 		it("should return an error when name is not defined", func() {
@@ -1,12 +1,16 @@
 package cmd
 
 import (
+	"context"
+	"net/url"
+
 	"github.com/heroku/color"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/buildpacks/pack/internal/docker"
 
+	"github.com/buildpacks/pack"
 	"github.com/buildpacks/pack/buildpackage"
 	builderwriter "github.com/buildpacks/pack/internal/builder/writer"
 	"github.com/buildpacks/pack/internal/commands"
@@ -15,6 +19,7 @@ import (
 	"github.com/buildpacks/pack/internal/term"
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/tracing"
 )
 
 // ConfigurableLogger defines behavior required by the PackCommand
@@ -73,11 +78,13 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 	rootCmd.PersistentFlags().Bool("timestamps", false, "Enable timestamps in output")
 	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Show less output")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Show more output")
+	rootCmd.PersistentFlags().BoolVar(&sshAcceptNew, "ssh-accept-new", false, "Add the host key of an ssh:// DOCKER_HOST to ~/.ssh/known_hosts when it isn't known yet, instead of prompting")
 	rootCmd.Flags().Bool("version", false, "Show current 'pack' version")
 
 	commands.AddHelpFlag(rootCmd, "pack")
 
 	rootCmd.AddCommand(commands.Build(logger, cfg, packClient))
+	rootCmd.AddCommand(commands.Lock(logger, cfg, packClient))
 	rootCmd.AddCommand(commands.NewBuilderCommand(logger, cfg, packClient))
 	rootCmd.AddCommand(commands.NewBuildpackCommand(logger, cfg, packClient, buildpackage.NewConfigReader()))
 	rootCmd.AddCommand(commands.NewExtensionCommand(logger, cfg, packClient, buildpackage.NewConfigReader()))
@@ -143,9 +150,33 @@ func initClient(logger logging.Logger, cfg config.Config) (*client.Client, error
 		return nil, err
 	}
 
-	dc, err := tryInitSSHDockerClient()
+	dc, err := tryInitSSHDockerClient(logger)
 	if err != nil {
 		return nil, err
 	}
-	return client.NewClient(client.WithLogger(logger), client.WithExperimental(cfg.Experimental), client.WithRegistryMirrors(cfg.RegistryMirrors), client.WithDockerClient(dc))
+
+	daemonDialer := func(hostURL *url.URL, identity string) (client.DialContext, error) {
+		return client.DialContext(newSSHDialContext(hostURL, identity, logger)), nil
+	}
+
+	opts := []client.Option{
+		client.WithLogger(logger),
+		client.WithExperimental(cfg.Experimental),
+		client.WithRegistryMirrors(cfg.RegistryMirrors),
+		client.WithDockerClient(dc),
+		client.WithDaemonDialer(daemonDialer),
+	}
+	if tracing.EnabledFromEnv() {
+		tracerProvider, shutdown, err := tracing.NewProviderFromEnv(context.Background(), pack.Version)
+		if err != nil {
+			return nil, errors.Wrap(err, "initializing tracing")
+		}
+		cobra.OnFinalize(func() {
+			if err := shutdown(context.Background()); err != nil {
+				logger.Debugf("flushing traces: %s", err)
+			}
+		})
+		opts = append(opts, client.WithTracerProvider(tracerProvider))
+	}
+	return client.NewClient(opts...)
 }
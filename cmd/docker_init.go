@@ -3,14 +3,16 @@ package cmd
 import (
 	"bufio"
 	"bytes"
-	"encoding/base64"
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
+	"sync"
 
 	dockerClient "github.com/docker/docker/client"
 	"golang.org/x/crypto/ssh"
@@ -18,9 +20,14 @@ import (
 
 	"github.com/buildpacks/pack/internal/sshdialer"
 	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
 )
 
-func tryInitSSHDockerClient() (dockerClient.CommonAPIClient, error) {
+// sshAcceptNew is set by the --ssh-accept-new flag, which is parsed after the docker client is created
+// but before the ssh connection is made
+var sshAcceptNew bool
+
+func tryInitSSHDockerClient(logger logging.Logger) (dockerClient.CommonAPIClient, error) {
 	dockerHost := os.Getenv("DOCKER_HOST")
 	_url, err := url.Parse(dockerHost)
 	isSSH := err == nil && _url.Scheme == "ssh"
@@ -29,17 +36,7 @@ func tryInitSSHDockerClient() (dockerClient.CommonAPIClient, error) {
 		return nil, nil
 	}
 
-	credentialsConfig := sshdialer.Config{
-		Identity:           os.Getenv("DOCKER_HOST_SSH_IDENTITY"),
-		PassPhrase:         os.Getenv("DOCKER_HOST_SSH_IDENTITY_PASSPHRASE"),
-		PasswordCallback:   newReadSecretCbk("please enter password:"),
-		PassPhraseCallback: newReadSecretCbk("please enter passphrase to private key:"),
-		HostKeyCallback:    newHostKeyCbk(),
-	}
-	dialContext, err := sshdialer.NewDialContext(_url, credentialsConfig)
-	if err != nil {
-		return nil, err
-	}
+	dialContext := newSSHDialContext(_url, os.Getenv("DOCKER_HOST_SSH_IDENTITY"), logger)
 
 	httpClient := &http.Client{
 		// No tls
@@ -59,6 +56,44 @@ func tryInitSSHDockerClient() (dockerClient.CommonAPIClient, error) {
 	return dockerClient.NewClientWithOpts(dockerClientOpts...)
 }
 
+// newSSHDialContext dials the docker daemon at the ssh:// hostURL, prompting for passwords and unknown host keys.
+// It's also used for the docker hosts builds run on with --on.
+func newSSHDialContext(hostURL *url.URL, identity string, logger logging.Logger) dialContextFunc {
+	credentialsConfig := sshdialer.Config{
+		Identity:           identity,
+		PassPhrase:         os.Getenv("DOCKER_HOST_SSH_IDENTITY_PASSPHRASE"),
+		PasswordCallback:   newReadSecretCbk("please enter password:"),
+		PassPhraseCallback: newReadSecretCbk("please enter passphrase to private key:"),
+		HostKeyCallback:    newHostKeyCbk(),
+		Logger:             logger,
+	}
+	return newLazyDialContext(func() (dialContextFunc, error) {
+		credentialsConfig.AcceptNewHostKeys = sshAcceptNew
+		return sshdialer.NewDialContext(hostURL, credentialsConfig)
+	})
+}
+
+type dialContextFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// newLazyDialContext only connects over ssh once the docker daemon is first used,
+// so that commands which don't use it don't prompt for passwords or host keys
+func newLazyDialContext(dial func() (dialContextFunc, error)) dialContextFunc {
+	var (
+		once        sync.Once
+		dialContext dialContextFunc
+		err         error
+	)
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		once.Do(func() {
+			dialContext, err = dial()
+		})
+		if err != nil {
+			return nil, err
+		}
+		return dialContext(ctx, network, addr)
+	}
+}
+
 // readSecret prompts for a secret and returns value input by user from stdin
 // Unlike terminal.ReadPassword(), $(echo $SECRET | podman...) is supported.
 // Additionally, all input after `<secret>/n` is queued to podman command.
@@ -137,8 +172,6 @@ Are you sure you want to continue connecting (yes/no)? `
 
 		if answer == "yes" || answer == "y" {
 			trust = pubKey.Marshal()
-			fmt.Fprintf(os.Stderr, "To avoid this in future add following line into your ~/.ssh/known_hosts:\n%s %s %s\n",
-				hostPort, pubKey.Type(), base64.StdEncoding.EncodeToString(trust))
 			return nil
 		}
 
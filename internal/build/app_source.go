@@ -0,0 +1,312 @@
+package build
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	dcontainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/pkg/archive"
+)
+
+const (
+	appSourceMountPath = "/pack-app-source"
+	appDeletionsPath   = "/pack-app-deletions"
+)
+
+// appIndex records the app source copied into an app source volume, so that subsequent builds only copy what changed
+type appIndex struct {
+	Volume          string                   `json:"volume"`
+	VolumeCreatedAt string                   `json:"volumeCreatedAt"`
+	UID             int                      `json:"uid"`
+	GID             int                      `json:"gid"`
+	Files           map[string]appIndexEntry `json:"files"` // keyed by slash separated path relative to the app directory
+}
+
+type appIndexEntry struct {
+	Mode    fs.FileMode `json:"mode"`
+	Size    int64       `json:"size"`
+	ModTime time.Time   `json:"modTime"`
+	Digest  string      `json:"digest,omitempty"` // sha256 of the contents of a file, or of the target of a symlink
+}
+
+// SyncDir copies a local directory (src) to the destination on the container like CopyDir, keeping a copy of it in
+// sourceVolume between builds. Only the files whose contents changed since the index at indexPath was written are sent
+// to the daemon, and files that no longer exist are removed from the volume, before the volume is copied to dst by a
+// container running the image of the given container. Windows containers and zip or tar sources are always copied
+// in full.
+func SyncDir(src, dst, sourceVolume, indexPath string, uid, gid int, osType string, fileFilter func(string) bool) ContainerOperation {
+	return func(ctrClient DockerClient, ctx context.Context, containerID string, stdout, stderr io.Writer) error {
+		if fi, err := os.Stat(src); osType == "windows" || err != nil || !fi.IsDir() {
+			return CopyDir(src, dst, uid, gid, osType, true, fileFilter)(ctrClient, ctx, containerID, stdout, stderr)
+		}
+
+		unlock, err := lockAppIndex(indexPath)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		previous, err := readAppIndex(indexPath)
+		if err != nil {
+			return err
+		}
+		vol, err := ensureAppSourceVolume(ctx, ctrClient, sourceVolume, previous, uid, gid)
+		if err != nil {
+			return err
+		}
+		if previous.VolumeCreatedAt != vol.CreatedAt {
+			previous = appIndex{}
+		}
+
+		current, err := indexAppDir(src, fileFilter, previous)
+		if err != nil {
+			return errors.Wrapf(err, "indexing '%s'", src)
+		}
+		current.Volume, current.VolumeCreatedAt, current.UID, current.GID = sourceVolume, vol.CreatedAt, uid, gid
+		changed, deleted := current.diff(previous)
+
+		// the volume no longer matches the index once it is modified, so the index is only rewritten on success
+		if err := os.Remove(indexPath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		if err := syncAppSourceVolume(ctx, ctrClient, containerID, src, dst, sourceVolume, uid, gid, fileFilter, changed, deleted, stderr); err != nil {
+			return err
+		}
+		return writeAppIndex(indexPath, current)
+	}
+}
+
+// ensureAppSourceVolume returns the app source volume, recreating it when its contents are not described by the index
+func ensureAppSourceVolume(ctx context.Context, ctrClient DockerClient, name string, index appIndex, uid, gid int) (volume.Volume, error) {
+	vol, err := ctrClient.VolumeInspect(ctx, name)
+	if err == nil {
+		if index.Volume == name && index.VolumeCreatedAt == vol.CreatedAt && index.UID == uid && index.GID == gid {
+			return vol, nil
+		}
+		if err := ctrClient.VolumeRemove(ctx, name, true); err != nil {
+			return volume.Volume{}, errors.Wrapf(err, "removing app source volume '%s'", name)
+		}
+	} else if !client.IsErrNotFound(err) {
+		return volume.Volume{}, errors.Wrapf(err, "inspecting app source volume '%s'", name)
+	}
+
+	vol, err = ctrClient.VolumeCreate(ctx, volume.CreateOptions{Name: name})
+	if err != nil {
+		return volume.Volume{}, errors.Wrapf(err, "creating app source volume '%s'", name)
+	}
+	return vol, nil
+}
+
+func syncAppSourceVolume(ctx context.Context, ctrClient DockerClient, containerID, src, dst, sourceVolume string, uid, gid int, fileFilter func(string) bool, changed map[string]bool, deleted []string, stderr io.Writer) error {
+	info, err := ctrClient.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return err
+	}
+	mnt, err := findMount(info, dst)
+	if err != nil {
+		return err
+	}
+
+	script := "set -e\n"
+	if len(deleted) > 0 {
+		script += fmt.Sprintf("cd %s && xargs -0 rm -rf -- < %s\n", appSourceMountPath, appDeletionsPath)
+	}
+	script += fmt.Sprintf("cp -a %s/. '%s/'\n", appSourceMountPath, dst)
+
+	ctr, err := ctrClient.ContainerCreate(ctx,
+		&dcontainer.Config{
+			Image:      info.Image,
+			Entrypoint: []string{"/bin/sh", "-c"},
+			Cmd:        []string{script},
+			WorkingDir: "/",
+			User:       "0",
+		},
+		&dcontainer.HostConfig{
+			Binds: []string{
+				fmt.Sprintf("%s:%s", sourceVolume, appSourceMountPath),
+				fmt.Sprintf("%s:%s", mnt.Name, mnt.Destination),
+			},
+			NetworkMode: "none",
+		},
+		nil, nil, "",
+	)
+	if err != nil {
+		return errors.Wrap(err, "creating app source container")
+	}
+	defer ctrClient.ContainerRemove(context.Background(), ctr.ID, dcontainer.RemoveOptions{Force: true})
+
+	changedFilter := func(relPath string) bool {
+		return (fileFilter == nil || fileFilter(relPath)) && changed[filepath.ToSlash(relPath)]
+	}
+	reader := archive.ReadDirAsTar(src, appSourceMountPath, uid, gid, -1, false, true, changedFilter)
+	defer reader.Close()
+	if err := copyDir(ctx, ctrClient, ctr.ID, reader); err != nil {
+		return errors.Wrap(err, "copying changed app source files")
+	}
+
+	if len(deleted) > 0 {
+		tarBuilder := archive.TarBuilder{}
+		tarBuilder.AddFile(appDeletionsPath, 0644, archive.NormalizedDateTime, []byte(strings.Join(deleted, "\x00")))
+		deletions := tarBuilder.Reader(archive.DefaultTarWriterFactory())
+		defer deletions.Close()
+		if err := ctrClient.CopyToContainer(ctx, ctr.ID, "/", deletions, types.CopyToContainerOptions{}); err != nil {
+			return errors.Wrap(err, "copying deleted app source files")
+		}
+	}
+
+	return container.RunWithHandler(ctx, ctrClient, ctr.ID, container.DefaultHandler(io.Discard, stderr))
+}
+
+// lockAppIndex holds an exclusive lock on the index until the returned function is called, so that concurrent builds
+// of the same app against the same daemon don't sync the volume at the same time
+func lockAppIndex(indexPath string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(indexPath), 0750); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filepath.Clean(indexPath+".lock"), os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening app source index lock '%s'", indexPath)
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, errors.Wrapf(err, "locking app source index '%s'", indexPath)
+	}
+	// closing the file releases the lock
+	return func() { f.Close() }, nil
+}
+
+func readAppIndex(indexPath string) (appIndex, error) {
+	var index appIndex
+	data, err := os.ReadFile(filepath.Clean(indexPath))
+	if os.IsNotExist(err) {
+		return index, nil
+	}
+	if err != nil {
+		return index, errors.Wrapf(err, "reading app source index '%s'", indexPath)
+	}
+	if err := json.Unmarshal(data, &index); err != nil {
+		// an unreadable index only costs a full copy
+		return appIndex{}, nil
+	}
+	return index, nil
+}
+
+func writeAppIndex(indexPath string, index appIndex) error {
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(indexPath), 0750); err != nil {
+		return err
+	}
+	return errors.Wrapf(os.WriteFile(indexPath, data, 0600), "writing app source index '%s'", indexPath)
+}
+
+// indexAppDir indexes the files of the app directory accepted by the file filter. The digests of the files whose
+// mode, size and modification time are unchanged since the previous index are reused rather than computed.
+func indexAppDir(src string, fileFilter func(string) bool, previous appIndex) (appIndex, error) {
+	index := appIndex{Files: map[string]appIndexEntry{}}
+	err := filepath.WalkDir(src, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(src, file)
+		if err != nil {
+			return err
+		}
+		// like archive.WriteDirToTar, the children of directories that are filtered out are still walked
+		if relPath == "." || (fileFilter != nil && !fileFilter(relPath)) {
+			return nil
+		}
+
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if fi.Mode()&fs.ModeSocket != 0 {
+			return nil
+		}
+
+		key := filepath.ToSlash(relPath)
+		entry := appIndexEntry{Mode: fi.Mode(), ModTime: fi.ModTime()}
+		switch {
+		case fi.Mode()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(file)
+			if err != nil {
+				return err
+			}
+			sum := sha256.Sum256([]byte(target))
+			entry.Digest = hex.EncodeToString(sum[:])
+		case fi.Mode().IsRegular():
+			entry.Size = fi.Size()
+			if prev, ok := previous.Files[key]; ok && prev.Mode == entry.Mode && prev.Size == entry.Size && prev.ModTime.Equal(entry.ModTime) {
+				entry.Digest = prev.Digest
+			} else if entry.Digest, err = fileDigest(file); err != nil {
+				return err
+			}
+		}
+		index.Files[key] = entry
+		return nil
+	})
+	return index, err
+}
+
+func fileDigest(file string) (string, error) {
+	f, err := os.Open(filepath.Clean(file))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// diff returns the paths whose type, mode or contents differ from the previous index, and the topmost paths of the
+// previous index that no longer exist. Paths that became directories or stopped being ones are replaced when the
+// changed paths are copied, so only paths whose parent directory still exists are reported as deleted.
+func (i appIndex) diff(previous appIndex) (map[string]bool, []string) {
+	changed := map[string]bool{}
+	for p, entry := range i.Files {
+		prev, ok := previous.Files[p]
+		if !ok || prev.Mode != entry.Mode || prev.Digest != entry.Digest {
+			changed[p] = true
+		}
+	}
+
+	var deleted []string
+	for p := range previous.Files {
+		if _, ok := i.Files[p]; ok {
+			continue
+		}
+		if parent := path.Dir(p); parent != "." {
+			if entry, ok := i.Files[parent]; !ok || !entry.Mode.IsDir() {
+				continue
+			}
+		}
+		deleted = append(deleted, p)
+	}
+	sort.Strings(deleted)
+	return changed, deleted
+}
@@ -0,0 +1,189 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestAppSource(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "AppSource", testAppSource, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testAppSource(t *testing.T, when spec.G, it spec.S) {
+	var appDir string
+
+	writeFile := func(path, contents string) {
+		path = filepath.Join(appDir, filepath.FromSlash(path))
+		h.AssertNil(t, os.MkdirAll(filepath.Dir(path), 0755))
+		h.AssertNil(t, os.WriteFile(path, []byte(contents), 0600))
+	}
+
+	it.Before(func() {
+		var err error
+		appDir, err = os.MkdirTemp("", "app-source")
+		h.AssertNil(t, err)
+
+		writeFile("main.go", "package main")
+		writeFile("pkg/lib.go", "package pkg")
+		writeFile("pkg/util/util.go", "package util")
+		writeFile("README.md", "# app")
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, os.RemoveAll(appDir))
+	})
+
+	when("#indexAppDir", func() {
+		it("indexes the files accepted by the filter", func() {
+			index, err := indexAppDir(appDir, func(path string) bool { return path != "README.md" }, appIndex{})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(index.Files), 5)
+			h.AssertEq(t, index.Files["pkg"].Mode.IsDir(), true)
+			h.AssertEq(t, index.Files["main.go"].Size, int64(12))
+			h.AssertEq(t, index.Files["main.go"].Digest, "512843855fcc92a51c810b1b58e0731c01eac9a6a23c157bfa02aad71edffbe7")
+			_, ok := index.Files["README.md"]
+			h.AssertEq(t, ok, false)
+		})
+
+		it("reuses the digests of files that didn't change", func() {
+			previous, err := indexAppDir(appDir, nil, appIndex{})
+			h.AssertNil(t, err)
+			entry := previous.Files["main.go"]
+			entry.Digest = "previous-digest"
+			previous.Files["main.go"] = entry
+
+			index, err := indexAppDir(appDir, nil, previous)
+			h.AssertNil(t, err)
+			h.AssertEq(t, index.Files["main.go"].Digest, "previous-digest")
+
+			modTime := time.Now().Add(time.Minute)
+			h.AssertNil(t, os.Chtimes(filepath.Join(appDir, "main.go"), modTime, modTime))
+			index, err = indexAppDir(appDir, nil, previous)
+			h.AssertNil(t, err)
+			h.AssertNotEq(t, index.Files["main.go"].Digest, "previous-digest")
+		})
+	})
+
+	when("#diff", func() {
+		it("returns the changed and deleted paths", func() {
+			previous, err := indexAppDir(appDir, nil, appIndex{})
+			h.AssertNil(t, err)
+
+			writeFile("main.go", "package main // changed")
+			writeFile("new.go", "package main")
+			h.AssertNil(t, os.RemoveAll(filepath.Join(appDir, "pkg", "util")))
+			h.AssertNil(t, os.Remove(filepath.Join(appDir, "README.md")))
+
+			current, err := indexAppDir(appDir, nil, previous)
+			h.AssertNil(t, err)
+
+			changed, deleted := current.diff(previous)
+			h.AssertEq(t, changed, map[string]bool{"main.go": true, "new.go": true})
+			h.AssertEq(t, deleted, []string{"README.md", "pkg/util"})
+		})
+
+		it("ignores contents that only got a new modification time", func() {
+			previous, err := indexAppDir(appDir, nil, appIndex{})
+			h.AssertNil(t, err)
+
+			modTime := time.Now().Add(time.Minute)
+			h.AssertNil(t, os.Chtimes(filepath.Join(appDir, "main.go"), modTime, modTime))
+
+			current, err := indexAppDir(appDir, nil, previous)
+			h.AssertNil(t, err)
+
+			changed, deleted := current.diff(previous)
+			h.AssertEq(t, len(changed), 0)
+			h.AssertEq(t, len(deleted), 0)
+		})
+
+		it("doesn't delete the contents of directories that became files", func() {
+			previous, err := indexAppDir(appDir, nil, appIndex{})
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, os.RemoveAll(filepath.Join(appDir, "pkg")))
+			writeFile("pkg", "not a directory")
+
+			current, err := indexAppDir(appDir, nil, previous)
+			h.AssertNil(t, err)
+
+			changed, deleted := current.diff(previous)
+			h.AssertEq(t, changed, map[string]bool{"pkg": true})
+			h.AssertEq(t, len(deleted), 0)
+		})
+
+		it("returns every path without a previous index", func() {
+			current, err := indexAppDir(appDir, nil, appIndex{})
+			h.AssertNil(t, err)
+
+			changed, deleted := current.diff(appIndex{})
+			h.AssertEq(t, len(changed), 6)
+			h.AssertEq(t, len(deleted), 0)
+		})
+	})
+
+	when("#readAppIndex", func() {
+		it("reads the index written by writeAppIndex", func() {
+			indexPath := filepath.Join(appDir, "index", "app.json")
+			index := appIndex{
+				Volume: "some-volume",
+				UID:    1000,
+				GID:    1001,
+				Files:  map[string]appIndexEntry{"main.go": {Mode: 0644, Size: 12, Digest: "some-digest"}},
+			}
+			h.AssertNil(t, writeAppIndex(indexPath, index))
+
+			read, err := readAppIndex(indexPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, read.Volume, "some-volume")
+			h.AssertEq(t, read.UID, 1000)
+			h.AssertEq(t, read.Files["main.go"].Digest, "some-digest")
+		})
+
+		it("returns an empty index when there is none", func() {
+			index, err := readAppIndex(filepath.Join(appDir, "missing.json"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(index.Files), 0)
+		})
+	})
+
+	when("#lockAppIndex", func() {
+		it("waits for the index to be unlocked", func() {
+			indexPath := filepath.Join(appDir, "index", "app.json")
+			unlock, err := lockAppIndex(indexPath)
+			h.AssertNil(t, err)
+
+			locked := make(chan struct{})
+			go func() {
+				unlockAgain, err := lockAppIndex(indexPath)
+				h.AssertNil(t, err)
+				unlockAgain()
+				close(locked)
+			}()
+
+			select {
+			case <-locked:
+				t.Fatal("expected the index to stay locked")
+			case <-time.After(100 * time.Millisecond):
+			}
+
+			unlock()
+			select {
+			case <-locked:
+			case <-time.After(5 * time.Second):
+				t.Fatal("expected the index to be unlocked")
+			}
+		})
+	})
+}
@@ -272,7 +272,15 @@ func createReader(src, dst string, uid, gid int, includeRoot bool, fileFilter fu
 		return archive.ReadDirAsTar(src, dst, uid, gid, mode, false, includeRoot, fileFilter), nil
 	}
 
-	return archive.ReadZipAsTar(src, dst, uid, gid, -1, false, fileFilter), nil
+	isZip, err := archive.IsZip(src)
+	if err != nil {
+		return nil, err
+	}
+	if isZip {
+		return archive.ReadZipAsTar(src, dst, uid, gid, -1, false, fileFilter), nil
+	}
+
+	return archive.ReadTarAsTar(src, dst, uid, gid, -1, false, fileFilter), nil
 }
 
 // EnsureVolumeAccess grants full access permissions to volumes for UID/GID-based user
@@ -247,6 +247,45 @@ drwxrwxrwx    2 123      456 (.*) some-vol
 		})
 	})
 
+	when("#SyncDir", func() {
+		it("only copies changes into the source volume and removes deleted files", func() {
+			if osType == "windows" {
+				t.Skip("source volumes are only synced for Linux containers")
+			}
+
+			ctx := context.Background()
+			appDir := t.TempDir()
+			indexPath := filepath.Join(t.TempDir(), "index.json")
+			sourceVolume := "tests-source-volume-" + h.RandString(5)
+			defer ctrClient.VolumeRemove(ctx, sourceVolume, true)
+
+			h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "unchanged"), []byte("unchanged"), 0644))
+			h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "changed"), []byte("before"), 0644))
+			h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "deleted"), []byte("deleted"), 0644))
+
+			sync := func() string {
+				ctr, err := createContainer(ctx, imageName, "/some-vol", osType, "sh", "-c", "ls /some-vol && cat /some-vol/changed")
+				h.AssertNil(t, err)
+				defer cleanupContainer(ctx, ctr.ID)
+
+				var outBuf, errBuf bytes.Buffer
+				err = build.SyncDir(appDir, "/some-vol", sourceVolume, indexPath, 123, 456, osType, nil)(ctrClient, ctx, ctr.ID, &outBuf, &errBuf)
+				h.AssertNil(t, err)
+
+				err = container.RunWithHandler(ctx, ctrClient, ctr.ID, container.DefaultHandler(&outBuf, &errBuf))
+				h.AssertNil(t, err)
+				h.AssertEq(t, errBuf.String(), "")
+				return outBuf.String()
+			}
+
+			h.AssertEq(t, sync(), "changed\ndeleted\nunchanged\nbefore")
+
+			h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "changed"), []byte("after"), 0644))
+			h.AssertNil(t, os.Remove(filepath.Join(appDir, "deleted")))
+			h.AssertEq(t, sync(), "changed\nunchanged\nafter")
+		})
+	})
+
 	when("#CopyOut", func() {
 		it("reads the contents of a container directory", func() {
 			h.SkipIf(t, osType == "windows", "copying directories out of windows containers not yet supported")
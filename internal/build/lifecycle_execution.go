@@ -40,6 +40,8 @@ type LifecycleExecution struct {
 	mountPaths   mountPaths
 	opts         LifecycleOptions
 	tmpDir       string
+	secretsDir   string
+	secretBinds  []string
 }
 
 func NewLifecycleExecution(logger logging.Logger, docker DockerClient, tmpDir string, opts LifecycleOptions) (*LifecycleExecution, error) {
@@ -72,6 +74,15 @@ func NewLifecycleExecution(logger logging.Logger, docker DockerClient, tmpDir st
 		exec.logger = opts.Termui
 	}
 
+	if len(opts.Secrets) > 0 {
+		if opts.SecretsHostDir == "" {
+			return nil, errors.New("build secrets require a tmpfs on the Docker host to be written to")
+		}
+		if exec.secretsDir, exec.secretBinds, err = WriteSecrets(opts.SecretsHostDir, SecretsDir, opts.Secrets); err != nil {
+			return nil, err
+		}
+	}
+
 	return exec, nil
 }
 
@@ -123,6 +134,15 @@ func FindLatestSupported(builderapis []*api.Version, lifecycleapis []string) (*a
 	return nil, errors.New("unable to find a supported Platform API version")
 }
 
+// buildpackNetwork returns the network of the phases that run buildpacks, which is the egress network when egress is
+// restricted.
+func (l *LifecycleExecution) buildpackNetwork() string {
+	if l.opts.EgressNetwork != "" {
+		return l.opts.EgressNetwork
+	}
+	return l.opts.Network
+}
+
 func randString(n int) string {
 	b := make([]byte, n)
 	for i := range b {
@@ -289,12 +309,29 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 		return l.Export(ctx, buildCache, launchCache, kanikoCache, phaseFactory)
 	}
 
+	if len(l.opts.Secrets) > 0 {
+		return errors.New("build secrets are not supported when using the creator")
+	}
+
+	if l.opts.EgressProxy != "" {
+		return errors.New("egress policies are not supported when using the creator")
+	}
+
 	if l.platformAPI.AtLeast("0.10") && l.hasExtensions() && !l.opts.UseCreatorWithExtensions {
 		return errors.New("builder has an order for extensions which is not supported when using the creator; re-run without '--trust-builder' or re-tag builder to avoid trusting it")
 	}
 	return l.Create(ctx, buildCache, launchCache, phaseFactory)
 }
 
+// copyApp returns the operation copying the app source to the app volume, through the app source volume when the app
+// source is kept between builds
+func (l *LifecycleExecution) copyApp() ContainerOperation {
+	if l.opts.AppSourceVolume == "" {
+		return CopyDir(l.opts.AppPath, l.mountPaths.appDir(), l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, true, l.opts.FileFilter)
+	}
+	return SyncDir(l.opts.AppPath, l.mountPaths.appDir(), l.opts.AppSourceVolume, l.opts.AppSourceIndex, l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.opts.FileFilter)
+}
+
 func (l *LifecycleExecution) Cleanup() error {
 	var reterr error
 	if err := l.docker.VolumeRemove(context.Background(), l.layersVolume, true); err != nil {
@@ -306,6 +343,11 @@ func (l *LifecycleExecution) Cleanup() error {
 	if err := os.RemoveAll(l.tmpDir); err != nil {
 		reterr = errors.Wrapf(err, "failed to clean up working directory %s", l.tmpDir)
 	}
+	if l.secretsDir != "" {
+		if err := os.RemoveAll(l.secretsDir); err != nil {
+			reterr = errors.Wrapf(err, "failed to clean up secrets directory %s", l.secretsDir)
+		}
+	}
 	return reterr
 }
 
@@ -376,9 +418,10 @@ func (l *LifecycleExecution) Create(ctx context.Context, buildCache, launchCache
 		WithFlags(l.withLogLevel(flags...)...),
 		WithArgs(l.opts.Image.String()),
 		WithNetwork(l.opts.Network),
+		WithResources(l.opts.Resources),
 		cacheBindOp,
 		WithContainerOperations(WriteProjectMetadata(l.mountPaths.projectPath(), l.opts.ProjectMetadata, l.os)),
-		WithContainerOperations(CopyDir(l.opts.AppPath, l.mountPaths.appDir(), l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, true, l.opts.FileFilter)),
+		WithContainerOperations(l.copyApp()),
 		If(l.opts.SBOMDestinationDir != "", WithPostContainerRunOperations(
 			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
 			CopyOutTo(l.mountPaths.sbomDir(), l.opts.SBOMDestinationDir))),
@@ -434,12 +477,14 @@ func (l *LifecycleExecution) Detect(ctx context.Context, phaseFactory PhaseFacto
 		WithArgs(
 			l.withLogLevel()...,
 		),
-		WithNetwork(l.opts.Network),
+		WithNetwork(l.buildpackNetwork()),
+		WithResources(l.opts.Resources),
 		WithBinds(l.opts.Volumes...),
 		WithContainerOperations(
 			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
-			CopyDir(l.opts.AppPath, l.mountPaths.appDir(), l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, true, l.opts.FileFilter),
+			l.copyApp(),
 		),
+		l.withSecrets(),
 		WithFlags(flags...),
 		If(l.hasExtensions(), WithPostContainerRunOperations(
 			CopyOutToMaybe(filepath.Join(l.mountPaths.layersDir(), "analyzed.toml"), l.tmpDir))),
@@ -699,8 +744,10 @@ func (l *LifecycleExecution) Build(ctx context.Context, phaseFactory PhaseFactor
 		l,
 		WithLogPrefix("builder"),
 		WithArgs(l.withLogLevel()...),
-		WithNetwork(l.opts.Network),
+		WithNetwork(l.buildpackNetwork()),
+		WithResources(l.opts.Resources),
 		WithBinds(l.opts.Volumes...),
+		l.withSecrets(),
 		WithFlags(flags...),
 	)
 
@@ -882,6 +929,11 @@ func (l *LifecycleExecution) withLogLevel(args ...string) []string {
 	return args
 }
 
+// withSecrets makes build secrets available to a phase. Only the detector and builder should be given them.
+func (l *LifecycleExecution) withSecrets() PhaseConfigProviderOperation {
+	return If(len(l.secretBinds) > 0, WithBinds(l.secretBinds...))
+}
+
 func (l *LifecycleExecution) hasExtensions() bool {
 	return len(l.opts.Builder.OrderExtensions()) > 0
 }
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/buildpacks/lifecycle/api"
 	"github.com/buildpacks/lifecycle/platform/files"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/docker/client"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
@@ -57,6 +59,11 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 		providedTargetImage    = "some-target-image"
 		providedAdditionalTags = []string{"some-additional-tag1", "some-additional-tag2"}
 		providedVolumes        = []string{"some-mount-source:/some-mount-target"}
+		providedSecrets        []build.Secret
+		providedEgressProxy    string
+		providedEgressNetwork  string
+		providedResources      = build.ContainerResources{Memory: 1024, CapDrop: []string{"ALL"}}
+		providedExtraHosts     = []string{"some-host:127.0.0.1"}
 
 		// builder options
 		providedBuilderImage = "some-registry.com/some-namespace/some-builder-name"
@@ -90,6 +97,12 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 		opts.RunImage = providedRunImage
 		opts.UseCreator = providedUseCreator
 		opts.Volumes = providedVolumes
+		opts.Secrets = providedSecrets
+		opts.SecretsHostDir = tmpDir
+		opts.EgressProxy = providedEgressProxy
+		opts.EgressNetwork = providedEgressNetwork
+		opts.Resources = providedResources
+		opts.ExtraHosts = providedExtraHosts
 		opts.Layout = providedLayout
 		opts.Keychain = authn.DefaultKeychain
 		opts.UseCreatorWithExtensions = useCreatorWithExtensions
@@ -350,6 +363,32 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("there are secrets", func() {
+				providedUseCreator = true
+				providedSecrets = []build.Secret{{ID: "some-secret", Data: []byte("some-secret-value")}}
+
+				it("errors", func() {
+					err := lifecycle.Run(context.Background(), func(execution *build.LifecycleExecution) build.PhaseFactory {
+						return fakePhaseFactory
+					})
+					h.AssertError(t, err, "build secrets are not supported when using the creator")
+					h.AssertEq(t, len(fakePhaseFactory.NewCalledWithProvider), 0)
+				})
+			})
+
+			when("there is an egress proxy", func() {
+				providedUseCreator = true
+				providedEgressProxy = "some-egress-proxy"
+
+				it("errors", func() {
+					err := lifecycle.Run(context.Background(), func(execution *build.LifecycleExecution) build.PhaseFactory {
+						return fakePhaseFactory
+					})
+					h.AssertError(t, err, "egress policies are not supported when using the creator")
+					h.AssertEq(t, len(fakePhaseFactory.NewCalledWithProvider), 0)
+				})
+			})
+
 			when("there are extensions", func() {
 				providedUseCreator = true
 				providedOrderExt = dist.Order{dist.OrderEntry{Group: []dist.ModuleRef{ /* don't care */ }}}
@@ -818,6 +857,12 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			h.AssertEq(t, fakePhase.RunCallCount, 1)
 		})
 
+		it("configures the phase with the expected resources and hosts", func() {
+			h.AssertEq(t, configProvider.HostConfig().Memory, int64(1024))
+			h.AssertEq(t, configProvider.HostConfig().CapDrop, strslice.StrSlice{"ALL"})
+			h.AssertEq(t, configProvider.HostConfig().ExtraHosts, providedExtraHosts)
+		})
+
 		it("configures the phase with the expected arguments", func() {
 			h.AssertIncludeAllExpectedPatterns(t,
 				configProvider.ContainerConfig().Cmd,
@@ -1314,6 +1359,14 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			h.AssertEq(t, configProvider.HostConfig().NetworkMode, container.NetworkMode(providedNetworkMode))
 		})
 
+		when("there is an egress network", func() {
+			providedEgressNetwork = "some-egress-network"
+
+			it("configures the phase with the egress network", func() {
+				h.AssertEq(t, configProvider.HostConfig().NetworkMode, container.NetworkMode("some-egress-network"))
+			})
+		})
+
 		it("configures the phase to copy app dir", func() {
 			h.AssertSliceContains(t, configProvider.HostConfig().Binds, providedVolumes...)
 			h.AssertEq(t, len(configProvider.ContainerOps()), 2)
@@ -1321,6 +1374,26 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			h.AssertFunctionName(t, configProvider.ContainerOps()[1], "CopyDir")
 		})
 
+		it("configures the phase with the expected resources and hosts", func() {
+			h.AssertEq(t, configProvider.HostConfig().Memory, int64(1024))
+			h.AssertEq(t, configProvider.HostConfig().CapDrop, strslice.StrSlice{"ALL"})
+			h.AssertEq(t, configProvider.HostConfig().ExtraHosts, providedExtraHosts)
+		})
+
+		when("secrets are provided", func() {
+			providedSecrets = []build.Secret{{ID: "some-secret", Data: []byte("some-secret-value")}}
+
+			it("configures the phase to mount the secrets read-only", func() {
+				h.AssertEq(t, len(configProvider.ContainerOps()), 2)
+				h.AssertSliceContainsMatch(t, configProvider.HostConfig().Binds, `/pack\.secrets\.\d+/some-secret:/run/secrets/some-secret:ro$`)
+			})
+
+			it("does not expose the secrets in the container config", func() {
+				h.AssertNotContains(t, strings.Join(configProvider.ContainerConfig().Env, " "), "some-secret-value")
+				h.AssertNotContains(t, strings.Join(configProvider.HostConfig().Binds, " "), "some-secret-value")
+			})
+		})
+
 		when("extensions", func() {
 			platformAPI = api.MustParse("0.10")
 
@@ -1357,6 +1430,12 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			h.AssertEq(t, fakePhase.RunCallCount, 1)
 		})
 
+		it("configures the phase with hosts but without resource limits", func() {
+			h.AssertEq(t, configProvider.HostConfig().ExtraHosts, providedExtraHosts)
+			h.AssertEq(t, configProvider.HostConfig().Memory, int64(0))
+			h.AssertEq(t, len(configProvider.HostConfig().CapDrop), 0)
+		})
+
 		when("platform < 0.7", func() {
 			when("clear cache", func() {
 				providedClearCache = true
@@ -2018,9 +2097,37 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			h.AssertEq(t, configProvider.HostConfig().NetworkMode, container.NetworkMode(providedNetworkMode))
 		})
 
+		when("there is an egress network", func() {
+			providedEgressNetwork = "some-egress-network"
+
+			it("configures the phase with the egress network", func() {
+				h.AssertEq(t, configProvider.HostConfig().NetworkMode, container.NetworkMode("some-egress-network"))
+			})
+		})
+
 		it("configures the phase with binds", func() {
 			h.AssertSliceContains(t, configProvider.HostConfig().Binds, providedVolumes...)
 		})
+
+		it("configures the phase with the expected resources and hosts", func() {
+			h.AssertEq(t, configProvider.HostConfig().Memory, int64(1024))
+			h.AssertEq(t, configProvider.HostConfig().CapDrop, strslice.StrSlice{"ALL"})
+			h.AssertEq(t, configProvider.HostConfig().ExtraHosts, providedExtraHosts)
+		})
+
+		it("does not write any secrets", func() {
+			h.AssertEq(t, len(configProvider.ContainerOps()), 0)
+		})
+
+		when("secrets are provided", func() {
+			providedSecrets = []build.Secret{{ID: "some-secret", Data: []byte("some-secret-value")}}
+
+			it("configures the phase to mount the secrets read-only", func() {
+				h.AssertEq(t, len(configProvider.ContainerOps()), 0)
+				h.AssertSliceContainsMatch(t, configProvider.HostConfig().Binds, `/some-secret:/run/secrets/some-secret:ro$`)
+				h.AssertNotContains(t, strings.Join(configProvider.ContainerConfig().Env, " "), "some-secret-value")
+			})
+		})
 	})
 
 	when("#ExtendBuild", func() {
@@ -9,6 +9,7 @@ import (
 	"github.com/buildpacks/imgutil"
 	"github.com/buildpacks/lifecycle/api"
 	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/docker/go-units"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 
@@ -89,11 +90,15 @@ type LifecycleOptions struct {
 	HTTPProxy                       string
 	HTTPSProxy                      string
 	NoProxy                         string
+	EgressProxy                     string // replaces the proxies of the detector and builder; requires UseCreator to be false
+	EgressNetwork                   string // replaces Network for the detector and builder, so that EgressProxy is their only route out
 	Network                         string
 	AdditionalTags                  []string
 	Volumes                         []string
 	DefaultProcessType              string
 	FileFilter                      func(string) bool
+	AppSourceVolume                 string // optional - volume the app source is kept in between builds, see SyncDir
+	AppSourceIndex                  string // path of the index of the files in AppSourceVolume
 	Workspace                       string
 	GID                             int
 	UID                             int
@@ -102,6 +107,21 @@ type LifecycleOptions struct {
 	SBOMDestinationDir              string
 	CreationTime                    *time.Time
 	Keychain                        authn.Keychain
+	Secrets                         []Secret // only given to the detector and builder; requires UseCreator to be false
+	SecretsHostDir                  string   // tmpfs on the Docker host the secrets are written to, see WriteSecrets
+	Resources                       ContainerResources
+	ExtraHosts                      []string
+	DNS                             []string
+}
+
+// ContainerResources limits the containers in which buildpacks run: the detector, builder and creator.
+type ContainerResources struct {
+	Memory      int64 // in bytes
+	NanoCPUs    int64
+	PidsLimit   int64
+	Ulimits     []*units.Ulimit
+	SecurityOpt []string
+	CapDrop     []string
 }
 
 func NewLifecycleExecutor(logger logging.Logger, docker DockerClient) *LifecycleExecutor {
@@ -6,8 +6,10 @@ import (
 
 	dcontainer "github.com/docker/docker/api/types/container"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/pkg/tracing"
 )
 
 type Phase struct {
@@ -26,12 +28,21 @@ type Phase struct {
 	fileFilter          func(string) bool
 }
 
-func (p *Phase) Run(ctx context.Context) error {
-	var err error
+func (p *Phase) Run(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "lifecycle."+p.name,
+		attribute.String("pack.lifecycle.phase", p.name),
+		attribute.String("pack.lifecycle.image", p.ctrConf.Image),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	// propagate the trace context so the lifecycle may continue the trace
+	p.ctrConf.Env = append(p.ctrConf.Env, tracing.Env(ctx)...)
+
 	p.ctr, err = p.docker.ContainerCreate(ctx, p.ctrConf, p.hostConf, nil, nil, "")
 	if err != nil {
 		return errors.Wrapf(err, "failed to create '%s' container", p.name)
 	}
+	span.SetAttributes(attribute.String("pack.container.id", p.ctr.ID))
 
 	for _, containerOp := range p.containerOps {
 		if err := containerOp(p.docker, ctx, p.ctr.ID, p.infoWriter, p.errorWriter); err != nil {
@@ -39,8 +39,8 @@ func NewPhaseConfigProvider(name string, lifecycleExec *LifecycleExecution, ops
 		hostConf:    new(container.HostConfig),
 		name:        name,
 		os:          lifecycleExec.os,
-		infoWriter:  logging.GetWriterForLevel(lifecycleExec.logger, logging.InfoLevel),
-		errorWriter: logging.GetWriterForLevel(lifecycleExec.logger, logging.ErrorLevel),
+		infoWriter:  newRedactingWriter(logging.GetWriterForLevel(lifecycleExec.logger, logging.InfoLevel), lifecycleExec.opts.Secrets),
+		errorWriter: newRedactingWriter(logging.GetWriterForLevel(lifecycleExec.logger, logging.ErrorLevel), lifecycleExec.opts.Secrets),
 	}
 
 	provider.ctrConf.Image = lifecycleExec.opts.Builder.Name()
@@ -53,6 +53,7 @@ func NewPhaseConfigProvider(name string, lifecycleExec *LifecycleExecution, ops
 	ops = append(ops,
 		WithEnv(fmt.Sprintf("%s=%s", platformAPIEnvVar, lifecycleExec.platformAPI.String())),
 		WithLifecycleProxy(lifecycleExec),
+		WithHosts(lifecycleExec.opts.ExtraHosts, lifecycleExec.opts.DNS),
 		WithBinds([]string{
 			fmt.Sprintf("%s:%s", lifecycleExec.layersVolume, lifecycleExec.mountPaths.layersDir()),
 			fmt.Sprintf("%s:%s", lifecycleExec.appVolume, lifecycleExec.mountPaths.appDir()),
@@ -179,7 +180,7 @@ func WithDaemonAccess(dockerHost string) PhaseConfigProviderOperation {
 			provider.hostConf.Binds = append(provider.hostConf.Binds, bind)
 		}
 		if provider.os != "windows" {
-			provider.hostConf.SecurityOpt = []string{"label=disable"}
+			provider.hostConf.SecurityOpt = append(provider.hostConf.SecurityOpt, "label=disable")
 		}
 	}
 }
@@ -206,19 +207,49 @@ func WithLogPrefix(prefix string) PhaseConfigProviderOperation {
 	}
 }
 
+// WithLifecycleProxy sets the proxy environment variables of the phase. The detector and builder, which run buildpacks,
+// use the egress proxy instead when there is one, so that it sees all of their requests.
 func WithLifecycleProxy(lifecycleExec *LifecycleExecution) PhaseConfigProviderOperation {
 	return func(provider *PhaseConfigProvider) {
-		if lifecycleExec.opts.HTTPProxy != "" {
-			provider.ctrConf.Env = append(provider.ctrConf.Env, "HTTP_PROXY="+lifecycleExec.opts.HTTPProxy, "http_proxy="+lifecycleExec.opts.HTTPProxy)
+		httpProxy, httpsProxy, noProxy := lifecycleExec.opts.HTTPProxy, lifecycleExec.opts.HTTPSProxy, lifecycleExec.opts.NoProxy
+		if lifecycleExec.opts.EgressProxy != "" && (provider.name == "detector" || provider.name == "builder") {
+			httpProxy, httpsProxy, noProxy = lifecycleExec.opts.EgressProxy, lifecycleExec.opts.EgressProxy, ""
 		}
 
-		if lifecycleExec.opts.HTTPSProxy != "" {
-			provider.ctrConf.Env = append(provider.ctrConf.Env, "HTTPS_PROXY="+lifecycleExec.opts.HTTPSProxy, "https_proxy="+lifecycleExec.opts.HTTPSProxy)
+		if httpProxy != "" {
+			provider.ctrConf.Env = append(provider.ctrConf.Env, "HTTP_PROXY="+httpProxy, "http_proxy="+httpProxy)
 		}
 
-		if lifecycleExec.opts.NoProxy != "" {
-			provider.ctrConf.Env = append(provider.ctrConf.Env, "NO_PROXY="+lifecycleExec.opts.NoProxy, "no_proxy="+lifecycleExec.opts.NoProxy)
+		if httpsProxy != "" {
+			provider.ctrConf.Env = append(provider.ctrConf.Env, "HTTPS_PROXY="+httpsProxy, "https_proxy="+httpsProxy)
 		}
+
+		if noProxy != "" {
+			provider.ctrConf.Env = append(provider.ctrConf.Env, "NO_PROXY="+noProxy, "no_proxy="+noProxy)
+		}
+	}
+}
+
+// WithHosts sets additional /etc/hosts entries and DNS servers for the container
+func WithHosts(extraHosts, dns []string) PhaseConfigProviderOperation {
+	return func(provider *PhaseConfigProvider) {
+		provider.hostConf.ExtraHosts = append(provider.hostConf.ExtraHosts, extraHosts...)
+		provider.hostConf.DNS = append(provider.hostConf.DNS, dns...)
+	}
+}
+
+// WithResources limits the resources, capabilities and security options of the container
+func WithResources(resources ContainerResources) PhaseConfigProviderOperation {
+	return func(provider *PhaseConfigProvider) {
+		provider.hostConf.Memory = resources.Memory
+		provider.hostConf.NanoCPUs = resources.NanoCPUs
+		if resources.PidsLimit != 0 {
+			pidsLimit := resources.PidsLimit
+			provider.hostConf.PidsLimit = &pidsLimit
+		}
+		provider.hostConf.Ulimits = append(provider.hostConf.Ulimits, resources.Ulimits...)
+		provider.hostConf.SecurityOpt = append(provider.hostConf.SecurityOpt, resources.SecurityOpt...)
+		provider.hostConf.CapDrop = append(provider.hostConf.CapDrop, resources.CapDrop...)
 	}
 }
 
@@ -3,12 +3,14 @@ package build_test
 import (
 	"bytes"
 	"io"
+	"os"
 	"testing"
 
 	ifakes "github.com/buildpacks/imgutil/fakes"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/docker/client"
+	"github.com/docker/go-units"
 	"github.com/heroku/color"
 	"github.com/pkg/errors"
 	"github.com/sclevine/spec"
@@ -61,6 +63,32 @@ func testPhaseConfigProvider(t *testing.T, when spec.G, it spec.S) {
 			h.AssertEq(t, phaseConfigProvider.HostConfig().Isolation, container.IsolationEmpty)
 		})
 
+		when("there is an egress proxy", func() {
+			var lifecycle *build.LifecycleExecution
+
+			it.Before(func() {
+				lifecycle = newTestLifecycleExec(t, false, "some-temp-dir", func(opts *build.LifecycleOptions) {
+					opts.EgressProxy = "some-egress-proxy"
+				})
+			})
+
+			it("uses it instead of the proxies for the detector and builder", func() {
+				for _, phase := range []string{"detector", "builder"} {
+					env := build.NewPhaseConfigProvider(phase, lifecycle).ContainerConfig().Env
+
+					h.AssertSliceContains(t, env, "HTTP_PROXY=some-egress-proxy", "https_proxy=some-egress-proxy")
+					h.AssertSliceNotContains(t, env, "HTTP_PROXY=some-http-proxy", "NO_PROXY=some-no-proxy")
+				}
+			})
+
+			it("keeps the proxies for the other phases", func() {
+				env := build.NewPhaseConfigProvider("exporter", lifecycle).ContainerConfig().Env
+
+				h.AssertSliceContains(t, env, "HTTP_PROXY=some-http-proxy", "HTTPS_PROXY=some-https-proxy", "NO_PROXY=some-no-proxy")
+				h.AssertSliceNotContains(t, env, "HTTP_PROXY=some-egress-proxy")
+			})
+		})
+
 		when("building for Windows", func() {
 			it("sets process isolation", func() {
 				fakeBuilderImage := ifakes.NewImage("fake-builder", "", nil)
@@ -220,6 +248,61 @@ func testPhaseConfigProvider(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("called with WithHosts", func() {
+			it("sets extra hosts and DNS servers on the config", func() {
+				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir")
+
+				phaseConfigProvider := build.NewPhaseConfigProvider(
+					"some-name",
+					lifecycle,
+					build.WithHosts([]string{"some-host:127.0.0.1"}, []string{"8.8.8.8"}),
+				)
+
+				h.AssertEq(t, phaseConfigProvider.HostConfig().ExtraHosts, []string{"some-host:127.0.0.1"})
+				h.AssertEq(t, phaseConfigProvider.HostConfig().DNS, []string{"8.8.8.8"})
+			})
+		})
+
+		when("called with WithResources", func() {
+			it("sets resource limits and security options on the config", func() {
+				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir")
+				ulimit := &units.Ulimit{Name: "nofile", Soft: 1024, Hard: 2048}
+
+				phaseConfigProvider := build.NewPhaseConfigProvider(
+					"some-name",
+					lifecycle,
+					build.WithResources(build.ContainerResources{
+						Memory:      1024,
+						NanoCPUs:    1500000000,
+						PidsLimit:   512,
+						Ulimits:     []*units.Ulimit{ulimit},
+						SecurityOpt: []string{"no-new-privileges"},
+						CapDrop:     []string{"ALL"},
+					}),
+				)
+
+				hostConfig := phaseConfigProvider.HostConfig()
+				h.AssertEq(t, hostConfig.Memory, int64(1024))
+				h.AssertEq(t, hostConfig.NanoCPUs, int64(1500000000))
+				h.AssertEq(t, *hostConfig.PidsLimit, int64(512))
+				h.AssertEq(t, hostConfig.Ulimits, []*units.Ulimit{ulimit})
+				h.AssertEq(t, hostConfig.SecurityOpt, []string{"no-new-privileges"})
+				h.AssertEq(t, hostConfig.CapDrop, strslice.StrSlice{"ALL"})
+			})
+
+			it("does not set a pids limit when none is provided", func() {
+				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir")
+
+				phaseConfigProvider := build.NewPhaseConfigProvider(
+					"some-name",
+					lifecycle,
+					build.WithResources(build.ContainerResources{}),
+				)
+
+				h.AssertNil(t, phaseConfigProvider.HostConfig().PidsLimit)
+			})
+		})
+
 		when("called with WithRegistryAccess", func() {
 			it("sets registry access on the config", func() {
 				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir")
@@ -291,6 +374,52 @@ func testPhaseConfigProvider(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("there are secrets", func() {
+			it("redacts secret values from the phase output", func() {
+				var outBuf bytes.Buffer
+				logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+
+				docker, err := client.NewClientWithOpts(client.FromEnv, client.WithVersion("1.38"))
+				h.AssertNil(t, err)
+
+				defaultBuilder, err := fakes.NewFakeBuilder()
+				h.AssertNil(t, err)
+
+				secretsHostDir, err := os.MkdirTemp("", "secrets-host-dir")
+				h.AssertNil(t, err)
+				defer os.RemoveAll(secretsHostDir)
+
+				opts := build.LifecycleOptions{
+					AppPath: "some-app-path",
+					Builder: defaultBuilder,
+					Secrets: []build.Secret{
+						{ID: "some-token", Data: []byte("some-token-value\n")},
+						{ID: "some-file", Data: []byte("first-line\nsecond-line\n}\n")},
+					},
+					SecretsHostDir: secretsHostDir,
+				}
+
+				lifecycleExec, err := build.NewLifecycleExecution(logger, docker, "some-temp-dir", opts)
+				h.AssertNil(t, err)
+
+				phaseConfigProvider := build.NewPhaseConfigProvider(
+					"some-name",
+					lifecycleExec,
+					build.WithLogPrefix("some-prefix"),
+				)
+
+				_, err = phaseConfigProvider.InfoWriter().Write([]byte("token is some-token-value\nprinting second-line {}\n"))
+				h.AssertNil(t, err)
+				_, err = phaseConfigProvider.ErrorWriter().Write([]byte("failed with some-token-value\n"))
+				h.AssertNil(t, err)
+
+				h.AssertContains(t, outBuf.String(), "token is <redacted>")
+				h.AssertContains(t, outBuf.String(), "printing <redacted> {}")
+				h.AssertContains(t, outBuf.String(), "failed with <redacted>")
+				h.AssertNotContains(t, outBuf.String(), "some-token-value")
+			})
+		})
+
 		when("verbose", func() {
 			it("prints debug information about the phase", func() {
 				var outBuf bytes.Buffer
@@ -0,0 +1,105 @@
+package build
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// SecretsDir is the platform-defined directory in which build secrets are made available to buildpacks.
+// Each secret is a read-only file named after its ID.
+const SecretsDir = "/run/secrets"
+
+// minRedactedLineLength is the shortest line of a multi-line secret that will be redacted on its own;
+// shorter lines (e.g. closing braces) would otherwise mangle unrelated output.
+const minRedactedLineLength = 4
+
+// Secret is a value exposed to the detect and build phases only.
+type Secret struct {
+	ID   string
+	Data []byte
+}
+
+// WriteSecrets writes each secret to a new directory in hostDir and returns that directory, along with the binds that
+// mount each secret read-only in dir.
+//
+// hostDir must be a tmpfs on the Docker host, e.g. /dev/shm, so that secrets are never written to a disk, the layers
+// volume, caches or image. The Docker API offers no way to populate a tmpfs mount of the container itself before it
+// starts. The secrets are bound one by one, rather than their directory, so that the directory stays private to the
+// current user on the host while the files are readable by the build user in the container.
+func WriteSecrets(hostDir, dir string, secrets []Secret) (string, []string, error) {
+	secretsDir, err := os.MkdirTemp(hostDir, "pack.secrets.")
+	if err != nil {
+		return "", nil, errors.Wrap(err, "creating secrets directory")
+	}
+
+	var binds []string
+	for _, secret := range secrets {
+		secretPath := filepath.Join(secretsDir, secret.ID)
+		if err := os.WriteFile(secretPath, secret.Data, 0400); err != nil {
+			os.RemoveAll(secretsDir)
+			return "", nil, errors.Wrapf(err, "writing secret '%s'", secret.ID)
+		}
+		// readable by the build user, the directory keeps the file private on the host
+		if err := os.Chmod(secretPath, 0444); err != nil {
+			os.RemoveAll(secretsDir)
+			return "", nil, errors.Wrapf(err, "writing secret '%s'", secret.ID)
+		}
+		binds = append(binds, fmt.Sprintf("%s:%s:ro", secretPath, path.Join(dir, secret.ID)))
+	}
+	return secretsDir, binds, nil
+}
+
+// redactingWriter replaces any secret values in the written data. It expects whole lines to be written to it,
+// as the logging.PrefixWriter does.
+type redactingWriter struct {
+	out    io.Writer
+	values [][]byte
+}
+
+func newRedactingWriter(out io.Writer, secrets []Secret) io.Writer {
+	if len(secrets) == 0 {
+		return out
+	}
+
+	w := &redactingWriter{out: out}
+	for _, secret := range secrets {
+		w.add(bytes.TrimSpace(secret.Data))
+		for _, line := range bytes.Split(secret.Data, []byte("\n")) {
+			if line = bytes.TrimSpace(line); len(line) >= minRedactedLineLength {
+				w.add(line)
+			}
+		}
+	}
+	return w
+}
+
+func (w *redactingWriter) add(value []byte) {
+	if len(value) == 0 {
+		return
+	}
+	// longest values first, so that a whole secret is redacted before any of its lines
+	for i, existing := range w.values {
+		if len(value) > len(existing) {
+			w.values = append(w.values[:i], append([][]byte{value}, w.values[i:]...)...)
+			return
+		}
+	}
+	w.values = append(w.values, value)
+}
+
+func (w *redactingWriter) Write(data []byte) (int, error) {
+	redacted := data
+	for _, value := range w.values {
+		redacted = bytes.ReplaceAll(redacted, value, []byte("<redacted>"))
+	}
+	if _, err := w.out.Write(redacted); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
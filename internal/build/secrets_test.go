@@ -0,0 +1,73 @@
+package build_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/build"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSecrets(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "secrets", testSecrets, spec.Report(report.Terminal{}), spec.Parallel())
+}
+
+func testSecrets(t *testing.T, when spec.G, it spec.S) {
+	when("#WriteSecrets", func() {
+		var hostDir string
+
+		it.Before(func() {
+			if runtime.GOOS == "windows" {
+				t.Skip("secrets are not supported for Windows builds")
+			}
+
+			var err error
+			hostDir, err = os.MkdirTemp("", "secrets-host-dir")
+			h.AssertNil(t, err)
+		})
+
+		it.After(func() {
+			h.AssertNil(t, os.RemoveAll(hostDir))
+		})
+
+		it("writes the secrets to a private directory and binds them read-only", func() {
+			secretsDir, binds, err := build.WriteSecrets(hostDir, "/run/secrets", []build.Secret{
+				{ID: "some-secret", Data: []byte("some-secret-value")},
+				{ID: "other-secret", Data: []byte("other-secret-value")},
+			})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, filepath.Dir(secretsDir), hostDir)
+			h.AssertEq(t, binds, []string{
+				filepath.Join(secretsDir, "some-secret") + ":/run/secrets/some-secret:ro",
+				filepath.Join(secretsDir, "other-secret") + ":/run/secrets/other-secret:ro",
+			})
+
+			fi, err := os.Stat(secretsDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, fi.Mode().Perm(), os.FileMode(0700))
+
+			fi, err = os.Stat(filepath.Join(secretsDir, "some-secret"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, fi.Mode().Perm(), os.FileMode(0444))
+
+			contents, err := os.ReadFile(filepath.Join(secretsDir, "some-secret"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(contents), "some-secret-value")
+		})
+
+		it("fails when the host directory does not exist", func() {
+			_, _, err := build.WriteSecrets(filepath.Join(hostDir, "missing"), "/run/secrets", []build.Secret{{ID: "some-secret"}})
+			h.AssertError(t, err, "creating secrets directory")
+		})
+	})
+}
@@ -76,6 +76,7 @@ type Builder struct {
 	lifecycleDescriptor  LifecycleDescriptor
 	additionalBuildpacks buildpack.ManagedCollection
 	additionalExtensions buildpack.ManagedCollection
+	removedBuildpacks    []dist.ModuleInfo
 	metadata             Metadata
 	mixins               []string
 	env                  map[string]string
@@ -85,6 +86,15 @@ type Builder struct {
 	order                dist.Order
 	orderExtensions      dist.Order
 	validateMixins       bool
+	saved                *savedLayers
+}
+
+// savedLayers holds the contents of the files an existing builder image was saved with, so that their layers are only
+// added again when the contents change.
+type savedLayers struct {
+	stack string
+	run   string
+	order *string // nil when the order of the image cannot be resolved
 }
 
 type orderTOML struct {
@@ -118,9 +128,10 @@ func New(baseImage imgutil.Image, name string, ops ...BuilderOption) (*Builder,
 
 func constructBuilder(img imgutil.Image, newName string, errOnMissingLabel bool, ops ...BuilderOption) (*Builder, error) {
 	var metadata Metadata
-	if ok, err := dist.GetLabel(img, metadataLabel, &metadata); err != nil {
+	isBuilder, err := dist.GetLabel(img, metadataLabel, &metadata)
+	if err != nil {
 		return nil, errors.Wrapf(err, "getting label %s", metadataLabel)
-	} else if !ok && errOnMissingLabel {
+	} else if !isBuilder && errOnMissingLabel {
 		return nil, fmt.Errorf("builder %s missing label %s -- try recreating builder", style.Symbol(img.Name()), style.Symbol(metadataLabel))
 	}
 
@@ -164,6 +175,12 @@ func constructBuilder(img imgutil.Image, newName string, errOnMissingLabel bool,
 		return nil, errors.Wrap(err, "adding image labels to builder")
 	}
 
+	if isBuilder {
+		if bldr.saved, err = bldr.savedLayers(); err != nil {
+			return nil, err
+		}
+	}
+
 	if newName != "" && img.Name() != newName {
 		img.Rename(newName)
 	}
@@ -345,6 +362,19 @@ func (b *Builder) AddBuildpacks(main buildpack.BuildModule, dependencies []build
 	}
 }
 
+// RemoveBuildpack removes a buildpack from the builder. Unless the same version is added again, its layer is hidden by
+// a whiteout layer when the builder is saved.
+func (b *Builder) RemoveBuildpack(info dist.ModuleInfo) {
+	var buildpacks []dist.ModuleInfo
+	for _, bp := range b.metadata.Buildpacks {
+		if bp.ID != info.ID || bp.Version != info.Version {
+			buildpacks = append(buildpacks, bp)
+		}
+	}
+	b.metadata.Buildpacks = buildpacks
+	b.removedBuildpacks = append(b.removedBuildpacks, info)
+}
+
 // AddExtension adds an extension to the builder
 func (b *Builder) AddExtension(bp buildpack.BuildModule) {
 	b.additionalExtensions.AddModules(bp)
@@ -431,12 +461,14 @@ func (b *Builder) Save(logger logging.Logger, creatorMetadata CreatorMetadata) e
 	}
 	defer os.RemoveAll(tmpDir)
 
-	dirsTar, err := b.defaultDirsLayer(tmpDir)
-	if err != nil {
-		return err
-	}
-	if err := b.image.AddLayer(dirsTar); err != nil {
-		return errors.Wrap(err, "adding default dirs layer")
+	if b.saved == nil {
+		dirsTar, err := b.defaultDirsLayer(tmpDir)
+		if err != nil {
+			return err
+		}
+		if err := b.image.AddLayer(dirsTar); err != nil {
+			return errors.Wrap(err, "adding default dirs layer")
+		}
 	}
 
 	if b.lifecycle != nil {
@@ -468,6 +500,10 @@ func (b *Builder) Save(logger logging.Logger, creatorMetadata CreatorMetadata) e
 		return errors.Wrapf(err, "getting label %s", dist.BuildpackLayersLabel)
 	}
 
+	if err := b.removeBuildpacks(logger, tmpDir, bpLayers); err != nil {
+		return err
+	}
+
 	var excludedBuildpacks []buildpack.BuildModule
 	excludedBuildpacks, err = b.addFlattenedModules(buildpack.KindBuildpack, logger, tmpDir, b.image, b.additionalBuildpacks.FlattenedModules(), bpLayers)
 	if err != nil {
@@ -503,21 +539,19 @@ func (b *Builder) Save(logger logging.Logger, creatorMetadata CreatorMetadata) e
 	}
 
 	if b.replaceOrder {
-		resolvedOrderBp, err := processOrder(b.metadata.Buildpacks, b.order, buildpack.KindBuildpack)
-		if err != nil {
-			return errors.Wrap(err, "processing buildpacks order")
-		}
-		resolvedOrderExt, err := processOrder(b.metadata.Extensions, b.orderExtensions, buildpack.KindExtension)
-		if err != nil {
-			return errors.Wrap(err, "processing extensions order")
-		}
-
-		orderTar, err := b.orderLayer(resolvedOrderBp, resolvedOrderExt, tmpDir)
+		orderContents, err := b.resolvedOrderFileContents()
 		if err != nil {
 			return err
 		}
-		if err := b.image.AddLayer(orderTar); err != nil {
-			return errors.Wrap(err, "adding order.tar layer")
+
+		if b.saved == nil || b.saved.order == nil || *b.saved.order != orderContents {
+			orderTar, err := b.orderLayer(orderContents, tmpDir)
+			if err != nil {
+				return err
+			}
+			if err := b.image.AddLayer(orderTar); err != nil {
+				return errors.Wrap(err, "adding order.tar layer")
+			}
 		}
 		if err := dist.SetLabel(b.image, OrderLabel, b.order); err != nil {
 			return err
@@ -527,20 +561,32 @@ func (b *Builder) Save(logger logging.Logger, creatorMetadata CreatorMetadata) e
 		}
 	}
 
-	stackTar, err := b.stackLayer(tmpDir)
+	stackContents, err := stackFileContents(b.metadata)
 	if err != nil {
 		return err
 	}
-	if err := b.image.AddLayer(stackTar); err != nil {
-		return errors.Wrap(err, "adding stack.tar layer")
+	if b.saved == nil || b.saved.stack != stackContents {
+		stackTar, err := b.stackLayer(tmpDir)
+		if err != nil {
+			return err
+		}
+		if err := b.image.AddLayer(stackTar); err != nil {
+			return errors.Wrap(err, "adding stack.tar layer")
+		}
 	}
 
-	runImageTar, err := b.runImageLayer(tmpDir)
+	runContents, err := runFileContents(b.metadata)
 	if err != nil {
 		return err
 	}
-	if err := b.image.AddLayer(runImageTar); err != nil {
-		return errors.Wrap(err, "adding run.tar layer")
+	if b.saved == nil || b.saved.run != runContents {
+		runImageTar, err := b.runImageLayer(tmpDir)
+		if err != nil {
+			return err
+		}
+		if err := b.image.AddLayer(runImageTar); err != nil {
+			return errors.Wrap(err, "adding run.tar layer")
+		}
 	}
 
 	if len(b.buildConfigEnv) > 0 {
@@ -559,13 +605,15 @@ func (b *Builder) Save(logger logging.Logger, creatorMetadata CreatorMetadata) e
 		logger.Debugf("Provided Environment Variables\n  %s", style.Map(b.env, "  ", "\n"))
 	}
 
-	envTar, err := b.envLayer(tmpDir, b.env)
-	if err != nil {
-		return err
-	}
+	if b.saved == nil || len(b.env) > 0 {
+		envTar, err := b.envLayer(tmpDir, b.env)
+		if err != nil {
+			return err
+		}
 
-	if err := b.image.AddLayer(envTar); err != nil {
-		return errors.Wrap(err, "adding env layer")
+		if err := b.image.AddLayer(envTar); err != nil {
+			return errors.Wrap(err, "adding env layer")
+		}
 	}
 
 	if creatorMetadata.Name == "" {
@@ -591,6 +639,59 @@ func (b *Builder) Save(logger logging.Logger, creatorMetadata CreatorMetadata) e
 
 // Helpers
 
+// savedLayers returns the contents of the files the builder image was saved with.
+func (b *Builder) savedLayers() (*savedLayers, error) {
+	stackContents, err := stackFileContents(b.metadata)
+	if err != nil {
+		return nil, err
+	}
+	runContents, err := runFileContents(b.metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	saved := &savedLayers{stack: stackContents, run: runContents}
+	if orderContents, err := b.resolvedOrderFileContents(); err == nil {
+		saved.order = &orderContents
+	}
+	return saved, nil
+}
+
+func (b *Builder) removeBuildpacks(logger logging.Logger, tmpDir string, layers dist.ModuleLayers) error {
+	for i, info := range b.removedBuildpacks {
+		if hasModule(b.metadata.Buildpacks, info) {
+			continue
+		}
+		if _, ok := layers[info.ID][info.Version]; !ok {
+			continue
+		}
+
+		logger.Debugf("Removing buildpack %s", style.Symbol(info.FullName()))
+		whiteoutsTar, err := b.whiteoutLayer(filepath.Join(tmpDir, "removed"), i, info)
+		if err != nil {
+			return err
+		}
+		if err := b.image.AddLayer(whiteoutsTar); err != nil {
+			return errors.Wrap(err, "adding whiteout layer tar")
+		}
+
+		delete(layers[info.ID], info.Version)
+		if len(layers[info.ID]) == 0 {
+			delete(layers, info.ID)
+		}
+	}
+	return nil
+}
+
+func hasModule(moduleList []dist.ModuleInfo, info dist.ModuleInfo) bool {
+	for _, module := range moduleList {
+		if module.ID == info.ID && module.Version == info.Version {
+			return true
+		}
+	}
+	return false
+}
+
 func (b *Builder) addExplodedModules(kind string, logger logging.Logger, tmpDir string, image imgutil.Image, additionalModules []buildpack.BuildModule, layers dist.ModuleLayers) error {
 	collectionToAdd := map[string]moduleWithDiffID{}
 	toAdd, errs := explodeModules(kind, tmpDir, additionalModules, logger)
@@ -1041,14 +1142,9 @@ func (b *Builder) embedLifecycleTar(tw archive.TarWriter) error {
 	return nil
 }
 
-func (b *Builder) orderLayer(order dist.Order, orderExt dist.Order, dest string) (string, error) {
-	contents, err := orderFileContents(order, orderExt)
-	if err != nil {
-		return "", err
-	}
-
+func (b *Builder) orderLayer(contents string, dest string) (string, error) {
 	layerTar := filepath.Join(dest, "order.tar")
-	err = layer.CreateSingleFileTar(layerTar, orderPath, contents, b.layerWriterFactory)
+	err := layer.CreateSingleFileTar(layerTar, orderPath, contents, b.layerWriterFactory)
 	if err != nil {
 		return "", errors.Wrapf(err, "failed to create order.toml layer tar")
 	}
@@ -1056,6 +1152,20 @@ func (b *Builder) orderLayer(order dist.Order, orderExt dist.Order, dest string)
 	return layerTar, nil
 }
 
+// resolvedOrderFileContents returns the contents of order.toml for the order of the builder, with the versions of the
+// modules on the builder filled in.
+func (b *Builder) resolvedOrderFileContents() (string, error) {
+	resolvedOrderBp, err := processOrder(b.metadata.Buildpacks, b.order, buildpack.KindBuildpack)
+	if err != nil {
+		return "", errors.Wrap(err, "processing buildpacks order")
+	}
+	resolvedOrderExt, err := processOrder(b.metadata.Extensions, b.orderExtensions, buildpack.KindExtension)
+	if err != nil {
+		return "", errors.Wrap(err, "processing extensions order")
+	}
+	return orderFileContents(resolvedOrderBp, resolvedOrderExt)
+}
+
 func orderFileContents(order dist.Order, orderExt dist.Order) (string, error) {
 	buf := &bytes.Buffer{}
 	tomlData := orderTOML{Order: order, OrderExt: orderExt}
@@ -1066,19 +1176,13 @@ func orderFileContents(order dist.Order, orderExt dist.Order) (string, error) {
 }
 
 func (b *Builder) stackLayer(dest string) (string, error) {
-	buf := &bytes.Buffer{}
-	var err error
-	if b.metadata.Stack.RunImage.Image != "" {
-		err = toml.NewEncoder(buf).Encode(b.metadata.Stack)
-	} else if len(b.metadata.RunImages) > 0 {
-		err = toml.NewEncoder(buf).Encode(StackMetadata{RunImage: b.metadata.RunImages[0]})
-	}
+	contents, err := stackFileContents(b.metadata)
 	if err != nil {
-		return "", errors.Wrapf(err, "failed to marshal stack.toml")
+		return "", err
 	}
 
 	layerTar := filepath.Join(dest, "stack.tar")
-	err = layer.CreateSingleFileTar(layerTar, stackPath, buf.String(), b.layerWriterFactory)
+	err = layer.CreateSingleFileTar(layerTar, stackPath, contents, b.layerWriterFactory)
 	if err != nil {
 		return "", errors.Wrapf(err, "failed to create stack.toml layer tar")
 	}
@@ -1086,17 +1190,28 @@ func (b *Builder) stackLayer(dest string) (string, error) {
 	return layerTar, nil
 }
 
-func (b *Builder) runImageLayer(dest string) (string, error) {
+func stackFileContents(metadata Metadata) (string, error) {
 	buf := &bytes.Buffer{}
-	err := toml.NewEncoder(buf).Encode(RunImages{
-		Images: b.metadata.RunImages,
-	})
+	var err error
+	if metadata.Stack.RunImage.Image != "" {
+		err = toml.NewEncoder(buf).Encode(metadata.Stack)
+	} else if len(metadata.RunImages) > 0 {
+		err = toml.NewEncoder(buf).Encode(StackMetadata{RunImage: metadata.RunImages[0]})
+	}
 	if err != nil {
-		return "", errors.Wrapf(err, "failed to marshal run.toml")
+		return "", errors.Wrapf(err, "failed to marshal stack.toml")
+	}
+	return buf.String(), nil
+}
+
+func (b *Builder) runImageLayer(dest string) (string, error) {
+	contents, err := runFileContents(b.metadata)
+	if err != nil {
+		return "", err
 	}
 
 	layerTar := filepath.Join(dest, "run.tar")
-	err = layer.CreateSingleFileTar(layerTar, runPath, buf.String(), b.layerWriterFactory)
+	err = layer.CreateSingleFileTar(layerTar, runPath, contents, b.layerWriterFactory)
 	if err != nil {
 		return "", errors.Wrapf(err, "failed to create run.toml layer tar")
 	}
@@ -1104,6 +1219,17 @@ func (b *Builder) runImageLayer(dest string) (string, error) {
 	return layerTar, nil
 }
 
+func runFileContents(metadata Metadata) (string, error) {
+	buf := &bytes.Buffer{}
+	err := toml.NewEncoder(buf).Encode(RunImages{
+		Images: metadata.RunImages,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to marshal run.toml")
+	}
+	return buf.String(), nil
+}
+
 func (b *Builder) envLayer(dest string, env map[string]string) (string, error) {
 	fh, err := os.Create(filepath.Join(dest, "env.tar"))
 	if err != nil {
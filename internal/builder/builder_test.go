@@ -1402,6 +1402,106 @@ func testBuilder(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("#RemoveBuildpack", func() {
+			var updated *builder.Builder
+
+			it.Before(func() {
+				subject.AddBuildpack(bp1v1)
+				subject.AddBuildpack(bp2v1)
+				h.AssertNil(t, subject.Save(logger, builder.CreatorMetadata{}))
+
+				var err error
+				updated, err = builder.FromImage(baseImage)
+				h.AssertNil(t, err)
+			})
+
+			it("hides the layer of the removed buildpack", func() {
+				updated.RemoveBuildpack(bp1v1.Descriptor().Info())
+				updated.AddBuildpack(bp1v2)
+				h.AssertNil(t, updated.Save(logger, builder.CreatorMetadata{}))
+
+				_, err := baseImage.FindLayerWithPath("/cnb/buildpacks/buildpack-1-id/.wh.buildpack-1-version-1")
+				h.AssertNil(t, err)
+				assertImageHasBPLayer(t, baseImage, bp1v2)
+
+				var metadata builder.Metadata
+				label, err := baseImage.Label("io.buildpacks.builder.metadata")
+				h.AssertNil(t, err)
+				h.AssertNil(t, json.Unmarshal([]byte(label), &metadata))
+				h.AssertEq(t, metadata.Buildpacks, []dist.ModuleInfo{
+					bp2v1.Descriptor().Info(),
+					bp1v2.Descriptor().Info(),
+				})
+
+				var layers dist.ModuleLayers
+				label, err = baseImage.Label("io.buildpacks.buildpack.layers")
+				h.AssertNil(t, err)
+				h.AssertNil(t, json.Unmarshal([]byte(label), &layers))
+				_, ok := layers["buildpack-1-id"]["buildpack-1-version-1"]
+				h.AssertEq(t, ok, false)
+				_, ok = layers["buildpack-1-id"]["buildpack-1-version-2"]
+				h.AssertEq(t, ok, true)
+			})
+
+			it("keeps the layer of a buildpack that is added again", func() {
+				layersBefore := baseImage.NumberOfAddedLayers()
+
+				updated.RemoveBuildpack(bp1v1.Descriptor().Info())
+				updated.AddBuildpack(bp1v1)
+				h.AssertNil(t, updated.Save(logger, builder.CreatorMetadata{}))
+
+				_, err := baseImage.FindLayerWithPath("/cnb/buildpacks/buildpack-1-id/.wh.buildpack-1-version-1")
+				h.AssertNotNil(t, err)
+
+				h.AssertEq(t, baseImage.NumberOfAddedLayers()-layersBefore, 0)
+			})
+		})
+
+		when("saving a builder image again", func() {
+			var updated *builder.Builder
+
+			it.Before(func() {
+				subject.AddBuildpack(bp1v1)
+				subject.SetOrder(dist.Order{{Group: []dist.ModuleRef{{ModuleInfo: dist.ModuleInfo{ID: "buildpack-1-id"}}}}})
+				h.AssertNil(t, subject.Save(logger, builder.CreatorMetadata{}))
+
+				var err error
+				updated, err = builder.FromImage(baseImage)
+				h.AssertNil(t, err)
+			})
+
+			it("does not add the layers whose contents are unchanged", func() {
+				layersBefore := baseImage.NumberOfAddedLayers()
+
+				updated.SetOrder(updated.Order())
+				h.AssertNil(t, updated.Save(logger, builder.CreatorMetadata{}))
+
+				h.AssertEq(t, baseImage.NumberOfAddedLayers()-layersBefore, 0)
+			})
+
+			it("adds the layers whose contents changed", func() {
+				layersBefore := baseImage.NumberOfAddedLayers()
+
+				updated.SetRunImage(pubbldr.RunConfig{Images: []pubbldr.RunImageConfig{{Image: "some/other-run"}}})
+				h.AssertNil(t, updated.Save(logger, builder.CreatorMetadata{}))
+
+				// the stack and run image layers
+				h.AssertEq(t, baseImage.NumberOfAddedLayers()-layersBefore, 2)
+			})
+
+			it("adds the order layer when the versions the order resolves to change", func() {
+				layersBefore := baseImage.NumberOfAddedLayers()
+
+				updated.RemoveBuildpack(bp1v1.Descriptor().Info())
+				updated.AddBuildpack(bp1v2)
+				updated.SetOrder(updated.Order())
+				h.AssertNil(t, updated.Save(logger, builder.CreatorMetadata{}))
+
+				// the whiteout, buildpack and order layers
+				h.AssertEq(t, baseImage.NumberOfAddedLayers()-layersBefore, 3)
+			})
+		})
+
 		when("#SetOrder", func() {
 			when("the buildpacks exist in the image", func() {
 				it.Before(func() {
@@ -66,6 +66,9 @@ Trusted: {{.Trusted}}
 {{- end }}
 {{- if ne .OrderExtensions "" }}
 {{ .OrderExtensions }}
+{{- end }}
+{{- if ne .Freshness "" }}
+{{ .Freshness }}
 {{- end }}`
 )
 
@@ -159,6 +162,16 @@ func writeBuilderInfo(
 		}
 	}
 
+	var freshnessString string
+	var freshnessWarnings []string
+
+	if info.Freshness != nil {
+		freshnessString, freshnessWarnings, err = freshnessOutput(info.Freshness)
+		if err != nil {
+			return fmt.Errorf("compiling freshness output: %w", err)
+		}
+	}
+
 	warnings = append(warnings, runImagesWarnings...)
 	warnings = append(warnings, orderWarnings...)
 	warnings = append(warnings, buildpacksWarnings...)
@@ -167,6 +180,7 @@ func writeBuilderInfo(
 		warnings = append(warnings, extensionsWarnings...)
 		warnings = append(warnings, orderExtWarnings...)
 	}
+	warnings = append(warnings, freshnessWarnings...)
 	outputTemplate, _ := template.New("").Parse(outputTemplate)
 
 	err = outputTemplate.Execute(
@@ -181,6 +195,7 @@ func writeBuilderInfo(
 			Lifecycle       string
 			Extensions      string
 			OrderExtensions string
+			Freshness       string
 		}{
 			*info,
 			logger.IsVerbose(),
@@ -191,6 +206,7 @@ func writeBuilderInfo(
 			lifecycleString,
 			extensionsString,
 			orderExtString,
+			freshnessString,
 		},
 	)
 
@@ -389,6 +405,73 @@ func buildpacksOutput(buildpacks []dist.ModuleInfo, builderName string) (string,
 	return output, []string{}, nil
 }
 
+func freshnessOutput(freshness *client.BuilderFreshness) (string, []string, error) {
+	output := "Freshness:\n"
+
+	var (
+		warnings             []string
+		tabWriterBuf         = bytes.Buffer{}
+		spaceStrippingWriter = &trailingSpaceStrippingWriter{
+			output: &tabWriterBuf,
+		}
+		freshnessTabWriter = tabwriter.NewWriter(spaceStrippingWriter, writerMinWidth, writerPadChar, defaultTabWidth, writerPadChar, writerFlags)
+	)
+
+	_, err := fmt.Fprint(freshnessTabWriter, "  TYPE\tNAME\tCURRENT\tLATEST\tSTATUS\n")
+	if err != nil {
+		return "", []string{}, fmt.Errorf("writing to tab writer: %w", err)
+	}
+
+	images := append([]client.ImageFreshness{freshness.Builder}, freshness.RunImages...)
+	for i, img := range images {
+		kind := "run image"
+		if i == 0 {
+			kind = "builder"
+		}
+		_, err = fmt.Fprintf(freshnessTabWriter, "  %s\t%s\t%s\t%s\t%s\n", kind, img.Name, shortDigest(img.Digest), shortDigest(img.LatestDigest), img.Status)
+		if err != nil {
+			return "", []string{}, fmt.Errorf("writing to tab writer: %w", err)
+		}
+	}
+
+	lifecycle := freshness.Lifecycle
+	_, err = fmt.Fprintf(freshnessTabWriter, "  lifecycle\t-\t%s\t%s\t%s\n", strs.ValueOrDefault(lifecycle.Version, "-"), lifecycle.LatestVersion, lifecycle.Status)
+	if err != nil {
+		return "", []string{}, fmt.Errorf("writing to tab writer: %w", err)
+	}
+
+	for _, bp := range freshness.Buildpacks {
+		_, err = fmt.Fprintf(freshnessTabWriter, "  buildpack\t%s\t%s\t%s\t%s\n", bp.ID, bp.Version, strs.ValueOrDefault(bp.LatestVersion, "-"), bp.Status)
+		if err != nil {
+			return "", []string{}, fmt.Errorf("writing to tab writer: %w", err)
+		}
+		if bp.Status == client.FreshnessYanked {
+			warnings = append(warnings, fmt.Sprintf("buildpack %s was yanked from registry %s", style.Symbol(bp.ID+"@"+bp.Version), style.Symbol(bp.Registry)))
+		}
+	}
+
+	err = freshnessTabWriter.Flush()
+	if err != nil {
+		return "", []string{}, fmt.Errorf("flushing tab writer: %w", err)
+	}
+
+	output += tabWriterBuf.String()
+	return output, warnings, nil
+}
+
+// shortDigest abbreviates a digest to the first 12 characters of its hash
+func shortDigest(digest string) string {
+	if digest == "" {
+		return "-"
+	}
+
+	algorithm, hash, found := strings.Cut(digest, ":")
+	if !found || len(hash) <= 12 {
+		return digest
+	}
+	return algorithm + ":" + hash[:12]
+}
+
 const lifecycleFormat = `
 Lifecycle:
   Version: %s
@@ -3,6 +3,7 @@ package writer_test
 import (
 	"bytes"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/Masterminds/semver"
@@ -659,5 +660,28 @@ REMOTE:
 				assert.Contains(outBuf.String(), expectedEmptyOrderExt)
 			})
 		})
+
+		when("freshness is checked", func() {
+			it("displays the freshness of each part of the builder and warns about yanked buildpacks", func() {
+				localInfo.Freshness = freshness
+
+				humanReadableWriter := writer.NewHumanReadable()
+
+				logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+				err := humanReadableWriter.Print(logger, localRunImages, localInfo, remoteInfo, nil, nil, sharedBuilderInfo)
+				assert.Nil(err)
+
+				assert.Contains(outBuf.String(), `Freshness:
+  TYPE         NAME              CURRENT                LATEST                 STATUS
+  builder      test-builder      sha256:111111111111    sha256:222222222222    outdated
+  run image    some/run-image    sha256:333333333333    sha256:333333333333    current
+  lifecycle    -                 0.17.0                 0.18.5                 outdated
+  buildpack    test.bp.one       test.bp.one.version    -                      unknown
+  buildpack    example/bp        1.0.0                  1.1.0                  yanked
+`)
+				assert.Contains(outBuf.String(), "Warning: buildpack 'example/bp@1.0.0' was yanked from registry 'official'")
+				assert.Equal(strings.Count(outBuf.String(), "Freshness:"), 1)
+			})
+		})
 	})
 }
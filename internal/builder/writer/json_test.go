@@ -500,6 +500,30 @@ func testJSON(t *testing.T, when spec.G, it spec.S) {
 				assert.ContainsJSON(prettifiedJSON, `{"detection_order": []}`)
 			})
 		})
+
+		when("freshness is checked", func() {
+			it("displays the freshness of each part of the builder", func() {
+				localInfo.Freshness = freshness
+
+				jsonWriter := writer.NewJSON()
+
+				logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+				err := jsonWriter.Print(logger, localRunImages, localInfo, remoteInfo, nil, nil, sharedBuilderInfo)
+				assert.Nil(err)
+
+				prettifiedJSON, err := validPrettifiedJSONOutput(outBuf)
+				assert.Nil(err)
+
+				assert.ContainsJSON(prettifiedJSON, `{"lifecycle": {"version": "0.17.0", "latest_version": "0.18.5", "status": "outdated"}}`)
+				assert.ContainsJSON(prettifiedJSON, `{"builder": {
+  "name": "test-builder",
+  "digest": "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+  "latest_digest": "sha256:2222222222222222222222222222222222222222222222222222222222222222",
+  "status": "outdated"
+}}`)
+				assert.ContainsJSON(prettifiedJSON, `{"id": "example/bp", "version": "1.0.0", "latest_version": "1.1.0", "registry": "official", "status": "yanked"}`)
+			})
+		})
 	})
 }
 
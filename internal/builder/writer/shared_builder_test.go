@@ -4,6 +4,7 @@ import (
 	pubbldr "github.com/buildpacks/pack/builder"
 	"github.com/buildpacks/pack/internal/builder/writer"
 	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/dist"
 )
 
@@ -136,4 +137,24 @@ var (
 	localRunImages = []config.RunImage{
 		{Image: "some/run-image", Mirrors: []string{"first/local", "second/local"}},
 	}
+
+	freshness = &client.BuilderFreshness{
+		Builder: client.ImageFreshness{
+			Name:         "test-builder",
+			Digest:       "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+			LatestDigest: "sha256:2222222222222222222222222222222222222222222222222222222222222222",
+			Status:       client.FreshnessOutdated,
+		},
+		RunImages: []client.ImageFreshness{{
+			Name:         "some/run-image",
+			Digest:       "sha256:3333333333333333333333333333333333333333333333333333333333333333",
+			LatestDigest: "sha256:3333333333333333333333333333333333333333333333333333333333333333",
+			Status:       client.FreshnessCurrent,
+		}},
+		Lifecycle: client.LifecycleFreshness{Version: "0.17.0", LatestVersion: "0.18.5", Status: client.FreshnessOutdated},
+		Buildpacks: []client.BuildpackFreshness{
+			{ID: "test.bp.one", Version: "test.bp.one.version", Status: client.FreshnessUnknown},
+			{ID: "example/bp", Version: "1.0.0", LatestVersion: "1.1.0", Registry: "official", Status: client.FreshnessYanked},
+		},
+	}
 )
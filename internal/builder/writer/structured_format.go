@@ -45,6 +45,35 @@ type BuilderInfo struct {
 	pubbldr.DetectionOrder `json:"detection_order" yaml:"detection_order" toml:"detection_order"`
 	Extensions             []dist.ModuleInfo      `json:"extensions,omitempty" yaml:"extensions,omitempty" toml:"extensions,omitempty"`
 	OrderExtensions        pubbldr.DetectionOrder `json:"order_extensions,omitempty" yaml:"order_extensions,omitempty" toml:"order_extensions,omitempty"`
+	Freshness              *Freshness             `json:"freshness,omitempty" yaml:"freshness,omitempty" toml:"freshness,omitempty"`
+}
+
+type Freshness struct {
+	Builder    ImageFreshness       `json:"builder" yaml:"builder" toml:"builder"`
+	RunImages  []ImageFreshness     `json:"run_images" yaml:"run_images" toml:"run_images"`
+	Lifecycle  LifecycleFreshness   `json:"lifecycle" yaml:"lifecycle" toml:"lifecycle"`
+	Buildpacks []BuildpackFreshness `json:"buildpacks" yaml:"buildpacks" toml:"buildpacks"`
+}
+
+type ImageFreshness struct {
+	Name         string                 `json:"name" yaml:"name" toml:"name"`
+	Digest       string                 `json:"digest,omitempty" yaml:"digest,omitempty" toml:"digest,omitempty"`
+	LatestDigest string                 `json:"latest_digest,omitempty" yaml:"latest_digest,omitempty" toml:"latest_digest,omitempty"`
+	Status       client.FreshnessStatus `json:"status" yaml:"status" toml:"status"`
+}
+
+type LifecycleFreshness struct {
+	Version       string                 `json:"version,omitempty" yaml:"version,omitempty" toml:"version,omitempty"`
+	LatestVersion string                 `json:"latest_version" yaml:"latest_version" toml:"latest_version"`
+	Status        client.FreshnessStatus `json:"status" yaml:"status" toml:"status"`
+}
+
+type BuildpackFreshness struct {
+	ID            string                 `json:"id" yaml:"id" toml:"id"`
+	Version       string                 `json:"version" yaml:"version" toml:"version"`
+	LatestVersion string                 `json:"latest_version,omitempty" yaml:"latest_version,omitempty" toml:"latest_version,omitempty"`
+	Registry      string                 `json:"registry,omitempty" yaml:"registry,omitempty" toml:"registry,omitempty"`
+	Status        client.FreshnessStatus `json:"status" yaml:"status" toml:"status"`
 }
 
 type StructuredFormat struct {
@@ -92,6 +121,7 @@ func (w *StructuredFormat) Print(
 			DetectionOrder:  local.Order,
 			Extensions:      local.Extensions,
 			OrderExtensions: local.OrderExtensions,
+			Freshness:       freshness(local.Freshness),
 		}
 	}
 
@@ -119,6 +149,7 @@ func (w *StructuredFormat) Print(
 			DetectionOrder:  remote.Order,
 			Extensions:      remote.Extensions,
 			OrderExtensions: remote.OrderExtensions,
+			Freshness:       freshness(remote.Freshness),
 		}
 	}
 
@@ -139,6 +170,26 @@ func (w *StructuredFormat) Print(
 	return nil
 }
 
+func freshness(f *client.BuilderFreshness) *Freshness {
+	if f == nil {
+		return nil
+	}
+
+	output := &Freshness{
+		Builder:    ImageFreshness(f.Builder),
+		RunImages:  []ImageFreshness{},
+		Lifecycle:  LifecycleFreshness(f.Lifecycle),
+		Buildpacks: []BuildpackFreshness{},
+	}
+	for _, runImage := range f.RunImages {
+		output.RunImages = append(output.RunImages, ImageFreshness(runImage))
+	}
+	for _, bp := range f.Buildpacks {
+		output.Buildpacks = append(output.Buildpacks, BuildpackFreshness(bp))
+	}
+	return output
+}
+
 func runImages(runImages []pubbldr.RunImageConfig, localRunImages []config.RunImage) []RunImage {
 	images := []RunImage{}
 
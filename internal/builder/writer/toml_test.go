@@ -477,6 +477,28 @@ default = false
 				assert.NotContains(outBuf.String(), "detection_order")
 			})
 		})
+
+		when("freshness is checked", func() {
+			it("displays the freshness of each part of the builder", func() {
+				localInfo.Freshness = freshness
+
+				tomlWriter := writer.NewTOML()
+
+				logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+				err := tomlWriter.Print(logger, localRunImages, localInfo, remoteInfo, nil, nil, sharedBuilderInfo)
+				assert.Nil(err)
+
+				assert.Succeeds(validTOMLOutput(outBuf))
+				assert.ContainsTOML(outBuf.String(), `
+[lifecycle]
+  version = "0.17.0"
+  latest_version = "0.18.5"
+  status = "outdated"
+`)
+				assert.Contains(outBuf.String(), `registry = "official"`)
+				assert.Contains(outBuf.String(), `status = "yanked"`)
+			})
+		})
 	})
 }
 
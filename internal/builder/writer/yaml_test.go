@@ -408,6 +408,35 @@ func testYAML(t *testing.T, when spec.G, it spec.S) {
 				assert.ContainsYAML(prettifiedYAML, `detection_order: []`)
 			})
 		})
+
+		when("freshness is checked", func() {
+			it("displays the freshness of each part of the builder", func() {
+				localInfo.Freshness = freshness
+
+				yamlWriter := writer.NewYAML()
+
+				logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+				err := yamlWriter.Print(logger, localRunImages, localInfo, remoteInfo, nil, nil, sharedBuilderInfo)
+				assert.Nil(err)
+
+				prettifiedYAML, err := validYAML(outBuf)
+				assert.Nil(err)
+
+				assert.ContainsYAML(prettifiedYAML, `
+lifecycle:
+  version: 0.17.0
+  latest_version: 0.18.5
+  status: outdated
+`)
+				assert.ContainsYAML(prettifiedYAML, `
+id: example/bp
+version: 1.0.0
+latest_version: 1.1.0
+registry: official
+status: yanked
+`)
+			})
+		})
 	})
 }
 
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	cpkg "github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/sbom"
+)
+
+type AttachSBOMFlags struct {
+	SBOMDirs []string
+	Format   string
+}
+
+func AttachSBOM(
+	logger logging.Logger,
+	client PackClient,
+) *cobra.Command {
+	var flags AttachSBOMFlags
+	cmd := &cobra.Command{
+		Use:   "attach <image-name>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Attach the SBoM of a published image as an OCI referrer",
+		Long: "Merge the SBoMs of an image in a registry and push the result as an OCI artifact referring to the image, " +
+			"so that registry-native scanners can discover it through the referrers API. " +
+			"Registries without support for the referrers API are updated using the referrers tag scheme.",
+		Example: "pack sbom attach registry.example.com/my-app --format spdx",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			format, err := sbom.ParseFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+
+			artifact, err := client.AttachSBOM(cmd.Context(), cpkg.AttachSBOMOptions{
+				Image:  args[0],
+				Format: format,
+				Dirs:   flags.SBOMDirs,
+			})
+			if err != nil {
+				return err
+			}
+
+			logger.Infof("Successfully attached SBoM %s", style.Symbol(artifact))
+			return nil
+		}),
+	}
+	AddHelpFlag(cmd, "attach")
+	cmd.Flags().StringArrayVar(&flags.SBOMDirs, "sbom-dir", nil, "Directory containing additional SBoM files to include, e.g. the output of `pack build --sbom-output-dir`."+stringArrayHelp("sbom-dir"))
+	cmd.Flags().StringVarP(&flags.Format, "format", "f", string(sbom.FormatCycloneDX), "Format of the attached document (cyclonedx, spdx, syft)")
+	return cmd
+}
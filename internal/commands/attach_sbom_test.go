@@ -0,0 +1,78 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	cpkg "github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/sbom"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestAttachSBOMCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "AttachSBOMCommand", testAttachSBOMCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testAttachSBOMCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.AttachSBOM(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#AttachSBOM", func() {
+		it("attaches a CycloneDX SBoM by default", func() {
+			mockClient.EXPECT().AttachSBOM(gomock.Any(), cpkg.AttachSBOMOptions{
+				Image:  "registry.example.com/some/image",
+				Format: sbom.FormatCycloneDX,
+			}).Return("registry.example.com/some/image@sha256:abc", nil)
+			command.SetArgs([]string{"registry.example.com/some/image"})
+
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Successfully attached SBoM 'registry.example.com/some/image@sha256:abc'")
+		})
+
+		it("passes the format and SBoM directories", func() {
+			mockClient.EXPECT().AttachSBOM(gomock.Any(), cpkg.AttachSBOMOptions{
+				Image:  "some/image",
+				Format: sbom.FormatSPDX,
+				Dirs:   []string{"some-dir"},
+			}).Return("some/image@sha256:abc", nil)
+			command.SetArgs([]string{"some/image", "--format", "spdx", "--sbom-dir", "some-dir"})
+
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("returns client errors", func() {
+			mockClient.EXPECT().AttachSBOM(gomock.Any(), gomock.Any()).Return("", errors.New("some error"))
+			command.SetArgs([]string{"some/image"})
+
+			h.AssertError(t, command.Execute(), "some error")
+		})
+	})
+}
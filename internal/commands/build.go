@@ -9,6 +9,7 @@ import (
 
 	"github.com/buildpacks/pack/pkg/cache"
 
+	"github.com/docker/go-units"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -20,6 +21,7 @@ import (
 	"github.com/buildpacks/pack/pkg/logging"
 	"github.com/buildpacks/pack/pkg/project"
 	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+	"github.com/buildpacks/pack/pkg/sbom"
 )
 
 type BuildFlags struct {
@@ -42,15 +44,33 @@ type BuildFlags struct {
 	LifecycleImage       string
 	Env                  []string
 	EnvFiles             []string
+	Secrets              []string
 	Buildpacks           []string
 	Extensions           []string
 	Volumes              []string
+	Memory               string
+	CPUs                 float64
+	PidsLimit            int64
+	Ulimits              []string
+	ExtraHosts           []string
+	DNS                  []string
+	SecurityOpt          []string
+	CapDrop              []string
+	VerifyReproducible   bool
+	UpdateLock           bool
+	UseGitignore         bool
+	PrintContext         bool
+	KeepAppVolume        bool
+	On                   string
+	EgressPolicy         string
+	EgressAllow          []string
 	AdditionalTags       []string
 	Workspace            string
 	GID                  int
 	UID                  int
 	PreviousImage        string
 	SBOMDestinationDir   string
+	AttachSBOM           string
 	ReportDestinationDir string
 	DateTime             string
 	PreBuildpacks        []string
@@ -79,7 +99,19 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 
 			inputPreviousImage := client.ParseInputImageReference(flags.PreviousImage)
 
-			descriptor, actualDescriptorPath, err := parseProjectToml(flags.AppPath, flags.DescriptorPath, logger)
+			// the project descriptor and lock file of a git repository are read from its checkout
+			localAppPath := flags.AppPath
+			var gitCheckout *client.GitCheckout
+			if client.IsGitSource(flags.AppPath) {
+				checkout, err := packClient.FetchGitSource(cmd.Context(), flags.AppPath)
+				if err != nil {
+					return err
+				}
+				defer checkout.Close()
+				gitCheckout, localAppPath = checkout, checkout.AppPath
+			}
+
+			descriptor, actualDescriptorPath, err := parseProjectToml(localAppPath, flags.DescriptorPath, logger)
 			if err != nil {
 				return err
 			}
@@ -108,7 +140,13 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				return err
 			}
 
+			secrets, err := parseSecrets(flags.Secrets)
+			if err != nil {
+				return err
+			}
+
 			trustBuilder := isTrustedBuilder(cfg, builder) || flags.TrustBuilder
+			policy := trustPolicy(cfg, builder, flags.TrustBuilder)
 			if trustBuilder {
 				logger.Debugf("Builder %s is trusted", style.Symbol(builder))
 				if flags.LifecycleImage != "" {
@@ -150,18 +188,40 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				uid = flags.UID
 			}
 
+			var memory int64
+			if flags.Memory != "" {
+				if memory, err = units.RAMInBytes(flags.Memory); err != nil {
+					return errors.Wrapf(err, "parsing memory limit %s", flags.Memory)
+				}
+			}
+
 			dateTime, err := parseTime(flags.DateTime)
 			if err != nil {
 				return errors.Wrapf(err, "parsing creation time %s", flags.DateTime)
 			}
-			if err := packClient.Build(cmd.Context(), client.BuildOptions{
+
+			egress, err := egressPolicy(flags, cfg, descriptor.Build.Egress)
+			if err != nil {
+				return err
+			}
+
+			var attachSBOM sbom.Format
+			if flags.AttachSBOM != "" {
+				if attachSBOM, err = sbom.ParseFormat(flags.AttachSBOM); err != nil {
+					return err
+				}
+			}
+			buildOpts := client.BuildOptions{
 				AppPath:           flags.AppPath,
+				GitCheckout:       gitCheckout,
 				Builder:           builder,
 				Registry:          flags.Registry,
 				AdditionalMirrors: getMirrors(cfg),
 				AdditionalTags:    flags.AdditionalTags,
 				RunImage:          flags.RunImage,
 				Env:               env,
+				Secrets:           secrets,
+				EgressPolicy:      egress,
 				Image:             inputImageName.Name(),
 				Publish:           flags.Publish,
 				DockerHost:        flags.DockerHost,
@@ -170,11 +230,20 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				TrustBuilder: func(string) bool {
 					return trustBuilder
 				},
-				Buildpacks: buildpacks,
-				Extensions: extensions,
+				TrustPolicy: &policy,
+				Buildpacks:  buildpacks,
+				Extensions:  extensions,
 				ContainerConfig: client.ContainerConfig{
-					Network: flags.Network,
-					Volumes: flags.Volumes,
+					Network:     flags.Network,
+					Volumes:     flags.Volumes,
+					Memory:      memory,
+					CPUs:        flags.CPUs,
+					PidsLimit:   flags.PidsLimit,
+					Ulimits:     flags.Ulimits,
+					ExtraHosts:  flags.ExtraHosts,
+					DNS:         flags.DNS,
+					SecurityOpt: flags.SecurityOpt,
+					CapDrop:     flags.CapDrop,
 				},
 				DefaultProcessType:       flags.DefaultProcessType,
 				ProjectDescriptorBaseDir: filepath.Dir(actualDescriptorPath),
@@ -188,17 +257,46 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				PreviousImage:            inputPreviousImage.Name(),
 				Interactive:              flags.Interactive,
 				SBOMDestinationDir:       flags.SBOMDestinationDir,
+				AttachSBOM:               attachSBOM,
 				ReportDestinationDir:     flags.ReportDestinationDir,
 				CreationTime:             dateTime,
 				PreBuildpacks:            flags.PreBuildpacks,
 				PostBuildpacks:           flags.PostBuildpacks,
+				UseGitignore:             flags.UseGitignore,
+				PrintContext:             flags.PrintContext,
+				KeepAppVolume:            flags.KeepAppVolume,
 				LayoutConfig: &client.LayoutConfig{
 					Sparse:             flags.Sparse,
 					InputImage:         inputImageName,
 					PreviousInputImage: inputPreviousImage,
 					LayoutRepoDir:      cfg.LayoutRepositoryDir,
 				},
-			}); err != nil {
+			}
+
+			if err := setDaemon(&buildOpts, cfg, flags.On, !cmd.Flags().Changed("lifecycle-image")); err != nil {
+				return err
+			}
+
+			lockPath := lockFilePath(localAppPath, actualDescriptorPath)
+			if buildOpts.Lock, err = readOrUpdateLock(cmd.Context(), logger, packClient, buildOpts, lockPath, flags.UpdateLock); err != nil {
+				return err
+			}
+
+			if flags.VerifyReproducible {
+				report, err := packClient.VerifyReproducible(cmd.Context(), buildOpts)
+				if err != nil {
+					return errors.Wrap(err, "failed to build")
+				}
+				logger.Infof("Successfully built image %s", style.Symbol(inputImageName.Name()))
+				if !report.Reproducible() {
+					printReproducibilityReport(logger, report)
+					return errors.Errorf("image %s is not reproducible", style.Symbol(inputImageName.Name()))
+				}
+				logger.Infof("Image %s is reproducible", style.Symbol(inputImageName.Name()))
+				return nil
+			}
+
+			if err := packClient.Build(cmd.Context(), buildOpts); err != nil {
 				return errors.Wrap(err, "failed to build")
 			}
 			logger.Infof("Successfully built image %s", style.Symbol(inputImageName.Name()))
@@ -228,10 +326,10 @@ func parseTime(providedTime string) (*time.Time, error) {
 }
 
 func buildCommandFlags(cmd *cobra.Command, buildFlags *BuildFlags, cfg config.Config) {
-	cmd.Flags().StringVarP(&buildFlags.AppPath, "path", "p", "", "Path to app dir or zip-formatted file (defaults to current working directory)")
+	cmd.Flags().StringVarP(&buildFlags.AppPath, "path", "p", "", "Path to app dir, zip, tar or tar.gz file, URL of a git repository as '<url>#<ref>:<subdir>', or OCI artifact as 'oci://<reference>' (defaults to current working directory)")
 	cmd.Flags().StringSliceVarP(&buildFlags.Buildpacks, "buildpack", "b", nil, "Buildpack to use. One of:\n  a buildpack by id and version in the form of '<buildpack>@<version>',\n  path to a buildpack directory (not supported on Windows),\n  path/URL to a buildpack .tar or .tgz file, or\n  a packaged buildpack image name in the form of '<hostname>/<repo>[:<tag>]'"+stringSliceHelp("buildpack"))
 	cmd.Flags().StringSliceVarP(&buildFlags.Extensions, "extension", "", nil, "Extension to use. One of:\n  an extension by id and version in the form of '<extension>@<version>',\n  path to an extension directory (not supported on Windows),\n  path/URL to an extension .tar or .tgz file, or\n  a packaged extension image name in the form of '<hostname>/<repo>[:<tag>]'"+stringSliceHelp("extension"))
-	cmd.Flags().StringVarP(&buildFlags.Builder, "builder", "B", cfg.DefaultBuilder, "Builder image")
+	cmd.Flags().StringVarP(&buildFlags.Builder, "builder", "B", cfg.DefaultBuilder, "Builder image, or an OCI layout of a builder as 'oci:<path>'")
 	cmd.Flags().Var(&buildFlags.Cache, "cache",
 		`Cache options used to define cache techniques for build process.
 - Cache as bind: 'type=<build/launch>;format=bind;source=<path to directory>'
@@ -243,10 +341,24 @@ func buildCommandFlags(cmd *cobra.Command, buildFlags *BuildFlags, cfg config.Co
 	cmd.Flags().BoolVar(&buildFlags.ClearCache, "clear-cache", false, "Clear image's associated cache before building")
 	cmd.Flags().StringVar(&buildFlags.DateTime, "creation-time", "", "Desired create time in the output image config. Accepted values are Unix timestamps (e.g., '1641013200'), or 'now'. Platform API version must be at least 0.9 to use this feature.")
 	cmd.Flags().StringVarP(&buildFlags.DescriptorPath, "descriptor", "d", "", "Path to the project descriptor file")
+	cmd.Flags().BoolVar(&buildFlags.UseGitignore, "use-gitignore", false, "Exclude the paths listed in .gitignore files of the app dir from the build, in addition to those listed in "+client.PackIgnoreFile+" files")
+	cmd.Flags().BoolVar(&buildFlags.PrintContext, "print-context", false, "Print the files of the app sent to the build, and their total size")
+	cmd.Flags().BoolVar(&buildFlags.KeepAppVolume, "keep-app-volume", false, "Keep the app source in a volume between builds, so that only the files that changed since the previous build are copied.\nThe volume is named after the app dir and the daemon, and can be removed with 'docker volume rm'")
 	cmd.Flags().StringVarP(&buildFlags.DefaultProcessType, "default-process", "D", "", `Set the default process type. (default "web")`)
 	cmd.Flags().StringArrayVarP(&buildFlags.Env, "env", "e", []string{}, "Build-time environment variable, in the form 'VAR=VALUE' or 'VAR'.\nWhen using latter value-less form, value will be taken from current\n  environment at the time this command is executed.\nThis flag may be specified multiple times and will override\n  individual values defined by --env-file."+stringArrayHelp("env")+"\nNOTE: These are NOT available at image runtime.")
+	cmd.Flags().StringArrayVar(&buildFlags.Secrets, "secret", nil, "Build-time secret, in the form 'id=<id>,src=<path>' or 'id=<id>,env=<VAR>'.\nSecrets are available to the detect and build phases only, as read-only files in /run/secrets/<id>.\nThey are not set as environment variables, cached or exported, and are redacted from build output."+stringArrayHelp("secret"))
 	cmd.Flags().StringArrayVar(&buildFlags.EnvFiles, "env-file", []string{}, "Build-time environment variables file\nOne variable per line, of the form 'VAR=VALUE' or 'VAR'\nWhen using latter value-less form, value will be taken from current\n  environment at the time this command is executed\nNOTE: These are NOT available at image runtime.\"")
 	cmd.Flags().StringVar(&buildFlags.Network, "network", "", "Connect detect and build containers to network")
+	cmd.Flags().StringVar(&buildFlags.Memory, "memory", "", "Memory limit of the containers running buildpacks, e.g. 2g")
+	cmd.Flags().Float64Var(&buildFlags.CPUs, "cpus", 0, "Number of CPUs available to the containers running buildpacks, e.g. 1.5")
+	cmd.Flags().Int64Var(&buildFlags.PidsLimit, "pids-limit", 0, "Maximum number of processes in the containers running buildpacks")
+	cmd.Flags().StringArrayVar(&buildFlags.Ulimits, "ulimit", nil, "Ulimit of the containers running buildpacks, in the form '<type>=<soft limit>[:<hard limit>]'"+stringArrayHelp("ulimit"))
+	cmd.Flags().StringArrayVar(&buildFlags.ExtraHosts, "add-host", nil, "Add a custom host-to-IP mapping to the build containers, in the form '<host>:<ip>'"+stringArrayHelp("host"))
+	cmd.Flags().StringArrayVar(&buildFlags.DNS, "dns", nil, "DNS server of the build containers"+stringArrayHelp("DNS server"))
+	cmd.Flags().StringArrayVar(&buildFlags.SecurityOpt, "security-opt", nil, "Security option of the containers running buildpacks, e.g. 'no-new-privileges'"+stringArrayHelp("security option"))
+	cmd.Flags().StringArrayVar(&buildFlags.CapDrop, "cap-drop", nil, "Kernel capability to drop from the containers running buildpacks, e.g. 'ALL'"+stringArrayHelp("capability"))
+	cmd.Flags().StringVar(&buildFlags.EgressPolicy, "egress-policy", "", "Egress policy of the containers running buildpacks. Accepted values are allow-all and allowlist, which only allows\nhosts in the project descriptor, config and --egress-allow. Contacted hosts are recorded in egress.toml in --report-output-dir.\nRestricting egress requires a Docker daemon on the local machine.")
+	cmd.Flags().StringArrayVar(&buildFlags.EgressAllow, "egress-allow", nil, "Host the containers running buildpacks may contact when using the allowlist egress policy, e.g. '*.npmjs.org'"+stringArrayHelp("host"))
 	cmd.Flags().StringArrayVar(&buildFlags.PreBuildpacks, "pre-buildpack", []string{}, "Buildpacks to prepend to the groups in the builder's order")
 	cmd.Flags().StringArrayVar(&buildFlags.PostBuildpacks, "post-buildpack", []string{}, "Buildpacks to append to the groups in the builder's order")
 	cmd.Flags().BoolVar(&buildFlags.Publish, "publish", false, "Publish the application image directly to the container registry specified in <image-name>, instead of the daemon. The run image must also reside in the registry.")
@@ -256,6 +368,7 @@ If not set (or set to empty string) the standard socket location will be used.
 Special value 'inherit' may be used in which case DOCKER_HOST environment variable will be used.
 This option may set DOCKER_HOST environment variable for the build container if needed.
 `)
+	cmd.Flags().StringVar(&buildFlags.On, "on", "", "Name of the docker host to build on, as added with 'pack config docker-hosts add'.\nWhen not set, the build runs on a docker host whose platform the builder is available for, if the builder isn't available for the platform of the local daemon\nand is pulled from a registry.")
 	cmd.Flags().StringVar(&buildFlags.LifecycleImage, "lifecycle-image", cfg.LifecycleImage, `Custom lifecycle image to use for analysis, restore, and export when builder is untrusted.`)
 	cmd.Flags().StringVar(&buildFlags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, and if-not-present. (default "always")`)
 	cmd.Flags().StringVarP(&buildFlags.Registry, "buildpack-registry", "r", cfg.DefaultRegistryName, "Buildpack Registry by name")
@@ -268,7 +381,10 @@ This option may set DOCKER_HOST environment variable for the build container if
 	cmd.Flags().IntVar(&buildFlags.UID, "uid", 0, `Override UID of user in the stack's build and run images. The provided value must be a positive number`)
 	cmd.Flags().StringVar(&buildFlags.PreviousImage, "previous-image", "", "Set previous image to a particular tag reference, digest reference, or (when performing a daemon build) image ID")
 	cmd.Flags().StringVar(&buildFlags.SBOMDestinationDir, "sbom-output-dir", "", "Path to export SBoM contents.\nOmitting the flag will yield no SBoM content.")
+	cmd.Flags().StringVar(&buildFlags.AttachSBOM, "attach-sbom", "", "Attach the merged SBoM to the published image as an OCI referrer, in the given format (cyclonedx, spdx, syft). Requires --publish.")
 	cmd.Flags().StringVar(&buildFlags.ReportDestinationDir, "report-output-dir", "", "Path to export build report.toml.\nOmitting the flag yield no report file.")
+	cmd.Flags().BoolVar(&buildFlags.VerifyReproducible, "verify-reproducible", false, "Build the app twice with new, empty caches and fail if the resulting images differ.\nDifferences are reported per layer and file. Cannot be used with --publish.")
+	cmd.Flags().BoolVar(&buildFlags.UpdateLock, "update-lock", false, "Resolve the builder, run image and buildpacks again and update "+project.LockFileName+" before building.\nWithout this flag, the inputs locked in "+project.LockFileName+" are used if it exists.")
 	cmd.Flags().BoolVar(&buildFlags.Interactive, "interactive", false, "Launch a terminal UI to depict the build process")
 	cmd.Flags().BoolVar(&buildFlags.Sparse, "sparse", false, "Use this flag to avoid saving on disk the run-image layers when the application image is exported to OCI layout format")
 	if !cfg.Experimental {
@@ -298,6 +414,32 @@ func validateBuildFlags(flags *BuildFlags, cfg config.Config, inputImageRef clie
 		return errors.New("cache-image flag requires the publish flag")
 	}
 
+	if flags.AttachSBOM != "" && !flags.Publish {
+		return errors.New("attach-sbom flag requires the publish flag")
+	}
+
+	if flags.VerifyReproducible && flags.Publish {
+		return errors.New("verify-reproducible flag cannot be used with the publish flag")
+	}
+
+	if flags.CPUs < 0 {
+		return errors.New("cpus flag must not be negative")
+	}
+
+	if flags.PidsLimit < 0 {
+		return errors.New("pids-limit flag must not be negative")
+	}
+
+	switch flags.EgressPolicy {
+	case "", client.EgressPolicyAllowAll, client.EgressPolicyAllowlist:
+	default:
+		return errors.Errorf("egress-policy flag must be one of %s or %s", client.EgressPolicyAllowAll, client.EgressPolicyAllowlist)
+	}
+
+	if flags.UpdateLock && flags.DescriptorPath == "" && (client.IsGitSource(flags.AppPath) || client.IsOCISource(flags.AppPath) || isFile(flags.AppPath)) {
+		return errors.New("update-lock flag requires a descriptor when the path is a git repository, OCI artifact or archive")
+	}
+
 	if flags.GID < 0 {
 		return errors.New("gid flag must be in the range of 0-2147483647")
 	}
@@ -317,6 +459,81 @@ func validateBuildFlags(flags *BuildFlags, cfg config.Config, inputImageRef clie
 	return nil
 }
 
+// isFile returns whether path is an existing file, like the zip or tar archive of an app
+func isFile(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.Mode().IsRegular()
+}
+
+func printReproducibilityReport(logger logging.Logger, report *client.ReproducibilityReport) {
+	for _, diff := range report.Config {
+		logger.Infof("Config %s differs: %s -> %s", style.Symbol(diff.Field), diff.Before, diff.After)
+	}
+	for _, layer := range report.Layers {
+		if layer.Buildpack != "" {
+			logger.Infof("Layer %s of buildpack %s %s: %s -> %s", style.Symbol(layer.Name), style.Symbol(layer.Buildpack), layer.Status, layer.Before, layer.After)
+		} else {
+			logger.Infof("Layer %s %s: %s -> %s", style.Symbol(layer.Name), layer.Status, layer.Before, layer.After)
+		}
+		for _, file := range layer.Files {
+			if len(file.Changes) > 0 {
+				logger.Infof("  %s %s: %s", file.Path, file.Status, strings.Join(file.Changes, ", "))
+			} else {
+				logger.Infof("  %s %s", file.Path, file.Status)
+			}
+		}
+	}
+}
+
+// setDaemon sets the docker host the build runs on, or when unset, the docker hosts with a platform the client may
+// select from. The lifecycle images of the docker hosts are dropped when the lifecycle image was set explicitly.
+func setDaemon(opts *client.BuildOptions, cfg config.Config, on string, useDaemonLifecycle bool) error {
+	toProfile := func(dockerHost config.DockerHost) client.DaemonProfile {
+		profile := daemonProfile(dockerHost)
+		if !useDaemonLifecycle {
+			profile.LifecycleImage = ""
+		}
+		return profile
+	}
+
+	if on != "" {
+		dockerHost, ok := config.GetDockerHost(cfg, on)
+		if !ok {
+			return errors.Errorf("docker host %s does not exist, add it with %s", style.Symbol(on), style.Symbol("pack config docker-hosts add"))
+		}
+		profile := toProfile(dockerHost)
+		opts.Daemon = &profile
+		return nil
+	}
+
+	for _, dockerHost := range cfg.DockerHosts {
+		if dockerHost.Platform != "" {
+			opts.DaemonCandidates = append(opts.DaemonCandidates, toProfile(dockerHost))
+		}
+	}
+	return nil
+}
+
+// egressPolicy returns the egress policy selected by the flags, or when unset, by the project descriptor.
+// The hosts allowed in the config and flags apply to either.
+func egressPolicy(flags BuildFlags, cfg config.Config, descriptor projectTypes.Egress) (*client.EgressPolicy, error) {
+	policy := flags.EgressPolicy
+	if policy == "" {
+		policy = descriptor.Policy
+	}
+	if len(flags.EgressAllow) > 0 && policy != client.EgressPolicyAllowlist {
+		return nil, errors.Errorf("egress-allow flag requires the %s egress policy", client.EgressPolicyAllowlist)
+	}
+
+	switch policy {
+	case client.EgressPolicyAllowlist:
+		return &client.EgressPolicy{Restrict: true, Allow: append(append([]string{}, cfg.EgressAllowlist...), flags.EgressAllow...)}, nil
+	case client.EgressPolicyAllowAll:
+		return &client.EgressPolicy{Restrict: false}, nil
+	}
+	return nil, nil
+}
+
 func parseEnv(envFiles []string, envVars []string) (map[string]string, error) {
 	env := map[string]string{}
 
@@ -336,6 +553,49 @@ func parseEnv(envFiles []string, envVars []string) (map[string]string, error) {
 	return env, nil
 }
 
+func parseSecrets(secrets []string) ([]client.BuildSecret, error) {
+	var parsed []client.BuildSecret
+	for _, secret := range secrets {
+		var buildSecret client.BuildSecret
+		for _, field := range strings.Split(secret, ",") {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				return nil, errors.Errorf("invalid secret %s, expected key=value pairs", style.Symbol(secret))
+			}
+			switch key {
+			case "id":
+				buildSecret.ID = value
+			case "src", "source":
+				path, err := expandHomeDir(value)
+				if err != nil {
+					return nil, err
+				}
+				buildSecret.Src = path
+			case "env":
+				buildSecret.Env = value
+			default:
+				return nil, errors.Errorf("invalid secret %s, unknown key %s", style.Symbol(secret), style.Symbol(key))
+			}
+		}
+		if buildSecret.ID == "" {
+			return nil, errors.Errorf("invalid secret %s, missing id", style.Symbol(secret))
+		}
+		parsed = append(parsed, buildSecret)
+	}
+	return parsed, nil
+}
+
+func expandHomeDir(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Wrap(err, "getting home directory")
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
+
 func parseEnvFile(filename string) (map[string]string, error) {
 	out := make(map[string]string)
 	f, err := os.ReadFile(filepath.Clean(filename))
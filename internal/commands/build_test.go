@@ -2,6 +2,7 @@ package commands_test
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -25,7 +26,9 @@ import (
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/project"
 	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+	"github.com/buildpacks/pack/pkg/sbom"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -114,6 +117,37 @@ func testBuildCommand(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("a trusted builder entry requires a digest", func() {
+				it("passes the entry to the client to decide trust", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithTrustPolicy(client.TrustPolicy{Rules: []client.TrustRule{{
+							Source:  "trusted-builders entry 'example.com/*'",
+							Pattern: "example.com/*",
+							Digests: []string{"sha256:aaaa"},
+							Scope:   client.TrustScopeBuildpacks,
+						}}})).
+						Return(nil)
+
+					cfg := config.Config{TrustedBuilders: []config.TrustedBuilder{{Name: "example.com/*", Digests: []string{"sha256:aaaa"}, Scope: "buildpacks"}}}
+					command = commands.Build(logger, cfg, mockClient)
+					command.SetArgs([]string{"image", "--builder", "example.com/my-builder"})
+					h.AssertNil(t, command.Execute())
+				})
+			})
+
+			when("a trusted builder entry matches the builder by pattern", func() {
+				it("sets the trust builder option", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithTrustedBuilder(true)).
+						Return(nil)
+
+					cfg := config.Config{TrustedBuilders: []config.TrustedBuilder{{Name: "example.com/*"}}}
+					command = commands.Build(logger, cfg, mockClient)
+					command.SetArgs([]string{"image", "--builder", "example.com/my-builder:latest"})
+					h.AssertNil(t, command.Execute())
+				})
+			})
+
 			when("the builder is suggested", func() {
 				it("sets the trust builder option", func() {
 					mockClient.EXPECT().
@@ -137,6 +171,49 @@ func testBuildCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("container resource flags are given", func() {
+			it("forwards them onto the client", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithContainerConfig(client.ContainerConfig{
+						Memory:      512 * 1024 * 1024,
+						CPUs:        1.5,
+						PidsLimit:   256,
+						Ulimits:     []string{"nofile=1024:2048"},
+						ExtraHosts:  []string{"some-host:127.0.0.1"},
+						DNS:         []string{"8.8.8.8"},
+						SecurityOpt: []string{"no-new-privileges"},
+						CapDrop:     []string{"ALL"},
+					})).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder",
+					"--memory", "512m",
+					"--cpus", "1.5",
+					"--pids-limit", "256",
+					"--ulimit", "nofile=1024:2048",
+					"--add-host", "some-host:127.0.0.1",
+					"--dns", "8.8.8.8",
+					"--security-opt", "no-new-privileges",
+					"--cap-drop", "ALL",
+				})
+				h.AssertNil(t, command.Execute())
+			})
+
+			when("the memory limit is invalid", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--memory", "lots"})
+					h.AssertError(t, command.Execute(), "parsing memory limit lots")
+				})
+			})
+
+			when("the pids limit is negative", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--pids-limit", "-1"})
+					h.AssertError(t, command.Execute(), "pids-limit flag must not be negative")
+				})
+			})
+		})
+
 		when("a network is given", func() {
 			it("forwards the network onto the client", func() {
 				mockClient.EXPECT().
@@ -148,6 +225,286 @@ func testBuildCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("--verify-reproducible", func() {
+			it("builds using VerifyReproducible", func() {
+				mockClient.EXPECT().
+					VerifyReproducible(gomock.Any(), EqBuildOptionsWithImage("my-builder", "image")).
+					Return(&client.ReproducibilityReport{}, nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--verify-reproducible"})
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), "Image 'image' is reproducible")
+			})
+
+			it("reports the differences and errors when the image is not reproducible", func() {
+				mockClient.EXPECT().
+					VerifyReproducible(gomock.Any(), gomock.Any()).
+					Return(&client.ReproducibilityReport{
+						Config: []client.ConfigDiff{{Field: "created", Before: "1980-01-01T00:00:01Z", After: "2024-01-01T00:00:00Z"}},
+						Layers: []client.LayerReproducibility{{
+							LayerDiff: client.LayerDiff{Name: "some/bp:node", Buildpack: "some/bp", Status: client.DiffChanged, Before: "sha256:a", After: "sha256:b"},
+							Files: []client.FileDiff{
+								{Path: "/layers/some_bp/node/build-id", Status: client.DiffChanged, Changes: []string{"content sha256:c -> sha256:d"}},
+								{Path: "/layers/some_bp/node/extra", Status: client.DiffAdded},
+							},
+						}},
+					}, nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--verify-reproducible"})
+				h.AssertError(t, command.Execute(), "image 'image' is not reproducible")
+				h.AssertContains(t, outBuf.String(), "Config 'created' differs: 1980-01-01T00:00:01Z -> 2024-01-01T00:00:00Z")
+				h.AssertContains(t, outBuf.String(), "Layer 'some/bp:node' of buildpack 'some/bp' changed: sha256:a -> sha256:b")
+				h.AssertContains(t, outBuf.String(), "  /layers/some_bp/node/build-id changed: content sha256:c -> sha256:d")
+				h.AssertContains(t, outBuf.String(), "  /layers/some_bp/node/extra added")
+			})
+
+			it("cannot be used with --publish", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--verify-reproducible", "--publish"})
+				h.AssertError(t, command.Execute(), "verify-reproducible flag cannot be used with the publish flag")
+			})
+		})
+
+		when("the app has a lock file", func() {
+			var appDir string
+
+			it.Before(func() {
+				var err error
+				appDir, err = os.MkdirTemp("", "build-lock")
+				h.AssertNil(t, err)
+			})
+
+			it.After(func() {
+				h.AssertNilE(t, os.RemoveAll(appDir))
+			})
+
+			it("builds with the locked inputs", func() {
+				lock := project.Lock{Builder: project.LockedImage{Name: "my-builder", Digest: "sha256:aaaa"}}
+				h.AssertNil(t, project.WriteLock(filepath.Join(appDir, project.LockFileName), lock))
+				lock.Version = project.LockVersion
+
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithLock(&lock)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--path", appDir})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("updates the lock file with --update-lock", func() {
+				lock := project.Lock{Version: project.LockVersion, Builder: project.LockedImage{Name: "my-builder", Digest: "sha256:bbbb"}}
+				mockClient.EXPECT().
+					ResolveLock(gomock.Any(), EqBuildOptionsWithImage("my-builder", "image")).
+					Return(lock, nil)
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithLock(&lock)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--path", appDir, "--update-lock"})
+				h.AssertNil(t, command.Execute())
+
+				written, err := project.ReadLock(filepath.Join(appDir, project.LockFileName))
+				h.AssertNil(t, err)
+				h.AssertEq(t, written, lock)
+			})
+
+			it("builds archives without a lock file", func() {
+				appZip := filepath.Join(appDir, "app.zip")
+				h.AssertNil(t, os.WriteFile(appZip, []byte("zip"), 0600))
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithLock(nil)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--path", appZip})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("cannot update the lock file of an archive without a descriptor", func() {
+				appZip := filepath.Join(appDir, "app.zip")
+				h.AssertNil(t, os.WriteFile(appZip, []byte("zip"), 0600))
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--path", appZip, "--update-lock"})
+				h.AssertError(t, command.Execute(), "update-lock flag requires a descriptor when the path is a git repository, OCI artifact or archive")
+			})
+		})
+
+		when("the path is a git repository", func() {
+			var checkoutDir string
+
+			it.Before(func() {
+				var err error
+				checkoutDir, err = os.MkdirTemp("", "git-checkout")
+				h.AssertNil(t, err)
+			})
+
+			it.After(func() {
+				h.AssertNil(t, os.RemoveAll(checkoutDir))
+			})
+
+			it("passes the URL and its checkout onto the client", func() {
+				checkout := &client.GitCheckout{AppPath: checkoutDir}
+				mockClient.EXPECT().
+					FetchGitSource(gomock.Any(), "https://git.example.com/org/repo.git#main:app").
+					Return(checkout, nil)
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithAppPath("https://git.example.com/org/repo.git#main:app")).
+					DoAndReturn(func(_ context.Context, opts client.BuildOptions) error {
+						h.AssertSameInstance(t, opts.GitCheckout, checkout)
+						return nil
+					})
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--path", "https://git.example.com/org/repo.git#main:app"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("uses the project.toml of the repository", func() {
+				h.AssertNil(t, os.WriteFile(filepath.Join(checkoutDir, "project.toml"), []byte(`
+[project]
+name = "Sample"
+
+[build]
+builder = "repo-builder"
+`), 0600))
+
+				mockClient.EXPECT().
+					FetchGitSource(gomock.Any(), "https://git.example.com/org/repo.git").
+					Return(&client.GitCheckout{AppPath: checkoutDir}, nil)
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithBuilder("repo-builder")).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--path", "https://git.example.com/org/repo.git"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("fails when the repository can't be fetched", func() {
+				mockClient.EXPECT().
+					FetchGitSource(gomock.Any(), "https://git.example.com/org/repo.git").
+					Return(nil, errors.New("fetch failed"))
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--path", "https://git.example.com/org/repo.git"})
+				h.AssertError(t, command.Execute(), "fetch failed")
+			})
+
+			it("cannot update the lock file without a descriptor", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--path", "https://git.example.com/org/repo.git", "--update-lock"})
+				h.AssertError(t, command.Execute(), "update-lock flag requires a descriptor when the path is a git repository, OCI artifact or archive")
+			})
+		})
+
+		when("--keep-app-volume", func() {
+			it("passes the option onto the client", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithKeepAppVolume(true)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--keep-app-volume"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("doesn't keep the app volume by default", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithKeepAppVolume(false)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("docker hosts are configured", func() {
+			it.Before(func() {
+				cfg.DockerHosts = []config.DockerHost{
+					{Name: "arm-box", Host: "ssh://arm-box", Platform: "linux/arm64", LifecycleImage: "some/arm-lifecycle"},
+					{Name: "x86-box", Host: "tcp://x86-box:2376"},
+				}
+				command = commands.Build(logger, cfg, mockClient)
+			})
+
+			it("builds on the docker host selected with --on", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithDaemons(&client.DaemonProfile{Name: "x86-box", Host: "tcp://x86-box:2376"}, nil)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--on", "x86-box"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("fails for a missing docker host", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--on", "other-box"})
+				h.AssertError(t, command.Execute(), "docker host 'other-box' does not exist")
+			})
+
+			it("lets the client select from the docker hosts with a platform", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithDaemons(nil, []client.DaemonProfile{
+						{Name: "arm-box", Host: "ssh://arm-box", Platform: "linux/arm64", LifecycleImage: "some/arm-lifecycle"},
+					})).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("prefers the lifecycle image of the flags to the one of the docker host", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithDaemons(&client.DaemonProfile{Name: "arm-box", Host: "ssh://arm-box", Platform: "linux/arm64"}, nil)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--on", "arm-box", "--lifecycle-image", "some/lifecycle"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("--egress-policy", func() {
+			it("passes the allowed hosts from the config and flags onto the client", func() {
+				cfg.EgressAllowlist = []string{"*.npmjs.org"}
+				command = commands.Build(logger, cfg, mockClient)
+
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithEgressPolicy(&client.EgressPolicy{
+						Restrict: true,
+						Allow:    []string{"*.npmjs.org", "example.com"},
+					})).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--egress-policy", "allowlist", "--egress-allow", "example.com"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("passes an unrestricted policy onto the client for allow-all", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithEgressPolicy(&client.EgressPolicy{Restrict: false})).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--egress-policy", "allow-all"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("errors for an unknown policy", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--egress-policy", "deny"})
+				h.AssertError(t, command.Execute(), "egress-policy flag must be one of allow-all or allowlist")
+			})
+
+			it("errors for allowed hosts without the allowlist policy", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--egress-policy", "allow-all", "--egress-allow", "example.com"})
+				h.AssertError(t, command.Execute(), "egress-allow flag requires the allowlist egress policy")
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--egress-allow", "example.com"})
+				h.AssertError(t, command.Execute(), "egress-allow flag requires the allowlist egress policy")
+			})
+
+			when("not provided", func() {
+				it("passes no policy onto the client", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithEgressPolicy(nil)).
+						Return(nil)
+
+					command.SetArgs([]string{"image", "--builder", "my-builder"})
+					h.AssertNil(t, command.Execute())
+				})
+			})
+		})
+
 		when("--pull-policy", func() {
 			it("sets pull-policy=never", func() {
 				mockClient.EXPECT().
@@ -356,6 +713,66 @@ func testBuildCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("--attach-sbom is passed", func() {
+			when("--publish is not used", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--attach-sbom", "spdx"})
+					err := command.Execute()
+					h.AssertError(t, err, "attach-sbom flag requires the publish flag")
+				})
+			})
+			when("--publish is used", func() {
+				it("passes the format", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithAttachSBOM(sbom.FormatSPDX)).
+						Return(nil)
+
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--attach-sbom", "spdx", "--publish"})
+					h.AssertNil(t, command.Execute())
+				})
+			})
+			when("the format is not supported", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--attach-sbom", "xml", "--publish"})
+					err := command.Execute()
+					h.AssertError(t, err, "unsupported SBOM format 'xml'")
+				})
+			})
+		})
+
+		when("--secret is passed", func() {
+			it("passes the secrets", func() {
+				home, err := os.UserHomeDir()
+				h.AssertNil(t, err)
+
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithSecrets([]client.BuildSecret{
+						{ID: "npmrc", Src: filepath.Join(home, ".npmrc")},
+						{ID: "token", Env: "SOME_TOKEN"},
+					})).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--secret", "id=npmrc,src=~/.npmrc", "--secret", "id=token,env=SOME_TOKEN"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			when("the secret has no id", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--secret", "src=some-file"})
+					err := command.Execute()
+					h.AssertError(t, err, "invalid secret 'src=some-file', missing id")
+				})
+			})
+
+			when("the secret has an unknown key", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--secret", "id=some-id,target=/some-path"})
+					err := command.Execute()
+					h.AssertError(t, err, "invalid secret 'id=some-id,target=/some-path', unknown key 'target'")
+				})
+			})
+		})
+
 		when("cache flag with 'format=image' is passed", func() {
 			when("--publish is not used", func() {
 				it("errors", func() {
@@ -949,6 +1366,33 @@ func EqBuildOptionsWithImage(builder, image string) gomock.Matcher {
 	}
 }
 
+func EqBuildOptionsWithAppPath(appPath string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("AppPath=%s", appPath),
+		equals: func(o client.BuildOptions) bool {
+			return o.AppPath == appPath
+		},
+	}
+}
+
+func EqBuildOptionsWithDaemons(daemon *client.DaemonProfile, candidates []client.DaemonProfile) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Daemon=%+v, DaemonCandidates=%+v", daemon, candidates),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.Daemon, daemon) && reflect.DeepEqual(o.DaemonCandidates, candidates)
+		},
+	}
+}
+
+func EqBuildOptionsWithKeepAppVolume(keep bool) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("KeepAppVolume=%t", keep),
+		equals: func(o client.BuildOptions) bool {
+			return o.KeepAppVolume == keep
+		},
+	}
+}
+
 func EqBuildOptionsDefaultProcess(defaultProc string) gomock.Matcher {
 	return buildOptionsMatcher{
 		description: fmt.Sprintf("Default Process Type=%s", defaultProc),
@@ -967,6 +1411,51 @@ func EqBuildOptionsWithPullPolicy(policy image.PullPolicy) gomock.Matcher {
 	}
 }
 
+func EqBuildOptionsWithAttachSBOM(format sbom.Format) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("AttachSBOM=%s", format),
+		equals: func(o client.BuildOptions) bool {
+			return o.AttachSBOM == format
+		},
+	}
+}
+
+func EqBuildOptionsWithEgressPolicy(policy *client.EgressPolicy) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("EgressPolicy=%+v", policy),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.EgressPolicy, policy)
+		},
+	}
+}
+
+func EqBuildOptionsWithTrustPolicy(policy client.TrustPolicy) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("TrustPolicy=%+v", policy),
+		equals: func(o client.BuildOptions) bool {
+			return o.TrustPolicy != nil && reflect.DeepEqual(*o.TrustPolicy, policy)
+		},
+	}
+}
+
+func EqBuildOptionsWithLock(lock *project.Lock) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Lock=%+v", lock),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.Lock, lock)
+		},
+	}
+}
+
+func EqBuildOptionsWithSecrets(secrets []client.BuildSecret) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Secrets=%+v", secrets),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.Secrets, secrets)
+		},
+	}
+}
+
 func EqBuildOptionsWithCacheImage(cacheImage string) gomock.Matcher {
 	return buildOptionsMatcher{
 		description: fmt.Sprintf("CacheImage=%s", cacheImage),
@@ -994,6 +1483,15 @@ func EqBuildOptionsWithLifecycleImage(lifecycleImage string) gomock.Matcher {
 	}
 }
 
+func EqBuildOptionsWithContainerConfig(config client.ContainerConfig) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("ContainerConfig=%+v", config),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.ContainerConfig, config)
+		},
+	}
+}
+
 func EqBuildOptionsWithNetwork(network string) gomock.Matcher {
 	return buildOptionsMatcher{
 		description: fmt.Sprintf("Network=%s", network),
@@ -1041,7 +1539,7 @@ func EqBuildOptionsWithAdditionalTags(additionalTags []string) gomock.Matcher {
 
 func EqBuildOptionsWithProjectDescriptor(descriptor projectTypes.Descriptor) gomock.Matcher {
 	return buildOptionsMatcher{
-		description: fmt.Sprintf("Descriptor=%s", descriptor),
+		description: fmt.Sprintf("Descriptor=%+v", descriptor),
 		equals: func(o client.BuildOptions) bool {
 			return reflect.DeepEqual(o.ProjectDescriptor, descriptor)
 		},
@@ -17,6 +17,7 @@ func NewBuilderCommand(logger logging.Logger, cfg config.Config, client PackClie
 	}
 
 	cmd.AddCommand(BuilderCreate(logger, cfg, client))
+	cmd.AddCommand(BuilderUpdate(logger, cfg, client))
 	cmd.AddCommand(BuilderInspect(logger, cfg, client, builderwriter.NewFactory()))
 	cmd.AddCommand(BuilderSuggest(logger, client))
 	AddHelpFlag(cmd, "builder")
@@ -24,6 +24,8 @@ type BuilderCreateFlags struct {
 	Policy          string
 	Flatten         []string
 	Label           map[string]string
+	Format          string
+	OutputPath      string
 }
 
 // CreateBuilder creates a builder image, based on a builder config
@@ -98,11 +100,22 @@ Creating a custom builder allows you to control what buildpacks are used and wha
 				PullPolicy:      pullPolicy,
 				Flatten:         toFlatten,
 				Labels:          flags.Label,
+				Format:          flags.Format,
+				OutputPath:      flags.OutputPath,
 			}); err != nil {
 				return err
 			}
-			logger.Infof("Successfully created builder image %s", style.Symbol(imageName))
-			logging.Tip(logger, "Run %s to use this builder", style.Symbol(fmt.Sprintf("pack build <image-name> --builder %s", imageName)))
+
+			switch flags.Format {
+			case "", client.FormatImage:
+				logger.Infof("Successfully created builder image %s", style.Symbol(imageName))
+				logging.Tip(logger, "Run %s to use this builder", style.Symbol(fmt.Sprintf("pack build <image-name> --builder %s", imageName)))
+			case client.FormatOCILayout:
+				logger.Infof("Successfully created builder %s and saved to OCI layout %s", style.Symbol(imageName), style.Symbol(flags.OutputPath))
+				logging.Tip(logger, "Run %s to use this builder", style.Symbol(fmt.Sprintf("pack build <image-name> --builder oci:%s", flags.OutputPath)))
+			default:
+				logger.Infof("Successfully created builder %s and saved to %s %s", style.Symbol(imageName), flags.Format, style.Symbol(flags.OutputPath))
+			}
 			return nil
 		}),
 	}
@@ -116,6 +129,8 @@ Creating a custom builder allows you to control what buildpacks are used and wha
 	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, and if-not-present. The default is always")
 	cmd.Flags().StringArrayVar(&flags.Flatten, "flatten", nil, "List of buildpacks to flatten together into a single layer (format: '<buildpack-id>@<buildpack-version>,<buildpack-id>@<buildpack-version>'")
 	cmd.Flags().StringToStringVarP(&flags.Label, "label", "l", nil, "Labels to add to the builder image, in the form of '<name>=<value>'")
+	cmd.Flags().StringVarP(&flags.Format, "format", "f", client.FormatImage, `Format to save builder as ("image", "oci-layout", "oci-archive" or "docker-archive")`)
+	cmd.Flags().StringVarP(&flags.OutputPath, "output", "o", "", "Path to save the builder to, required when --format is not \"image\"")
 
 	AddHelpFlag(cmd, "create")
 	return cmd
@@ -138,5 +153,21 @@ func validateCreateFlags(flags *BuilderCreateFlags, cfg config.Config) error {
 		return errors.Errorf("Please provide a builder config path, using --config.")
 	}
 
+	switch flags.Format {
+	case "", client.FormatImage:
+		if flags.OutputPath != "" {
+			return errors.Errorf("--output can only be used with --format %s, %s or %s", client.FormatOCILayout, client.FormatOCIArchive, client.FormatDockerArchive)
+		}
+	case client.FormatOCILayout, client.FormatOCIArchive, client.FormatDockerArchive:
+		if flags.Publish {
+			return errors.Errorf("--publish cannot be used with --format %s", flags.Format)
+		}
+		if flags.OutputPath == "" {
+			return errors.Errorf("Please provide a path to save the builder to, using --output.")
+		}
+	default:
+		return errors.Errorf("unknown format %s, must be one of %s, %s, %s or %s", style.Symbol(flags.Format), client.FormatImage, client.FormatOCILayout, client.FormatOCIArchive, client.FormatDockerArchive)
+	}
+
 	return nil
 }
@@ -2,6 +2,7 @@ package commands_test
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -17,6 +18,7 @@ import (
 	"github.com/buildpacks/pack/internal/commands"
 	"github.com/buildpacks/pack/internal/commands/testmocks"
 	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/logging"
 	h "github.com/buildpacks/pack/testhelpers"
 )
@@ -241,6 +243,61 @@ func testCreateCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("--format", func() {
+			it.Before(func() {
+				h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(validConfig), 0666))
+			})
+
+			it("saves the builder to the output path", func() {
+				outputPath := filepath.Join(tmpDir, "builder-layout")
+				mockClient.EXPECT().
+					CreateBuilder(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, opts client.CreateBuilderOptions) error {
+						h.AssertEq(t, opts.Format, client.FormatOCILayout)
+						h.AssertEq(t, opts.OutputPath, outputPath)
+						return nil
+					})
+
+				command.SetArgs([]string{
+					"some/builder",
+					"--config", builderConfigPath,
+					"--format", "oci-layout",
+					"--output", outputPath,
+				})
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), fmt.Sprintf("Successfully created builder 'some/builder' and saved to OCI layout '%s'", outputPath))
+			})
+
+			it("requires an output path", func() {
+				command.SetArgs([]string{
+					"some/builder",
+					"--config", builderConfigPath,
+					"--format", "docker-archive",
+				})
+				h.AssertError(t, command.Execute(), "Please provide a path to save the builder to, using --output.")
+			})
+
+			it("cannot be used with --publish", func() {
+				command.SetArgs([]string{
+					"some/builder",
+					"--config", builderConfigPath,
+					"--format", "oci-archive",
+					"--output", filepath.Join(tmpDir, "builder.tar"),
+					"--publish",
+				})
+				h.AssertError(t, command.Execute(), "--publish cannot be used with --format oci-archive")
+			})
+
+			it("errors for an unknown format", func() {
+				command.SetArgs([]string{
+					"some/builder",
+					"--config", builderConfigPath,
+					"--format", "zip",
+				})
+				h.AssertError(t, command.Execute(), "unknown format 'zip'")
+			})
+		})
+
 		when("warnings encountered in builder.toml", func() {
 			it.Before(func() {
 				h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(`
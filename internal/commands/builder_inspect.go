@@ -17,6 +17,7 @@ type BuilderInspector interface {
 type BuilderInspectFlags struct {
 	Depth        int
 	OutputFormat string
+	Freshness    bool
 }
 
 func BuilderInspect(logger logging.Logger,
@@ -49,6 +50,7 @@ func BuilderInspect(logger logging.Logger,
 
 	cmd.Flags().IntVarP(&flags.Depth, "depth", "d", builder.OrderDetectionMaxDepth, "Max depth to display for Detection Order.\nOmission of this flag or values < 0 will display the entire tree.")
 	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display builder detail (json, yaml, toml, human-readable).\nOmission of this flag will display as human-readable.")
+	cmd.Flags().BoolVar(&flags.Freshness, "freshness", false, "Check the builder, run images, lifecycle and buildpacks for newer versions.\nBuildpacks are looked up in the configured buildpack registries.")
 	AddHelpFlag(cmd, "inspect")
 	return cmd
 }
@@ -67,8 +69,17 @@ func inspectBuilder(
 		Trusted:   isTrustedBuilder(cfg, imageName),
 	}
 
-	localInfo, localErr := inspector.InspectBuilder(imageName, true, client.WithDetectionOrderDepth(flags.Depth))
-	remoteInfo, remoteErr := inspector.InspectBuilder(imageName, false, client.WithDetectionOrderDepth(flags.Depth))
+	modifiers := []client.BuilderInspectionModifier{client.WithDetectionOrderDepth(flags.Depth)}
+	if flags.Freshness {
+		var registryNames []string
+		for _, registry := range config.GetRegistries(cfg) {
+			registryNames = append(registryNames, registry.Name)
+		}
+		modifiers = append(modifiers, client.WithFreshness(registryNames))
+	}
+
+	localInfo, localErr := inspector.InspectBuilder(imageName, true, modifiers...)
+	remoteInfo, remoteErr := inspector.InspectBuilder(imageName, false, modifiers...)
 
 	writer, err := writerFactory.Writer(flags.OutputFormat)
 	if err != nil {
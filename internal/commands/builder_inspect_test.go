@@ -133,6 +133,34 @@ func testBuilderInspectCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("freshness flag is provided", func() {
+			it("passes a modifier with the configured registries to the builder inspector", func() {
+				builderInspector := newDefaultBuilderInspector()
+				command := commands.BuilderInspect(logger, cfg, builderInspector, newDefaultWriterFactory())
+				command.SetArgs([]string{"--freshness"})
+
+				err := command.Execute()
+				assert.Nil(err)
+
+				assert.Equal(builderInspector.CalculatedConfigForLocal.Freshness, true)
+				assert.Equal(builderInspector.CalculatedConfigForLocal.RegistryNames, []string{config.OfficialRegistryName})
+				assert.Equal(builderInspector.CalculatedConfigForRemote.Freshness, true)
+			})
+		})
+
+		when("freshness flag is not provided", func() {
+			it("does not check freshness", func() {
+				builderInspector := newDefaultBuilderInspector()
+				command := commands.BuilderInspect(logger, cfg, builderInspector, newDefaultWriterFactory())
+
+				err := command.Execute()
+				assert.Nil(err)
+
+				assert.Equal(builderInspector.CalculatedConfigForLocal.Freshness, false)
+				assert.Equal(builderInspector.CalculatedConfigForRemote.Freshness, false)
+			})
+		})
+
 		when("output type is set to json", func() {
 			it("passes json to the writer factory", func() {
 				writerFactory := newDefaultWriterFactory()
@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/Masterminds/semver"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuilderUpdateFlags define flags provided to the UpdateBuilder command
+type BuilderUpdateFlags struct {
+	Publish    bool
+	Registry   string
+	Policy     string
+	Buildpacks []string
+	Lifecycle  string
+	RunImages  []string
+}
+
+// BuilderUpdate updates the buildpacks, lifecycle or run images of an existing builder image
+func BuilderUpdate(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuilderUpdateFlags
+
+	cmd := &cobra.Command{
+		Use:     "update <image-name>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Update the buildpacks, lifecycle or run images of a builder image",
+		Example: "pack builder update my-builder:bionic --buildpack example/node@1.2.0 --lifecycle 0.18.5",
+		Long: `Update an existing builder image in place, instead of recreating it from a builder config.
+
+Buildpacks given with --buildpack replace the other versions of the same buildpacks on the builder, and the builder order is updated to use them. Only the layers of the updated buildpacks, lifecycle, order and run image metadata are added to the builder image; all other layers are reused.
+
+Replaced buildpacks are hidden by whiteout layers rather than removed, so the builder image does not get smaller. Recreate the builder with 'pack builder create' to reclaim their space.
+`,
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if err := validateUpdateFlags(&flags, cfg); err != nil {
+				return err
+			}
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "parsing pull policy %s", flags.Policy)
+			}
+
+			relativeBaseDir, err := os.Getwd()
+			if err != nil {
+				return errors.Wrap(err, "getting working directory")
+			}
+
+			var lifecycle builder.LifecycleConfig
+			if _, err := semver.NewVersion(flags.Lifecycle); err == nil {
+				lifecycle.Version = flags.Lifecycle
+			} else {
+				lifecycle.URI = flags.Lifecycle
+			}
+
+			imageName := args[0]
+			if err := pack.UpdateBuilder(cmd.Context(), client.UpdateBuilderOptions{
+				RelativeBaseDir: relativeBaseDir,
+				BuilderName:     imageName,
+				Buildpacks:      flags.Buildpacks,
+				Lifecycle:       lifecycle,
+				RunImages:       flags.RunImages,
+				Publish:         flags.Publish,
+				Registry:        flags.Registry,
+				PullPolicy:      pullPolicy,
+			}); err != nil {
+				return err
+			}
+			logger.Infof("Successfully updated builder image %s", style.Symbol(imageName))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.Registry, "buildpack-registry", "R", cfg.DefaultRegistryName, "Buildpack Registry by name")
+	if !cfg.Experimental {
+		cmd.Flags().MarkHidden("buildpack-registry")
+	}
+	cmd.Flags().StringArrayVarP(&flags.Buildpacks, "buildpack", "b", nil, "Buildpack to add to the builder in place of its other versions."+stringArrayHelp("buildpack"))
+	cmd.Flags().StringVar(&flags.Lifecycle, "lifecycle", "", "Lifecycle version or URI to replace the lifecycle of the builder with")
+	cmd.Flags().StringArrayVar(&flags.RunImages, "run-image", nil, "Run image to replace the run images of the builder with. The first one is the default."+stringArrayHelp("run-image"))
+	cmd.Flags().BoolVar(&flags.Publish, "publish", false, "Update the builder in the container registry specified in <image-name>, instead of the daemon.")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, and if-not-present. The default is always")
+
+	AddHelpFlag(cmd, "update")
+	return cmd
+}
+
+func validateUpdateFlags(flags *BuilderUpdateFlags, cfg config.Config) error {
+	if flags.Publish && flags.Policy == image.PullNever.String() {
+		return errors.Errorf("--publish and --pull-policy never cannot be used together. The --publish flag requires the use of remote images.")
+	}
+
+	if flags.Registry != "" && !cfg.Experimental {
+		return client.NewExperimentError("Support for buildpack registries is currently experimental.")
+	}
+
+	if len(flags.Buildpacks) == 0 && flags.Lifecycle == "" && len(flags.RunImages) == 0 {
+		return errors.Errorf("Please provide something to update, using --buildpack, --lifecycle or --run-image.")
+	}
+
+	return nil
+}
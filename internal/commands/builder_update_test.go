@@ -0,0 +1,97 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestUpdateCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "UpdateCommand", testUpdateCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testUpdateCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.BuilderUpdate(logging.NewLogWithWriters(&outBuf, &outBuf), config.Config{}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#Update", func() {
+		it("updates the builder", func() {
+			wd, err := os.Getwd()
+			h.AssertNil(t, err)
+
+			mockClient.EXPECT().UpdateBuilder(gomock.Any(), client.UpdateBuilderOptions{
+				RelativeBaseDir: wd,
+				BuilderName:     "some/builder",
+				Buildpacks:      []string{"example/foo@1.2.0", "docker://example/bar"},
+				Lifecycle:       builder.LifecycleConfig{Version: "0.18.5"},
+				RunImages:       []string{"some/run-image"},
+				PullPolicy:      image.PullAlways,
+			}).Return(nil)
+
+			command.SetArgs([]string{
+				"some/builder",
+				"--buildpack", "example/foo@1.2.0",
+				"--buildpack", "docker://example/bar",
+				"--lifecycle", "0.18.5",
+				"--run-image", "some/run-image",
+			})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Successfully updated builder image 'some/builder'")
+		})
+
+		it("treats a lifecycle that is not a version as a URI", func() {
+			var opts client.UpdateBuilderOptions
+			mockClient.EXPECT().UpdateBuilder(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, o client.UpdateBuilderOptions) error {
+					opts = o
+					return nil
+				})
+
+			command.SetArgs([]string{"some/builder", "--lifecycle", "./lifecycle.tgz"})
+			h.AssertNil(t, command.Execute())
+			h.AssertEq(t, opts.Lifecycle, builder.LifecycleConfig{URI: "./lifecycle.tgz"})
+		})
+
+		it("errors when there is nothing to update", func() {
+			command.SetArgs([]string{"some/builder"})
+			h.AssertError(t, command.Execute(), "Please provide something to update, using --buildpack, --lifecycle or --run-image.")
+		})
+
+		it("errors when both --publish and pull-policy=never flags are specified", func() {
+			command.SetArgs([]string{"some/builder", "--lifecycle", "0.18.5", "--publish", "--pull-policy", "never"})
+			h.AssertError(t, command.Execute(), "--publish and --pull-policy never cannot be used together")
+		})
+	})
+}
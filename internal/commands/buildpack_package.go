@@ -121,8 +121,11 @@ func BuildpackPackage(logger logging.Logger, cfg config.Config, packager Buildpa
 				action = "published"
 				location = "registry"
 			}
-			if flags.Format == client.FormatFile {
+			switch flags.Format {
+			case client.FormatFile:
 				location = "file"
+			case client.FormatOCILayout:
+				location = "OCI layout"
 			}
 			logger.Infof("Successfully %s package %s and saved to %s", action, style.Symbol(name), location)
 			return nil
@@ -130,7 +133,7 @@ func BuildpackPackage(logger logging.Logger, cfg config.Config, packager Buildpa
 	}
 
 	cmd.Flags().StringVarP(&flags.PackageTomlPath, "config", "c", "", "Path to package TOML config")
-	cmd.Flags().StringVarP(&flags.Format, "format", "f", "", `Format to save package as ("image" or "file")`)
+	cmd.Flags().StringVarP(&flags.Format, "format", "f", "", `Format to save package as ("image", "file" or "oci-layout")`)
 	cmd.Flags().BoolVar(&flags.Publish, "publish", false, `Publish the buildpack directly to the container registry specified in <name>, instead of the daemon (applies to "--format=image" only).`)
 	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, and if-not-present. The default is always")
 	cmd.Flags().StringVarP(&flags.Path, "path", "p", "", "Path to the Buildpack that needs to be packaged")
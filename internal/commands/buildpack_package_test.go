@@ -16,6 +16,7 @@ import (
 	"github.com/buildpacks/pack/internal/commands"
 	"github.com/buildpacks/pack/internal/commands/fakes"
 	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
@@ -88,6 +89,19 @@ func testPackageCommand(t *testing.T, when spec.G, it spec.S) {
 				h.AssertEq(t, receivedOptions.Config, myConfig)
 			})
 
+			when("oci-layout format", func() {
+				it("saves the package to the directory", func() {
+					cmd := packageCommand(withBuildpackPackager(fakeBuildpackPackager), withLogger(logger))
+					cmd.SetArgs([]string{"./some-layout", "-f", "oci-layout"})
+					h.AssertNil(t, cmd.Execute())
+
+					receivedOptions := fakeBuildpackPackager.CreateCalledWithOptions
+					h.AssertEq(t, receivedOptions.Name, "./some-layout")
+					h.AssertEq(t, receivedOptions.Format, client.FormatOCILayout)
+					h.AssertContains(t, outBuf.String(), "Successfully created package './some-layout' and saved to OCI layout")
+				})
+			})
+
 			when("file format", func() {
 				when("extension is .cnb", func() {
 					it("does not modify the name", func() {
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuildpackSearchFlags consist of flags applicable to the `buildpack search` command
+type BuildpackSearchFlags struct {
+	// BuildpackRegistry is the name of the buildpack registry to search. All configured registries are searched if
+	// it is empty.
+	BuildpackRegistry string
+}
+
+// BuildpackSearch searches buildpack registries for buildpacks
+func BuildpackSearch(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuildpackSearchFlags
+
+	cmd := &cobra.Command{
+		Use:   "search <term>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Search buildpack registries for buildpacks",
+		Long: "Search buildpack registries for buildpacks whose ID contains the given term.\n\n" +
+			"The term may end with a version or semver range, e.g. 'paketo-buildpacks/nodejs@^1.2', to only show matching versions.",
+		Example: "pack buildpack search nodejs\npack buildpack search 'paketo-buildpacks/nodejs@>=1.0 <2.0'",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			var registryNames []string
+			if flags.BuildpackRegistry != "" {
+				registry, err := config.GetRegistry(cfg, flags.BuildpackRegistry)
+				if err != nil {
+					return err
+				}
+				registryNames = []string{registry.Name}
+			} else {
+				for _, registry := range config.GetRegistries(cfg) {
+					registryNames = append(registryNames, registry.Name)
+				}
+			}
+
+			results, err := pack.SearchBuildpacks(client.SearchBuildpackOptions{
+				Term:          args[0],
+				RegistryNames: registryNames,
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(results) == 0 {
+				logger.Infof("No buildpacks found matching %s", style.Symbol(args[0]))
+				return nil
+			}
+
+			tw := tabwriter.NewWriter(logger.Writer(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "REGISTRY\tID\tVERSION\tYANKED\tADDRESS")
+			for _, result := range results {
+				yanked := "no"
+				if result.Yanked {
+					yanked = "yes"
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", result.Registry, result.ID, result.Version, yanked, result.Address)
+			}
+			return tw.Flush()
+		}),
+	}
+	cmd.Flags().StringVarP(&flags.BuildpackRegistry, "buildpack-registry", "r", "", "Buildpack Registry name. All configured registries are searched by default")
+	AddHelpFlag(cmd, "search")
+	return cmd
+}
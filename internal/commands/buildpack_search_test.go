@@ -0,0 +1,97 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildpackSearchCommand(t *testing.T) {
+	spec.Run(t, "BuildpackSearchCommand", testBuildpackSearchCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildpackSearchCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		cfg            config.Config
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		cfg = config.Config{
+			Registries: []config.Registry{{Name: "some-registry", Type: "http", URL: "https://example.com/index.json"}},
+		}
+
+		command = commands.BuildpackSearch(logger, cfg, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#BuildpackSearch", func() {
+		it("fails without a term", func() {
+			err := command.Execute()
+			h.AssertError(t, err, "accepts 1 arg")
+		})
+
+		it("searches every configured registry and prints the results", func() {
+			mockClient.EXPECT().
+				SearchBuildpacks(client.SearchBuildpackOptions{
+					Term:          "nodejs@^1.2",
+					RegistryNames: []string{"some-registry", "official"},
+				}).
+				Return([]client.BuildpackSearchResult{
+					{Registry: "official", ID: "paketo-buildpacks/nodejs", Version: "1.2.0", Address: "example.com/nodejs@sha256:abc"},
+					{Registry: "official", ID: "paketo-buildpacks/nodejs", Version: "1.3.0", Yanked: true, Address: "example.com/nodejs@sha256:def"},
+				}, nil)
+
+			command.SetArgs([]string{"nodejs@^1.2"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertContainsMatch(t, outBuf.String(), `REGISTRY\s+ID\s+VERSION\s+YANKED\s+ADDRESS`)
+			h.AssertContainsMatch(t, outBuf.String(), `official\s+paketo-buildpacks/nodejs\s+1.2.0\s+no\s+example.com/nodejs@sha256:abc`)
+			h.AssertContainsMatch(t, outBuf.String(), `official\s+paketo-buildpacks/nodejs\s+1.3.0\s+yes\s+example.com/nodejs@sha256:def`)
+		})
+
+		it("only searches the given registry", func() {
+			mockClient.EXPECT().
+				SearchBuildpacks(client.SearchBuildpackOptions{
+					Term:          "nodejs",
+					RegistryNames: []string{"some-registry"},
+				}).
+				Return(nil, nil)
+
+			command.SetArgs([]string{"nodejs", "--buildpack-registry", "some-registry"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "No buildpacks found matching")
+		})
+
+		it("returns errors from the client", func() {
+			mockClient.EXPECT().
+				SearchBuildpacks(gomock.Any()).
+				Return(nil, errors.New("some-error"))
+
+			command.SetArgs([]string{"nodejs"})
+			h.AssertError(t, command.Execute(), "some-error")
+		})
+	})
+}
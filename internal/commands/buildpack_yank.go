@@ -10,6 +10,7 @@ import (
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/logging"
+	registryTypes "github.com/buildpacks/pack/registry"
 )
 
 type BuildpackYankFlags struct {
@@ -37,10 +38,16 @@ func BuildpackYank(logger logging.Logger, cfg config.Config, pack PackClient) *c
 				return err
 			}
 
+			// git based registries are yanked from through GitHub issues
+			yankType := "github"
+			if registry.Type == registryTypes.TypeHTTP || registry.Type == registryTypes.TypeOCI {
+				yankType = registry.Type
+			}
+
 			opts := client.YankBuildpackOptions{
 				ID:      id,
 				Version: version,
-				Type:    "github",
+				Type:    yankType,
 				URL:     registry.URL,
 				Yank:    !flags.Undo,
 			}
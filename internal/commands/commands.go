@@ -14,24 +14,35 @@ import (
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/project"
+	"github.com/buildpacks/pack/pkg/sbom"
 )
 
 //go:generate mockgen -package testmocks -destination testmocks/mock_pack_client.go github.com/buildpacks/pack/internal/commands PackClient
 type PackClient interface {
 	InspectBuilder(string, bool, ...client.BuilderInspectionModifier) (*client.BuilderInfo, error)
 	InspectImage(string, bool) (*client.ImageInfo, error)
+	DiffImages(ctx context.Context, imageA, imageB string) (*client.ImageDiff, error)
 	Rebase(context.Context, client.RebaseOptions) error
+	RebaseAll(context.Context, client.RebaseAllOptions) (client.RebaseSummary, error)
 	CreateBuilder(context.Context, client.CreateBuilderOptions) error
+	UpdateBuilder(context.Context, client.UpdateBuilderOptions) error
 	NewBuildpack(context.Context, client.NewBuildpackOptions) error
 	PackageBuildpack(ctx context.Context, opts client.PackageBuildpackOptions) error
 	PackageExtension(ctx context.Context, opts client.PackageBuildpackOptions) error
 	Build(context.Context, client.BuildOptions) error
+	FetchGitSource(context.Context, string) (*client.GitCheckout, error)
+	VerifyReproducible(context.Context, client.BuildOptions) (*client.ReproducibilityReport, error)
+	ResolveLock(context.Context, client.BuildOptions) (project.Lock, error)
 	RegisterBuildpack(context.Context, client.RegisterBuildpackOptions) error
 	YankBuildpack(client.YankBuildpackOptions) error
 	InspectBuildpack(client.InspectBuildpackOptions) (*client.BuildpackInfo, error)
 	InspectExtension(client.InspectExtensionOptions) (*client.ExtensionInfo, error)
 	PullBuildpack(context.Context, client.PullBuildpackOptions) error
+	SearchBuildpacks(client.SearchBuildpackOptions) ([]client.BuildpackSearchResult, error)
 	DownloadSBOM(name string, options client.DownloadSBOMOptions) error
+	MergeSBOM(context.Context, client.MergeSBOMOptions) (*sbom.Document, error)
+	AttachSBOM(context.Context, client.AttachSBOMOptions) (string, error)
 }
 
 func AddHelpFlag(cmd *cobra.Command, commandName string) {
@@ -94,9 +105,13 @@ func getMirrors(config config.Config) map[string][]string {
 	return mirrors
 }
 
+// isTrustedBuilder returns true if the builder is trusted by name alone, i.e. it is a suggested builder or matches a
+// trusted builder entry that trusts the whole builder without requiring a digest or signature
 func isTrustedBuilder(cfg config.Config, builder string) bool {
 	for _, trustedBuilder := range cfg.TrustedBuilders {
-		if builder == trustedBuilder.Name {
+		unconditional := len(trustedBuilder.Digests) == 0 && len(trustedBuilder.SignatureKeys) == 0 &&
+			(trustedBuilder.Scope == "" || trustedBuilder.Scope == string(client.TrustScopeBuilder))
+		if unconditional && client.MatchesTrustPattern(trustedBuilder.Name, builder) {
 			return true
 		}
 	}
@@ -104,6 +119,28 @@ func isTrustedBuilder(cfg config.Config, builder string) bool {
 	return isSuggestedBuilder(builder)
 }
 
+// trustPolicy returns the policy deciding whether builders are trusted, from the trust builder flag, the suggested
+// builders and the trusted builder entries in the config
+func trustPolicy(cfg config.Config, builder string, trustBuilderFlag bool) client.TrustPolicy {
+	var policy client.TrustPolicy
+	if trustBuilderFlag {
+		policy.Rules = append(policy.Rules, client.TrustRule{Source: "the --trust-builder flag", Pattern: builder})
+	}
+	if isSuggestedBuilder(builder) {
+		policy.Rules = append(policy.Rules, client.TrustRule{Source: "the suggested builders", Pattern: builder})
+	}
+	for _, trustedBuilder := range cfg.TrustedBuilders {
+		policy.Rules = append(policy.Rules, client.TrustRule{
+			Source:        fmt.Sprintf("trusted-builders entry %s", style.Symbol(trustedBuilder.Name)),
+			Pattern:       trustedBuilder.Name,
+			Digests:       trustedBuilder.Digests,
+			SignatureKeys: trustedBuilder.SignatureKeys,
+			Scope:         client.TrustScope(trustedBuilder.Scope),
+		})
+	}
+	return policy
+}
+
 func deprecationWarning(logger logging.Logger, oldCmd, replacementCmd string) {
 	logger.Warnf("Command %s has been deprecated, please use %s instead", style.Symbol("pack "+oldCmd), style.Symbol("pack "+replacementCmd))
 }
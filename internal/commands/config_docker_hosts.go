@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+var dockerHostFlags config.DockerHost
+
+func ConfigDockerHosts(logger logging.Logger, cfg config.Config, cfgPath string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "docker-hosts",
+		Aliases: []string{"docker-host"},
+		Short:   "List, add and remove docker daemons to build on",
+		Long:    "Docker hosts are named docker daemons, e.g. remote machines of another architecture, that builds run on with `pack build --on <name>`.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			listDockerHosts(args, logger, cfg)
+			return nil
+		}),
+	}
+
+	listCmd := generateListCmd(cmd.Use, logger, cfg, listDockerHosts)
+	listCmd.Long = "List the docker hosts builds can run on."
+	listCmd.Example = "pack config docker-hosts list"
+	cmd.AddCommand(listCmd)
+
+	addCmd := generateAdd("docker host", logger, cfg, cfgPath, addDockerHost)
+	addCmd.Use = "add <name> <host>"
+	addCmd.Args = cobra.ExactArgs(2)
+	addCmd.Long = "Add a docker host to build on. Builds run on it with `pack build --on <name>`, or when it has a platform\nthe builder is available for, but the builder isn't available for the platform of the local daemon."
+	addCmd.Example = "pack config docker-hosts add arm-box ssh://user@arm-box.example.com --platform linux/arm64"
+	addCmd.Flags().StringVar(&dockerHostFlags.Platform, "platform", "", "Platform of the docker host, in the form os/arch[/variant], e.g. linux/arm64")
+	addCmd.Flags().StringVar(&dockerHostFlags.SSHIdentity, "ssh-identity", "", "Path of the private key used to connect to ssh:// hosts")
+	addCmd.Flags().StringVar(&dockerHostFlags.TLSCertPath, "tls-cert-path", "", "Directory with the ca.pem, cert.pem and key.pem files used to connect to tcp:// hosts over TLS")
+	addCmd.Flags().StringVar(&dockerHostFlags.LifecycleImage, "lifecycle-image", "", "Lifecycle image to use when building on the docker host")
+	cmd.AddCommand(addCmd)
+
+	rmCmd := generateRemove("docker host", logger, cfg, cfgPath, removeDockerHost)
+	rmCmd.Use = "remove <name>"
+	rmCmd.Long = "Remove a docker host."
+	rmCmd.Example = "pack config docker-hosts remove arm-box"
+	cmd.AddCommand(rmCmd)
+
+	AddHelpFlag(cmd, "docker-hosts")
+	return cmd
+}
+
+func addDockerHost(args []string, logger logging.Logger, cfg config.Config, cfgPath string) error {
+	dockerHost := dockerHostFlags
+	dockerHost.Name, dockerHost.Host = args[0], args[1]
+
+	if _, ok := config.GetDockerHost(cfg, dockerHost.Name); ok {
+		return errors.Errorf("docker host %s already exists", style.Symbol(dockerHost.Name))
+	}
+	if hostURL, err := url.Parse(dockerHost.Host); err != nil || hostURL.Scheme == "" {
+		return errors.Errorf("invalid docker host address %s, expected e.g. ssh://user@host or tcp://host:2376", style.Symbol(dockerHost.Host))
+	}
+	if dockerHost.Platform != "" {
+		if parts := strings.Split(dockerHost.Platform, "/"); len(parts) < 2 || len(parts) > 3 {
+			return errors.Errorf("invalid platform %s, expected os/arch[/variant]", style.Symbol(dockerHost.Platform))
+		}
+	}
+
+	cfg.DockerHosts = append(cfg.DockerHosts, dockerHost)
+	if err := config.Write(cfg, cfgPath); err != nil {
+		return errors.Wrapf(err, "writing config to %s", cfgPath)
+	}
+
+	logger.Infof("Successfully added docker host %s", style.Symbol(dockerHost.Name))
+	return nil
+}
+
+func removeDockerHost(args []string, logger logging.Logger, cfg config.Config, cfgPath string) error {
+	name := args[0]
+	if _, ok := config.GetDockerHost(cfg, name); !ok {
+		return errors.Errorf("docker host %s does not exist", style.Symbol(name))
+	}
+
+	var dockerHosts []config.DockerHost
+	for _, dockerHost := range cfg.DockerHosts {
+		if dockerHost.Name != name {
+			dockerHosts = append(dockerHosts, dockerHost)
+		}
+	}
+	cfg.DockerHosts = dockerHosts
+	if err := config.Write(cfg, cfgPath); err != nil {
+		return errors.Wrapf(err, "writing config to %s", cfgPath)
+	}
+
+	logger.Infof("Successfully removed docker host %s", style.Symbol(name))
+	return nil
+}
+
+func listDockerHosts(args []string, logger logging.Logger, cfg config.Config) {
+	if len(cfg.DockerHosts) == 0 {
+		logger.Info("No docker hosts have been added")
+		logging.Tip(logger, "Run %s to add a docker host", style.Symbol("pack config docker-hosts add <name> <host>"))
+		return
+	}
+
+	buf := strings.Builder{}
+	buf.WriteString("Docker Hosts:\n")
+	for _, dockerHost := range cfg.DockerHosts {
+		buf.WriteString(fmt.Sprintf("  %s: %s", dockerHost.Name, dockerHost.Host))
+		if dockerHost.Platform != "" {
+			buf.WriteString(fmt.Sprintf(" (%s)", dockerHost.Platform))
+		}
+		buf.WriteString("\n")
+	}
+	logger.Info(buf.String())
+}
+
+// daemonProfile converts a configured docker host to the daemon profile the client builds on
+func daemonProfile(dockerHost config.DockerHost) client.DaemonProfile {
+	return client.DaemonProfile{
+		Name:           dockerHost.Name,
+		Host:           dockerHost.Host,
+		Platform:       dockerHost.Platform,
+		SSHIdentity:    dockerHost.SSHIdentity,
+		TLSCertPath:    dockerHost.TLSCertPath,
+		LifecycleImage: dockerHost.LifecycleImage,
+	}
+}
@@ -0,0 +1,111 @@
+package commands_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestConfigDockerHosts(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ConfigDockerHostsCommand", testConfigDockerHostsCommand, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testConfigDockerHostsCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		cmd          *cobra.Command
+		logger       logging.Logger
+		outBuf       bytes.Buffer
+		tempPackHome string
+		configPath   string
+		armBox       = config.DockerHost{Name: "arm-box", Host: "ssh://user@arm-box", Platform: "linux/arm64"}
+		testCfg      = config.Config{DockerHosts: []config.DockerHost{armBox}}
+	)
+
+	it.Before(func() {
+		var err error
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		tempPackHome, err = os.MkdirTemp("", "pack-home")
+		h.AssertNil(t, err)
+		configPath = filepath.Join(tempPackHome, "config.toml")
+
+		cmd = commands.ConfigDockerHosts(logger, testCfg, configPath)
+		cmd.SetOut(logging.GetWriterForLevel(logger, logging.InfoLevel))
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tempPackHome))
+	})
+
+	when("list", func() {
+		it("lists the docker hosts", func() {
+			cmd.SetArgs([]string{"list"})
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), "arm-box: ssh://user@arm-box (linux/arm64)")
+		})
+
+		it("explains how to add docker hosts when there are none", func() {
+			cmd = commands.ConfigDockerHosts(logger, config.Config{}, configPath)
+			cmd.SetArgs([]string{})
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), "No docker hosts have been added")
+		})
+	})
+
+	when("add", func() {
+		it("adds the docker host to the config", func() {
+			cmd.SetArgs([]string{"add", "x86-box", "tcp://x86-box:2376", "--platform", "linux/amd64", "--tls-cert-path", "/certs", "--lifecycle-image", "some/lifecycle"})
+			h.AssertNil(t, cmd.Execute())
+
+			cfg, err := config.Read(configPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, cfg.DockerHosts, []config.DockerHost{
+				armBox,
+				{Name: "x86-box", Host: "tcp://x86-box:2376", Platform: "linux/amd64", TLSCertPath: "/certs", LifecycleImage: "some/lifecycle"},
+			})
+		})
+
+		it("fails for an existing name", func() {
+			cmd.SetArgs([]string{"add", "arm-box", "ssh://other-box"})
+			h.AssertError(t, cmd.Execute(), "docker host 'arm-box' already exists")
+		})
+
+		it("fails for an address without a scheme", func() {
+			cmd.SetArgs([]string{"add", "other-box", "other-box"})
+			h.AssertError(t, cmd.Execute(), "invalid docker host address 'other-box'")
+		})
+
+		it("fails for an invalid platform", func() {
+			cmd.SetArgs([]string{"add", "other-box", "ssh://other-box", "--platform", "arm64"})
+			h.AssertError(t, cmd.Execute(), "invalid platform 'arm64'")
+		})
+	})
+
+	when("remove", func() {
+		it("removes the docker host from the config", func() {
+			cmd.SetArgs([]string{"remove", "arm-box"})
+			h.AssertNil(t, cmd.Execute())
+
+			cfg, err := config.Read(configPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(cfg.DockerHosts), 0)
+		})
+
+		it("fails for a missing docker host", func() {
+			cmd.SetArgs([]string{"remove", "other-box"})
+			h.AssertError(t, cmd.Execute(), "docker host 'other-box' does not exist")
+		})
+	})
+}
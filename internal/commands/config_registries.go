@@ -46,7 +46,7 @@ func ConfigRegistries(logger logging.Logger, cfg config.Config, cfgPath string)
 	addCmd.Example = "pack config registries add my-registry https://github.com/buildpacks/my-registry"
 	addCmd.Long = bpRegistryExplanation + "Users can add registries from the config by using registries remove, and publish/yank buildpacks from it, as well as use those buildpacks when building applications."
 	addCmd.Flags().BoolVar(&setDefault, "default", false, "Set this buildpack registry as the default")
-	addCmd.Flags().StringVar(&registryType, "type", "github", "Type of buildpack registry [git|github]")
+	addCmd.Flags().StringVar(&registryType, "type", "github", "Type of buildpack registry [git|github|http|oci]")
 	cmd.AddCommand(addCmd)
 
 	rmCmd := generateRemove("registries", logger, cfg, cfgPath, removeRegistry)
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/sbom"
+)
+
+type ConvertSBOMFlags struct {
+	Format     string
+	OutputFile string
+}
+
+func ConvertSBOM(logger logging.Logger) *cobra.Command {
+	var flags ConvertSBOMFlags
+	cmd := &cobra.Command{
+		Use:     "convert <sbom-file>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Convert an SBoM between formats",
+		Long:    "Convert an SBoM document between CycloneDX, SPDX and Syft JSON. The format of the input is detected from its content.",
+		Example: "pack sbom convert sbom.cdx.json --format spdx --output-file sbom.spdx.json",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			format, err := sbom.ParseFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+
+			doc, err := sbom.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			return writeSBOM(logger, doc, format, flags.OutputFile)
+		}),
+	}
+	AddHelpFlag(cmd, "convert")
+	cmd.Flags().StringVarP(&flags.Format, "format", "f", "", "Format to convert to (cyclonedx, spdx, syft)")
+	cmd.Flags().StringVarP(&flags.OutputFile, "output-file", "o", "", "Path to write the converted document to.\nIt defaults to standard output.")
+	cmd.MarkFlagRequired("format")
+	return cmd
+}
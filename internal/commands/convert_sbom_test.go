@@ -0,0 +1,68 @@
+package commands_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestConvertSBOMCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ConvertSBOMCommand", testConvertSBOMCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testConvertSBOMCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command   *cobra.Command
+		outBuf    bytes.Buffer
+		tmpDir    string
+		inputFile string
+	)
+
+	it.Before(func() {
+		command = commands.ConvertSBOM(logging.NewLogWithWriters(&outBuf, &outBuf))
+
+		var err error
+		tmpDir, err = os.MkdirTemp("", "pack.convert.sbom.command.")
+		h.AssertNil(t, err)
+		inputFile = filepath.Join(tmpDir, "sbom.cdx.json")
+		h.AssertNil(t, os.WriteFile(inputFile,
+			[]byte(`{"bomFormat": "CycloneDX", "components": [{"type": "library", "name": "openssl", "version": "3.0.2"}]}`), 0600))
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#ConvertSBOM", func() {
+		it("converts the document to the requested format", func() {
+			command.SetArgs([]string{inputFile, "--format", "syft"})
+
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), `"artifacts": [`)
+			h.AssertContains(t, outBuf.String(), `"name": "openssl"`)
+		})
+
+		it("requires a format", func() {
+			command.SetArgs([]string{inputFile})
+			h.AssertError(t, command.Execute(), `required flag(s) "format" not set`)
+		})
+
+		it("fails on unrecognized documents", func() {
+			h.AssertNil(t, os.WriteFile(inputFile, []byte(`{}`), 0600))
+			command.SetArgs([]string{inputFile, "--format", "spdx"})
+			h.AssertError(t, command.Execute(), "unable to detect SBOM format")
+		})
+	})
+}
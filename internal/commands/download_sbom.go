@@ -9,6 +9,7 @@ import (
 
 type DownloadSBOMFlags struct {
 	Remote         bool
+	Referrer       bool
 	DestinationDir string
 }
 
@@ -26,7 +27,8 @@ func DownloadSBOM(
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
 			img := args[0]
 			options := cpkg.DownloadSBOMOptions{
-				Daemon:         !flags.Remote,
+				Daemon:         !flags.Remote && !flags.Referrer,
+				Referrer:       flags.Referrer,
 				DestinationDir: flags.DestinationDir,
 			}
 
@@ -35,6 +37,7 @@ func DownloadSBOM(
 	}
 	AddHelpFlag(cmd, "download")
 	cmd.Flags().BoolVar(&flags.Remote, "remote", false, "Download SBoM of image in remote registry (without pulling image)")
+	cmd.Flags().BoolVar(&flags.Referrer, "referrer", false, "Download SBoM attached to the image in its registry as an OCI referrer (see `pack sbom attach`), rather than the SBoM layer")
 	cmd.Flags().StringVarP(&flags.DestinationDir, "output-dir", "o", ".", "Path to export SBoM contents.\nIt defaults export to the current working directory.")
 	return cmd
 }
@@ -71,6 +71,20 @@ func testDownloadSBOMCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("the referrer flag is specified", func() {
+			it("downloads the SBoM attached to the remote image", func() {
+				mockClient.EXPECT().DownloadSBOM("some/image", cpkg.DownloadSBOMOptions{
+					Daemon:         false,
+					Referrer:       true,
+					DestinationDir: ".",
+				})
+				command.SetArgs([]string{"some/image", "--referrer"})
+
+				err := command.Execute()
+				h.AssertNil(t, err)
+			})
+		})
+
 		when("the output-dir flag is specified", func() {
 			it("respects the output-dir flag", func() {
 				mockClient.EXPECT().DownloadSBOM("some/image", cpkg.DownloadSBOMOptions{
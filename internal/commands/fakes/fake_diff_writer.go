@@ -0,0 +1,21 @@
+package fakes
+
+import (
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type FakeDiffWriter struct {
+	PrintForDiff  string
+	ErrorForPrint error
+
+	ReceivedDiff *client.ImageDiff
+}
+
+func (w *FakeDiffWriter) Print(logger logging.Logger, diff *client.ImageDiff) error {
+	w.ReceivedDiff = diff
+
+	logger.Infof("\nDIFF:\n%s\n", w.PrintForDiff)
+
+	return w.ErrorForPrint
+}
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type InspectDiffFlags struct {
+	OutputFormat string
+}
+
+func InspectDiff(logger logging.Logger, writerFactory InspectImageWriterFactory, client PackClient) *cobra.Command {
+	var flags InspectDiffFlags
+	cmd := &cobra.Command{
+		Use:   "diff <image-a> <image-b>",
+		Args:  cobra.ExactArgs(2),
+		Short: "Show the differences between two app images",
+		Long: "Compare two app images built using Cloud Native Buildpacks, showing changed buildpacks, run image, processes, " +
+			"bill of materials entries and buildpack layers. Images are read from the daemon if present, otherwise from their registry.",
+		Example: "pack inspect diff my-app:v1 my-app:v2",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			w, err := writerFactory.DiffWriter(flags.OutputFormat)
+			if err != nil {
+				return err
+			}
+
+			diff, err := client.DiffImages(cmd.Context(), args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			return w.Print(logger, diff)
+		}),
+	}
+	AddHelpFlag(cmd, "diff")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display the differences (json, yaml, toml, human-readable).\nOmission of this flag will display as human-readable.")
+	return cmd
+}
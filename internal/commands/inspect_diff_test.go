@@ -0,0 +1,106 @@
+package commands_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/fakes"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestInspectDiffCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Commands", testInspectDiffCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testInspectDiffCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		diffWriter     *fakes.FakeDiffWriter
+		writerFactory  *fakes.FakeInspectImageWriterFactory
+		expectedDiff   *client.ImageDiff
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+
+		diffWriter = &fakes.FakeDiffWriter{PrintForDiff: "Sample diff output"}
+		writerFactory = &fakes.FakeInspectImageWriterFactory{ReturnForDiffWriter: diffWriter}
+		expectedDiff = &client.ImageDiff{
+			ImageA:     "some/app:v1",
+			ImageB:     "some/app:v2",
+			Buildpacks: []client.BuildpackDiff{{ID: "some/bp", Status: client.DiffChanged, Before: "1.0.0", After: "1.1.0"}},
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#InspectDiff", func() {
+		it("passes the diff of both images to the writer", func() {
+			mockClient.EXPECT().DiffImages(gomock.Any(), "some/app:v1", "some/app:v2").Return(expectedDiff, nil)
+
+			command := commands.InspectDiff(logger, writerFactory, mockClient)
+			command.SetArgs([]string{"some/app:v1", "some/app:v2"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertEq(t, writerFactory.ReceivedForKind, "human-readable")
+			h.AssertEq(t, diffWriter.ReceivedDiff, expectedDiff)
+			h.AssertContains(t, outBuf.String(), "DIFF:\nSample diff output")
+		})
+
+		it("uses the requested output format", func() {
+			mockClient.EXPECT().DiffImages(gomock.Any(), "some/app:v1", "some/app:v2").Return(expectedDiff, nil)
+
+			command := commands.InspectDiff(logger, writerFactory, mockClient)
+			command.SetArgs([]string{"some/app:v1", "some/app:v2", "--output", "json"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertEq(t, writerFactory.ReceivedForKind, "json")
+		})
+
+		it("requires two images", func() {
+			command := commands.InspectDiff(logger, writerFactory, mockClient)
+			command.SetArgs([]string{"some/app:v1"})
+			h.AssertError(t, command.Execute(), "accepts 2 arg(s), received 1")
+		})
+
+		when("the writer cannot be created", func() {
+			it("returns the error", func() {
+				writerFactory.ErrorForDiffWriter = errors.New("unknown format")
+
+				command := commands.InspectDiff(logger, writerFactory, mockClient)
+				command.SetArgs([]string{"some/app:v1", "some/app:v2", "--output", "xml"})
+				h.AssertError(t, command.Execute(), "unknown format")
+			})
+		})
+
+		when("the images cannot be compared", func() {
+			it("returns the error", func() {
+				mockClient.EXPECT().DiffImages(gomock.Any(), "some/app:v1", "missing/app").
+					Return(nil, errors.New("unable to find image 'missing/app' locally or remotely"))
+
+				command := commands.InspectDiff(logger, writerFactory, mockClient)
+				command.SetArgs([]string{"some/app:v1", "missing/app"})
+				h.AssertError(t, command.Execute(), "unable to find image 'missing/app' locally or remotely")
+			})
+		})
+	})
+}
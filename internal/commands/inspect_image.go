@@ -14,6 +14,7 @@ import (
 //go:generate mockgen -package testmocks -destination testmocks/mock_inspect_image_writer_factory.go github.com/buildpacks/pack/internal/commands InspectImageWriterFactory
 type InspectImageWriterFactory interface {
 	Writer(kind string, BOM bool) (writer.InspectImageWriter, error)
+	DiffWriter(kind string) (writer.DiffWriter, error)
 }
 
 type InspectImageFlags struct {
@@ -33,6 +34,8 @@ func InspectImage(
 		Args:    cobra.ExactArgs(1),
 		Aliases: []string{"inspect-image"},
 		Short:   "Show information about a built app image",
+		Long: "Show information about a built app image. To inspect an image named like a subcommand of inspect, e.g. " +
+			"`diff`, separate it with `--`: `pack inspect -- diff`.",
 		Example: "pack inspect buildpacksio/pack",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
 			img := args[0]
@@ -61,6 +64,7 @@ func InspectImage(
 		}),
 	}
 	AddHelpFlag(cmd, "inspect")
+	cmd.AddCommand(InspectDiff(logger, writerFactory, client))
 	cmd.Flags().BoolVar(&flags.BOM, "bom", false, "print bill of materials")
 	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display builder detail (json, yaml, toml, human-readable).\nOmission of this flag will display as human-readable.")
 	return cmd
@@ -119,6 +119,32 @@ func testInspectImageCommand(t *testing.T, when spec.G, it spec.S) {
 			assert.ContainsF(outBuf.String(), "REMOTE:\n%s", expectedRemoteImageDisplay)
 		})
 
+		it("compares images with the diff subcommand", func() {
+			diffWriter := &fakes.FakeDiffWriter{PrintForDiff: "Sample diff output"}
+			inspectImageWriterFactory := &fakes.FakeInspectImageWriterFactory{ReturnForDiffWriter: diffWriter}
+			imageDiff := &client.ImageDiff{ImageA: "some/app:v1", ImageB: "some/app:v2"}
+
+			mockClient.EXPECT().DiffImages(gomock.Any(), "some/app:v1", "some/app:v2").Return(imageDiff, nil)
+			command := commands.InspectImage(logger, inspectImageWriterFactory, cfg, mockClient)
+			command.SetArgs([]string{"diff", "some/app:v1", "some/app:v2"})
+			assert.Nil(command.Execute())
+
+			assert.Equal(diffWriter.ReceivedDiff, imageDiff)
+		})
+
+		it("inspects images named like its subcommands after --", func() {
+			inspectImageWriter := newDefaultInspectImageWriter()
+			inspectImageWriterFactory := newImageWriterFactory(inspectImageWriter)
+
+			mockClient.EXPECT().InspectImage("diff", true).Return(expectedLocalImageInfo, nil)
+			mockClient.EXPECT().InspectImage("diff", false).Return(expectedRemoteImageInfo, nil)
+			command := commands.InspectImage(logger, inspectImageWriterFactory, cfg, mockClient)
+			command.SetArgs([]string{"--", "diff"})
+			assert.Nil(command.Execute())
+
+			assert.Equal(inspectImageWriter.ReceivedInfoForLocal, expectedLocalImageInfo)
+		})
+
 		it("passes output of local and remote builders to correct writer for extension", func() {
 			inspectImageWriter := newDefaultInspectImageWriter()
 			inspectImageWriterFactory := newImageWriterFactory(inspectImageWriter)
@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/project"
+)
+
+// LockFlags consist of flags applicable to the `lock` command
+type LockFlags struct {
+	Publish        bool
+	AppPath        string
+	DescriptorPath string
+	Builder        string
+	RunImage       string
+	Registry       string
+	Policy         string
+	Buildpacks     []string
+	Extensions     []string
+}
+
+// Lock resolves the inputs of a build and records them in the project's lock file
+func Lock(logger logging.Logger, cfg config.Config, packClient PackClient) *cobra.Command {
+	var flags LockFlags
+
+	cmd := &cobra.Command{
+		Use:   "lock [<image-name>]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Record the exact builder, run image and buildpacks used to build an app",
+		Long: "Lock resolves the builder, run image and buildpacks of an app to exact digests and checksums, and records them in " +
+			project.LockFileName + " next to the project descriptor. `pack build` uses the locked inputs while the lock file exists, " +
+			"so that later builds of the same source use the same inputs. The image name is only used to select a run image mirror.",
+		Example: "pack lock --path apps/test-app --builder cnbs/sample-builder:jammy",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			descriptor, actualDescriptorPath, err := parseProjectToml(flags.AppPath, flags.DescriptorPath, logger)
+			if err != nil {
+				return err
+			}
+
+			builder := flags.Builder
+			if !cmd.Flags().Changed("builder") && descriptor.Build.Builder != "" {
+				builder = descriptor.Build.Builder
+			}
+
+			if builder == "" {
+				suggestSettingBuilder(logger, packClient)
+				return client.NewSoftError()
+			}
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "parsing pull policy %s", flags.Policy)
+			}
+
+			var imageName string
+			if len(args) > 0 {
+				imageName = client.ParseInputImageReference(args[0]).Name()
+			}
+
+			lockPath := lockFilePath(flags.AppPath, actualDescriptorPath)
+			_, err = readOrUpdateLock(cmd.Context(), logger, packClient, client.BuildOptions{
+				AppPath:                  flags.AppPath,
+				Image:                    imageName,
+				Builder:                  builder,
+				Registry:                 flags.Registry,
+				AdditionalMirrors:        getMirrors(cfg),
+				RunImage:                 flags.RunImage,
+				Publish:                  flags.Publish,
+				PullPolicy:               pullPolicy,
+				Buildpacks:               flags.Buildpacks,
+				Extensions:               flags.Extensions,
+				ProjectDescriptorBaseDir: filepath.Dir(actualDescriptorPath),
+				ProjectDescriptor:        descriptor,
+			}, lockPath, true)
+			return err
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.AppPath, "path", "p", "", "Path to app dir (defaults to current working directory)")
+	cmd.Flags().StringVarP(&flags.DescriptorPath, "descriptor", "d", "", "Path to the project descriptor file")
+	cmd.Flags().StringVarP(&flags.Builder, "builder", "B", cfg.DefaultBuilder, "Builder image")
+	cmd.Flags().StringVar(&flags.RunImage, "run-image", "", "Run image (defaults to default stack image)")
+	cmd.Flags().StringSliceVarP(&flags.Buildpacks, "buildpack", "b", nil, "Buildpack to use, as accepted by `pack build`"+stringSliceHelp("buildpack"))
+	cmd.Flags().StringSliceVar(&flags.Extensions, "extension", nil, "Extension to use, as accepted by `pack build`"+stringSliceHelp("extension"))
+	cmd.Flags().StringVarP(&flags.Registry, "buildpack-registry", "r", cfg.DefaultRegistryName, "Buildpack Registry by name")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, and if-not-present. (default "always")`)
+	cmd.Flags().BoolVar(&flags.Publish, "publish", false, "Select the run image mirror used when publishing the image")
+	AddHelpFlag(cmd, "lock")
+	return cmd
+}
+
+// lockFilePath returns the path of the lock file of an app, which is stored next to its project descriptor. Apps that
+// are not in a local directory, like archives and OCI artifacts, only have a lock file next to an explicit descriptor,
+// and an empty path is returned otherwise.
+func lockFilePath(appPath, descriptorPath string) string {
+	if descriptorPath != "" {
+		return filepath.Join(filepath.Dir(descriptorPath), project.LockFileName)
+	}
+	if appPath != "" {
+		if fi, err := os.Stat(appPath); err != nil || !fi.IsDir() {
+			return ""
+		}
+	}
+	return filepath.Join(appPath, project.LockFileName)
+}
+
+// readOrUpdateLock returns the lock stored at lockPath, if any. If update is true, the inputs of the build are resolved
+// again and written to lockPath instead.
+func readOrUpdateLock(ctx context.Context, logger logging.Logger, packClient PackClient, opts client.BuildOptions, lockPath string, update bool) (*project.Lock, error) {
+	if lockPath == "" {
+		if update {
+			return nil, errors.Errorf("a descriptor is required to lock %s, as it is not a directory", style.Symbol(opts.AppPath))
+		}
+		return nil, nil
+	}
+
+	if update {
+		lock, err := packClient.ResolveLock(ctx, opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "resolving lock")
+		}
+		if err := project.WriteLock(lockPath, lock); err != nil {
+			return nil, errors.Wrapf(err, "writing lock file %s", style.Symbol(lockPath))
+		}
+		logger.Infof("Wrote lock file %s", style.Symbol(lockPath))
+		return &lock, nil
+	}
+
+	if _, err := os.Stat(lockPath); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	lock, err := project.ReadLock(lockPath)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debugf("Using lock file %s", style.Symbol(lockPath))
+	return &lock, nil
+}
@@ -0,0 +1,114 @@
+package commands_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/project"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLockCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "LockCommand", testLockCommand, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testLockCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		appDir         string
+	)
+
+	it.Before(func() {
+		var err error
+		appDir, err = os.MkdirTemp("", "lock-command")
+		h.AssertNil(t, err)
+
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.Lock(logger, config.Config{}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNilE(t, os.RemoveAll(appDir))
+	})
+
+	when("#Lock", func() {
+		it("writes the resolved inputs to the lock file", func() {
+			lock := project.Lock{
+				Version:  project.LockVersion,
+				Builder:  project.LockedImage{Name: "my-builder", Digest: "sha256:aaaa"},
+				RunImage: project.LockedImage{Name: "some/run", Digest: "sha256:bbbb"},
+				Buildpacks: []project.LockedBuildpack{
+					{Locator: "docker://some/buildpack", Image: "index.docker.io/some/buildpack@sha256:cccc"},
+				},
+			}
+			mockClient.EXPECT().
+				ResolveLock(gomock.Any(), EqBuildOptionsWithImage("my-builder", "")).
+				Return(lock, nil)
+
+			command.SetArgs([]string{"--builder", "my-builder", "--path", appDir})
+			h.AssertNil(t, command.Execute())
+
+			written, err := project.ReadLock(filepath.Join(appDir, project.LockFileName))
+			h.AssertNil(t, err)
+			h.AssertEq(t, written, lock)
+			h.AssertContains(t, outBuf.String(), "Wrote lock file")
+		})
+
+		it("uses the builder from the project descriptor", func() {
+			h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "project.toml"), []byte(`
+[_]
+schema-version = "0.2"
+
+[io.buildpacks]
+builder = "descriptor-builder"
+`), 0644))
+
+			mockClient.EXPECT().
+				ResolveLock(gomock.Any(), EqBuildOptionsWithImage("descriptor-builder", "")).
+				Return(project.Lock{}, nil)
+
+			command.SetArgs([]string{"--path", appDir})
+			h.AssertNil(t, command.Execute())
+			_, err := os.Stat(filepath.Join(appDir, project.LockFileName))
+			h.AssertNil(t, err)
+		})
+
+		it("requires a descriptor to lock an archive", func() {
+			appZip := filepath.Join(appDir, "app.zip")
+			h.AssertNil(t, os.WriteFile(appZip, []byte("zip"), 0600))
+
+			command.SetArgs([]string{"--builder", "my-builder", "--path", appZip})
+			h.AssertError(t, command.Execute(), "a descriptor is required to lock '"+appZip+"', as it is not a directory")
+		})
+
+		it("returns errors from the client", func() {
+			mockClient.EXPECT().
+				ResolveLock(gomock.Any(), gomock.Any()).
+				Return(project.Lock{}, errors.New("some-error"))
+
+			command.SetArgs([]string{"--builder", "my-builder", "--path", appDir})
+			h.AssertError(t, command.Execute(), "resolving lock: some-error")
+		})
+	})
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	cpkg "github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/sbom"
+)
+
+type MergeSBOMFlags struct {
+	Remote     bool
+	SBOMDirs   []string
+	Format     string
+	OutputFile string
+}
+
+func MergeSBOM(
+	logger logging.Logger,
+	client PackClient,
+) *cobra.Command {
+	var flags MergeSBOMFlags
+	cmd := &cobra.Command{
+		Use:   "merge [<image-name>]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Merge the SBoMs of an image into a single document",
+		Long: "Merge the launch, build and per-buildpack SBoMs of an image and/or SBoM directories (as written by " +
+			"`pack sbom download` or `pack build --sbom-output-dir`) into one CycloneDX, SPDX or Syft JSON document",
+		Example: "pack sbom merge buildpacksio/pack --format spdx --output-file sbom.spdx.json",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			format, err := sbom.ParseFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+
+			doc, err := client.MergeSBOM(cmd.Context(), sbomSourceOptions(args, flags.Remote, flags.SBOMDirs))
+			if err != nil {
+				return err
+			}
+
+			return writeSBOM(logger, doc, format, flags.OutputFile)
+		}),
+	}
+	AddHelpFlag(cmd, "merge")
+	cmd.Flags().BoolVar(&flags.Remote, "remote", false, "Read SBoM of image in remote registry (without pulling image)")
+	cmd.Flags().StringArrayVar(&flags.SBOMDirs, "sbom-dir", nil, "Directory containing SBoM files to include, e.g. the output of `pack build --sbom-output-dir`."+stringArrayHelp("sbom-dir"))
+	cmd.Flags().StringVarP(&flags.Format, "format", "f", string(sbom.FormatCycloneDX), "Format of the merged document (cyclonedx, spdx, syft)")
+	cmd.Flags().StringVarP(&flags.OutputFile, "output-file", "o", "", "Path to write the merged document to.\nIt defaults to standard output.")
+	return cmd
+}
+
+func sbomSourceOptions(args []string, remote bool, dirs []string) cpkg.MergeSBOMOptions {
+	opts := cpkg.MergeSBOMOptions{Daemon: !remote, Dirs: dirs}
+	if len(args) > 0 {
+		opts.Image = args[0]
+	}
+	return opts
+}
+
+func writeSBOM(logger logging.Logger, doc *sbom.Document, format sbom.Format, outputFile string) error {
+	data, err := sbom.Encode(doc, format)
+	if err != nil {
+		return err
+	}
+
+	if outputFile == "" {
+		_, err = logger.Writer().Write(data)
+		return err
+	}
+
+	if err := os.WriteFile(outputFile, data, 0644); err != nil {
+		return errors.Wrapf(err, "writing SBoM to %s", style.Symbol(outputFile))
+	}
+	logger.Infof("Wrote %d package(s) to %s", len(doc.Packages), style.Symbol(outputFile))
+	return nil
+}
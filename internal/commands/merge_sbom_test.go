@@ -0,0 +1,94 @@
+package commands_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	cpkg "github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/sbom"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestMergeSBOMCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "MergeSBOMCommand", testMergeSBOMCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testMergeSBOMCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		doc            *sbom.Document
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.MergeSBOM(logger, mockClient)
+
+		doc = &sbom.Document{Name: "some/image", Packages: []sbom.Package{{Name: "openssl", Version: "3.0.2"}}}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#MergeSBOM", func() {
+		it("prints a CycloneDX document by default", func() {
+			mockClient.EXPECT().MergeSBOM(gomock.Any(), cpkg.MergeSBOMOptions{Image: "some/image", Daemon: true}).Return(doc, nil)
+			command.SetArgs([]string{"some/image"})
+
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), `"bomFormat": "CycloneDX"`)
+			h.AssertContains(t, outBuf.String(), `"name": "openssl"`)
+		})
+
+		it("writes the requested format to a file", func() {
+			tmpDir, err := os.MkdirTemp("", "pack.merge.sbom.command.")
+			h.AssertNil(t, err)
+			defer os.RemoveAll(tmpDir)
+			outputFile := filepath.Join(tmpDir, "sbom.spdx.json")
+
+			mockClient.EXPECT().MergeSBOM(gomock.Any(), cpkg.MergeSBOMOptions{
+				Image:  "some/image",
+				Daemon: false,
+				Dirs:   []string{"some-dir", "other-dir"},
+			}).Return(doc, nil)
+			command.SetArgs([]string{"some/image", "--remote", "--sbom-dir", "some-dir", "--sbom-dir", "other-dir", "--format", "spdx", "--output-file", outputFile})
+
+			h.AssertNil(t, command.Execute())
+			contents, err := os.ReadFile(outputFile)
+			h.AssertNil(t, err)
+			h.AssertContains(t, string(contents), `"spdxVersion": "SPDX-2.3"`)
+			h.AssertContains(t, outBuf.String(), "Wrote 1 package(s) to")
+		})
+
+		it("rejects unknown formats", func() {
+			command.SetArgs([]string{"some/image", "--format", "xml"})
+			h.AssertError(t, command.Execute(), "unsupported SBOM format 'xml'")
+		})
+
+		it("returns client errors", func() {
+			mockClient.EXPECT().MergeSBOM(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			command.SetArgs([]string{"some/image"})
+			h.AssertError(t, command.Execute(), "some error")
+		})
+	})
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/sbom"
+)
+
+type QuerySBOMFlags struct {
+	Remote    bool
+	SBOMDirs  []string
+	InputFile string
+	Name      string
+	Version   string
+	License   string
+	Buildpack string
+}
+
+func QuerySBOM(
+	logger logging.Logger,
+	client PackClient,
+) *cobra.Command {
+	var flags QuerySBOMFlags
+	cmd := &cobra.Command{
+		Use:   "query [<image-name>]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "List packages recorded in SBoMs",
+		Long: "List the packages recorded in the SBoMs of an image, SBoM directories or an SBoM document, " +
+			"optionally filtered by name, version, license or buildpack. Filters are case-insensitive and accept shell patterns such as 'lib*'.",
+		Example: "pack sbom query buildpacksio/pack --license 'GPL*'",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			var doc *sbom.Document
+			var err error
+			switch {
+			case flags.InputFile != "" && (len(args) > 0 || len(flags.SBOMDirs) > 0):
+				return errors.Errorf("%s cannot be used with an image or %s", style.Symbol("--input-file"), style.Symbol("--sbom-dir"))
+			case flags.InputFile != "":
+				doc, err = sbom.ReadFile(flags.InputFile)
+			default:
+				doc, err = client.MergeSBOM(cmd.Context(), sbomSourceOptions(args, flags.Remote, flags.SBOMDirs))
+			}
+			if err != nil {
+				return err
+			}
+
+			packages := doc.Find(sbom.Query{
+				Name:      flags.Name,
+				Version:   flags.Version,
+				License:   flags.License,
+				Buildpack: flags.Buildpack,
+			})
+			if len(packages) == 0 {
+				logger.Info("No matching packages found")
+				return nil
+			}
+
+			tw := tabwriter.NewWriter(logger.Writer(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "NAME\tVERSION\tTYPE\tLICENSES\tBUILDPACK\tSCOPE")
+			for _, pkg := range packages {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+					pkg.Name, orDash(pkg.Version), orDash(pkg.Type), orDash(strings.Join(pkg.Licenses, ", ")), orDash(pkg.Buildpack), orDash(pkg.Scope))
+			}
+			return tw.Flush()
+		}),
+	}
+	AddHelpFlag(cmd, "query")
+	cmd.Flags().BoolVar(&flags.Remote, "remote", false, "Read SBoM of image in remote registry (without pulling image)")
+	cmd.Flags().StringArrayVar(&flags.SBOMDirs, "sbom-dir", nil, "Directory containing SBoM files to include, e.g. the output of `pack build --sbom-output-dir`."+stringArrayHelp("sbom-dir"))
+	cmd.Flags().StringVarP(&flags.InputFile, "input-file", "i", "", "Query a CycloneDX, SPDX or Syft JSON document instead of an image")
+	cmd.Flags().StringVar(&flags.Name, "name", "", "Only list packages with a matching name")
+	cmd.Flags().StringVar(&flags.Version, "version", "", "Only list packages with a matching version")
+	cmd.Flags().StringVar(&flags.License, "license", "", "Only list packages with a matching license")
+	cmd.Flags().StringVar(&flags.Buildpack, "buildpack", "", "Only list packages contributed by a matching buildpack ID")
+	return cmd
+}
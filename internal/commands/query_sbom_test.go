@@ -0,0 +1,77 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	cpkg "github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/sbom"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestQuerySBOMCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "QuerySBOMCommand", testQuerySBOMCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testQuerySBOMCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		doc            *sbom.Document
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.QuerySBOM(logger, mockClient)
+
+		doc = &sbom.Document{Packages: []sbom.Package{
+			{Name: "openssl", Version: "3.0.2", Licenses: []string{"Apache-2.0"}, Buildpack: "some/bp", Scope: sbom.ScopeLaunch},
+			{Name: "readline", Version: "8.1", Licenses: []string{"GPL-3.0-only"}, Buildpack: "some/bp", Scope: sbom.ScopeLaunch},
+		}}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#QuerySBOM", func() {
+		it("lists matching packages", func() {
+			mockClient.EXPECT().MergeSBOM(gomock.Any(), cpkg.MergeSBOMOptions{Image: "some/image", Daemon: true}).Return(doc, nil)
+			command.SetArgs([]string{"some/image", "--license", "GPL*"})
+
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "NAME      VERSION  TYPE  LICENSES      BUILDPACK  SCOPE")
+			h.AssertContains(t, outBuf.String(), "readline  8.1      -     GPL-3.0-only  some/bp    launch")
+			h.AssertNotContains(t, outBuf.String(), "openssl")
+		})
+
+		it("says when nothing matches", func() {
+			mockClient.EXPECT().MergeSBOM(gomock.Any(), gomock.Any()).Return(doc, nil)
+			command.SetArgs([]string{"some/image", "--name", "zlib"})
+
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "No matching packages found")
+		})
+
+		it("does not allow an input file with an image", func() {
+			command.SetArgs([]string{"some/image", "--input-file", "sbom.cdx.json"})
+			h.AssertError(t, command.Execute(), "'--input-file' cannot be used with an image or '--sbom-dir'")
+		})
+	})
+}
@@ -1,6 +1,12 @@
 package commands
 
 import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
 	"github.com/pkg/errors"
 
 	"github.com/spf13/cobra"
@@ -15,17 +21,26 @@ import (
 
 func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
 	var opts client.RebaseOptions
+	var allOpts rebaseAllFlags
 	var policy string
+	var smokeTest string
 
 	cmd := &cobra.Command{
-		Use:     "rebase <image-name>",
-		Args:    cobra.ExactArgs(1),
-		Short:   "Rebase app image with latest run image",
-		Example: "pack rebase buildpacksio/pack",
+		Use: "rebase <image-name>",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if allOpts.FromRunImage != "" {
+				return nil
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		Short: "Rebase app image with latest run image",
+		Example: "pack rebase buildpacksio/pack\n" +
+			"pack rebase --all-from cnbs/sample-stack-run:jammy --images-file images.txt --publish",
 		Long: "Rebase allows you to quickly swap out the underlying OS layers (run image) of an app image generated by `pack build` " +
-			"with a newer version of the run image, without re-building the application.",
+			"with a newer version of the run image, without re-building the application.\n\n" +
+			"With --all-from, every provided image (as arguments, via --images-file or discovered with --registry-prefix) " +
+			"that currently runs on the given run image is rebased.",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
-			opts.RepoName = args[0]
 			opts.AdditionalMirrors = getMirrors(cfg)
 
 			var err error
@@ -38,6 +53,19 @@ func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Co
 				return errors.Wrapf(err, "parsing pull policy %s", stringPolicy)
 			}
 
+			if smokeTest != "" {
+				opts.SmokeTest = []string{smokeTest}
+				opts.SmokeTestShell = true
+			}
+
+			if allOpts.FromRunImage != "" {
+				return rebaseAll(cmd, logger, pack, opts, allOpts, args)
+			}
+			if err := validateRebaseAllFlags(allOpts); err != nil {
+				return err
+			}
+
+			opts.RepoName = args[0]
 			if err := pack.Rebase(cmd.Context(), opts); err != nil {
 				return err
 			}
@@ -53,6 +81,123 @@ func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Co
 	cmd.Flags().StringVar(&opts.ReportDestinationDir, "report-output-dir", "", "Path to export build report.toml.\nOmitting the flag yield no report file.")
 	cmd.Flags().BoolVar(&opts.Force, "force", false, "Perform rebase operation without target validation (only available for API >= 0.12)")
 
+	cmd.Flags().BoolVar(&opts.Verify, "verify", false, "Verify that the new run image matches the stack, target, mixins and OS distribution recorded on the app image")
+	cmd.Flags().StringVar(&smokeTest, "smoke-test", "", "Shell command to run in a container of the rebased image before it is tagged, with /bin/sh, or cmd on Windows images; the rebase is discarded if the command fails (daemon only)")
+	cmd.Flags().BoolVar(&opts.Rollback, "rollback", false, "Rebase the app image back onto the run image it used before its last rebase")
+
+	cmd.Flags().StringVar(&allOpts.FromRunImage, "all-from", "", "Rebase every provided image that currently runs on this run image")
+	cmd.Flags().StringVar(&allOpts.ImagesFile, "images-file", "", "Path to a file listing images to rebase, one per line (requires --all-from)")
+	cmd.Flags().StringVar(&allOpts.RegistryPrefix, "registry-prefix", "", "Rebase images from every repository starting with this prefix, e.g. registry.example.com/team/ (requires --all-from)")
+	cmd.Flags().BoolVar(&allOpts.DryRun, "dry-run", false, "Only report which images would be rebased (requires --all-from)")
+	cmd.Flags().IntVar(&allOpts.Concurrency, "concurrency", 0, "Maximum number of images rebased at once (requires --all-from)")
+
 	AddHelpFlag(cmd, "rebase")
 	return cmd
 }
+
+type rebaseAllFlags struct {
+	FromRunImage   string
+	ImagesFile     string
+	RegistryPrefix string
+	DryRun         bool
+	Concurrency    int
+}
+
+func validateRebaseAllFlags(flags rebaseAllFlags) error {
+	if flags.ImagesFile != "" || flags.RegistryPrefix != "" || flags.DryRun || flags.Concurrency != 0 {
+		return errors.Errorf("%s, %s, %s and %s require %s",
+			style.Symbol("--images-file"), style.Symbol("--registry-prefix"), style.Symbol("--dry-run"), style.Symbol("--concurrency"), style.Symbol("--all-from"))
+	}
+	return nil
+}
+
+func rebaseAll(cmd *cobra.Command, logger logging.Logger, pack PackClient, opts client.RebaseOptions, flags rebaseAllFlags, args []string) error {
+	if opts.PreviousImage != "" || opts.ReportDestinationDir != "" || opts.Rollback {
+		return errors.Errorf("%s, %s and %s cannot be used with %s",
+			style.Symbol("--previous-image"), style.Symbol("--report-output-dir"), style.Symbol("--rollback"), style.Symbol("--all-from"))
+	}
+
+	images := args
+	if flags.ImagesFile != "" {
+		fileImages, err := readImagesFile(flags.ImagesFile)
+		if err != nil {
+			return err
+		}
+		images = append(images, fileImages...)
+	}
+
+	summary, err := pack.RebaseAll(cmd.Context(), client.RebaseAllOptions{
+		FromRunImage:      flags.FromRunImage,
+		Images:            images,
+		RegistryPrefix:    flags.RegistryPrefix,
+		RunImage:          opts.RunImage,
+		Publish:           opts.Publish,
+		PullPolicy:        opts.PullPolicy,
+		AdditionalMirrors: opts.AdditionalMirrors,
+		Force:             opts.Force,
+		Verify:            opts.Verify,
+		SmokeTest:         opts.SmokeTest,
+		SmokeTestShell:    opts.SmokeTestShell,
+		DryRun:            flags.DryRun,
+		Concurrency:       flags.Concurrency,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := writeRebaseSummary(logger, summary); err != nil {
+		return err
+	}
+
+	if failed := summary.Count(client.RebaseStatusFailed); failed > 0 {
+		return errors.Errorf("failed to rebase %d of %d images", failed, len(summary.Results))
+	}
+	return nil
+}
+
+// readImagesFile reads image names from path, one per line. Blank lines and lines starting with # are ignored.
+func readImagesFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading images file %s", style.Symbol(path))
+	}
+	defer file.Close()
+
+	var images []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		images = append(images, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "reading images file %s", style.Symbol(path))
+	}
+	return images, nil
+}
+
+func writeRebaseSummary(logger logging.Logger, summary client.RebaseSummary) error {
+	logger.Infof("\nRebase summary: %d rebased, %d would be rebased, %d skipped, %d failed",
+		summary.Count(client.RebaseStatusRebased),
+		summary.Count(client.RebaseStatusWouldRebase),
+		summary.Count(client.RebaseStatusSkipped),
+		summary.Count(client.RebaseStatusFailed),
+	)
+
+	tw := tabwriter.NewWriter(logger.Writer(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "IMAGE\tSTATUS\tPREVIOUS\tCURRENT\tDETAILS")
+	for _, result := range summary.Results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			result.Image, result.Status, orDash(result.PreviousDigest), orDash(result.Digest), result.Reason)
+	}
+	return tw.Flush()
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
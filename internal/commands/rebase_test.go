@@ -2,6 +2,8 @@ package commands_test
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/heroku/color"
@@ -158,6 +160,27 @@ func testRebaseCommand(t *testing.T, when spec.G, it spec.S) {
 					})
 				})
 			})
+			when("--verify and --smoke-test are provided", func() {
+				it("passes them through", func() {
+					opts.Verify = true
+					opts.SmokeTest = []string{"curl -f localhost:8080/health"}
+					opts.SmokeTestShell = true
+					mockClient.EXPECT().Rebase(gomock.Any(), opts).Return(nil)
+
+					command.SetArgs([]string{repoName, "--verify", "--smoke-test", "curl -f localhost:8080/health"})
+					h.AssertNil(t, command.Execute())
+				})
+			})
+
+			when("--rollback", func() {
+				it("passes it through", func() {
+					opts.Rollback = true
+					mockClient.EXPECT().Rebase(gomock.Any(), opts).Return(nil)
+
+					command.SetArgs([]string{repoName, "--rollback"})
+					h.AssertNil(t, command.Execute())
+				})
+			})
 			when("image name and previous image are provided", func() {
 				var expectedOpts client.RebaseOptions
 
@@ -197,5 +220,71 @@ func testRebaseCommand(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 		})
+
+		when("--all-from", func() {
+			var expectedOpts client.RebaseAllOptions
+
+			it.Before(func() {
+				expectedOpts = client.RebaseAllOptions{
+					FromRunImage:      "some/run",
+					Images:            []string{"some/app", "other/app"},
+					PullPolicy:        image.PullAlways,
+					AdditionalMirrors: map[string][]string{},
+				}
+			})
+
+			it("rebases the provided images and prints a summary", func() {
+				mockClient.EXPECT().
+					RebaseAll(gomock.Any(), expectedOpts).
+					Return(client.RebaseSummary{Results: []client.RebaseResult{
+						{Image: "some/app", Status: client.RebaseStatusRebased, PreviousDigest: "sha256:old", Digest: "sha256:new"},
+						{Image: "other/app", Status: client.RebaseStatusSkipped, PreviousDigest: "sha256:other", Reason: "run image 'other/run' does not match"},
+					}}, nil)
+
+				command.SetArgs([]string{"--all-from", "some/run", "some/app", "other/app"})
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), "Rebase summary: 1 rebased, 0 would be rebased, 1 skipped, 0 failed")
+				h.AssertContainsMatch(t, outBuf.String(), `some/app\s+rebased\s+sha256:old\s+sha256:new`)
+				h.AssertContainsMatch(t, outBuf.String(), `other/app\s+skipped\s+sha256:other\s+-\s+run image 'other/run' does not match`)
+			})
+
+			it("reads images from a file", func() {
+				imagesFile := filepath.Join(t.TempDir(), "images.txt")
+				h.AssertNil(t, os.WriteFile(imagesFile, []byte("# production apps\nsome/app\n\nother/app\n"), 0600))
+
+				expectedOpts.DryRun = true
+				expectedOpts.Concurrency = 2
+				mockClient.EXPECT().
+					RebaseAll(gomock.Any(), expectedOpts).
+					Return(client.RebaseSummary{}, nil)
+
+				command.SetArgs([]string{"--all-from", "some/run", "--images-file", imagesFile, "--dry-run", "--concurrency", "2"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("fails when any image could not be rebased", func() {
+				mockClient.EXPECT().
+					RebaseAll(gomock.Any(), expectedOpts).
+					Return(client.RebaseSummary{Results: []client.RebaseResult{
+						{Image: "some/app", Status: client.RebaseStatusFailed, Reason: "some error"},
+						{Image: "other/app", Status: client.RebaseStatusRebased},
+					}}, nil)
+
+				command.SetArgs([]string{"--all-from", "some/run", "some/app", "other/app"})
+				h.AssertError(t, command.Execute(), "failed to rebase 1 of 2 images")
+			})
+
+			it("cannot be combined with --previous-image", func() {
+				command.SetArgs([]string{"--all-from", "some/run", "--previous-image", "some/app:previous", "some/app"})
+				h.AssertError(t, command.Execute(), "cannot be used with '--all-from'")
+			})
+		})
+
+		when("bulk rebase flags are provided without --all-from", func() {
+			it("fails to run", func() {
+				command.SetArgs([]string{"some/app", "--dry-run"})
+				h.AssertError(t, command.Execute(), "require '--all-from'")
+			})
+		})
 	})
 }
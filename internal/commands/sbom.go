@@ -15,6 +15,10 @@ func NewSBOMCommand(logger logging.Logger, cfg config.Config, client PackClient)
 	}
 
 	cmd.AddCommand(DownloadSBOM(logger, client))
+	cmd.AddCommand(MergeSBOM(logger, client))
+	cmd.AddCommand(ConvertSBOM(logger))
+	cmd.AddCommand(QuerySBOM(logger, client))
+	cmd.AddCommand(AttachSBOM(logger, client))
 	AddHelpFlag(cmd, "sbom")
 	return cmd
 }
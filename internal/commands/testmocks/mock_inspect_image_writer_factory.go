@@ -35,6 +35,21 @@ func (m *MockInspectImageWriterFactory) EXPECT() *MockInspectImageWriterFactoryM
 	return m.recorder
 }
 
+// DiffWriter mocks base method.
+func (m *MockInspectImageWriterFactory) DiffWriter(arg0 string) (writer.DiffWriter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffWriter", arg0)
+	ret0, _ := ret[0].(writer.DiffWriter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffWriter indicates an expected call of DiffWriter.
+func (mr *MockInspectImageWriterFactoryMockRecorder) DiffWriter(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffWriter", reflect.TypeOf((*MockInspectImageWriterFactory)(nil).DiffWriter), arg0)
+}
+
 // Writer mocks base method.
 func (m *MockInspectImageWriterFactory) Writer(arg0 string, arg1 bool) (writer.InspectImageWriter, error) {
 	m.ctrl.T.Helper()
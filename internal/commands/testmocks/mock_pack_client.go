@@ -8,9 +8,10 @@ import (
 	context "context"
 	reflect "reflect"
 
-	gomock "github.com/golang/mock/gomock"
-
 	client "github.com/buildpacks/pack/pkg/client"
+	project "github.com/buildpacks/pack/pkg/project"
+	sbom "github.com/buildpacks/pack/pkg/sbom"
+	gomock "github.com/golang/mock/gomock"
 )
 
 // MockPackClient is a mock of PackClient interface.
@@ -36,6 +37,21 @@ func (m *MockPackClient) EXPECT() *MockPackClientMockRecorder {
 	return m.recorder
 }
 
+// AttachSBOM mocks base method.
+func (m *MockPackClient) AttachSBOM(arg0 context.Context, arg1 client.AttachSBOMOptions) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachSBOM", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AttachSBOM indicates an expected call of AttachSBOM.
+func (mr *MockPackClientMockRecorder) AttachSBOM(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachSBOM", reflect.TypeOf((*MockPackClient)(nil).AttachSBOM), arg0, arg1)
+}
+
 // Build mocks base method.
 func (m *MockPackClient) Build(arg0 context.Context, arg1 client.BuildOptions) error {
 	m.ctrl.T.Helper()
@@ -64,6 +80,21 @@ func (mr *MockPackClientMockRecorder) CreateBuilder(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBuilder", reflect.TypeOf((*MockPackClient)(nil).CreateBuilder), arg0, arg1)
 }
 
+// DiffImages mocks base method.
+func (m *MockPackClient) DiffImages(arg0 context.Context, arg1, arg2 string) (*client.ImageDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffImages", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*client.ImageDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffImages indicates an expected call of DiffImages.
+func (mr *MockPackClientMockRecorder) DiffImages(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffImages", reflect.TypeOf((*MockPackClient)(nil).DiffImages), arg0, arg1, arg2)
+}
+
 // DownloadSBOM mocks base method.
 func (m *MockPackClient) DownloadSBOM(arg0 string, arg1 client.DownloadSBOMOptions) error {
 	m.ctrl.T.Helper()
@@ -78,6 +109,21 @@ func (mr *MockPackClientMockRecorder) DownloadSBOM(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadSBOM", reflect.TypeOf((*MockPackClient)(nil).DownloadSBOM), arg0, arg1)
 }
 
+// FetchGitSource mocks base method.
+func (m *MockPackClient) FetchGitSource(arg0 context.Context, arg1 string) (*client.GitCheckout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchGitSource", arg0, arg1)
+	ret0, _ := ret[0].(*client.GitCheckout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchGitSource indicates an expected call of FetchGitSource.
+func (mr *MockPackClientMockRecorder) FetchGitSource(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchGitSource", reflect.TypeOf((*MockPackClient)(nil).FetchGitSource), arg0, arg1)
+}
+
 // InspectBuilder mocks base method.
 func (m *MockPackClient) InspectBuilder(arg0 string, arg1 bool, arg2 ...client.BuilderInspectionModifier) (*client.BuilderInfo, error) {
 	m.ctrl.T.Helper()
@@ -143,6 +189,21 @@ func (mr *MockPackClientMockRecorder) InspectImage(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectImage", reflect.TypeOf((*MockPackClient)(nil).InspectImage), arg0, arg1)
 }
 
+// MergeSBOM mocks base method.
+func (m *MockPackClient) MergeSBOM(arg0 context.Context, arg1 client.MergeSBOMOptions) (*sbom.Document, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeSBOM", arg0, arg1)
+	ret0, _ := ret[0].(*sbom.Document)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeSBOM indicates an expected call of MergeSBOM.
+func (mr *MockPackClientMockRecorder) MergeSBOM(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeSBOM", reflect.TypeOf((*MockPackClient)(nil).MergeSBOM), arg0, arg1)
+}
+
 // NewBuildpack mocks base method.
 func (m *MockPackClient) NewBuildpack(arg0 context.Context, arg1 client.NewBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -213,6 +274,21 @@ func (mr *MockPackClientMockRecorder) Rebase(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebase", reflect.TypeOf((*MockPackClient)(nil).Rebase), arg0, arg1)
 }
 
+// RebaseAll mocks base method.
+func (m *MockPackClient) RebaseAll(arg0 context.Context, arg1 client.RebaseAllOptions) (client.RebaseSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebaseAll", arg0, arg1)
+	ret0, _ := ret[0].(client.RebaseSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RebaseAll indicates an expected call of RebaseAll.
+func (mr *MockPackClientMockRecorder) RebaseAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebaseAll", reflect.TypeOf((*MockPackClient)(nil).RebaseAll), arg0, arg1)
+}
+
 // RegisterBuildpack mocks base method.
 func (m *MockPackClient) RegisterBuildpack(arg0 context.Context, arg1 client.RegisterBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -227,6 +303,65 @@ func (mr *MockPackClientMockRecorder) RegisterBuildpack(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterBuildpack", reflect.TypeOf((*MockPackClient)(nil).RegisterBuildpack), arg0, arg1)
 }
 
+// ResolveLock mocks base method.
+func (m *MockPackClient) ResolveLock(arg0 context.Context, arg1 client.BuildOptions) (project.Lock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveLock", arg0, arg1)
+	ret0, _ := ret[0].(project.Lock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveLock indicates an expected call of ResolveLock.
+func (mr *MockPackClientMockRecorder) ResolveLock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveLock", reflect.TypeOf((*MockPackClient)(nil).ResolveLock), arg0, arg1)
+}
+
+// SearchBuildpacks mocks base method.
+func (m *MockPackClient) SearchBuildpacks(arg0 client.SearchBuildpackOptions) ([]client.BuildpackSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchBuildpacks", arg0)
+	ret0, _ := ret[0].([]client.BuildpackSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchBuildpacks indicates an expected call of SearchBuildpacks.
+func (mr *MockPackClientMockRecorder) SearchBuildpacks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBuildpacks", reflect.TypeOf((*MockPackClient)(nil).SearchBuildpacks), arg0)
+}
+
+// UpdateBuilder mocks base method.
+func (m *MockPackClient) UpdateBuilder(arg0 context.Context, arg1 client.UpdateBuilderOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBuilder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBuilder indicates an expected call of UpdateBuilder.
+func (mr *MockPackClientMockRecorder) UpdateBuilder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBuilder", reflect.TypeOf((*MockPackClient)(nil).UpdateBuilder), arg0, arg1)
+}
+
+// VerifyReproducible mocks base method.
+func (m *MockPackClient) VerifyReproducible(arg0 context.Context, arg1 client.BuildOptions) (*client.ReproducibilityReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyReproducible", arg0, arg1)
+	ret0, _ := ret[0].(*client.ReproducibilityReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyReproducible indicates an expected call of VerifyReproducible.
+func (mr *MockPackClientMockRecorder) VerifyReproducible(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyReproducible", reflect.TypeOf((*MockPackClient)(nil).VerifyReproducible), arg0, arg1)
+}
+
 // YankBuildpack mocks base method.
 func (m *MockPackClient) YankBuildpack(arg0 client.YankBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -23,6 +23,8 @@ type Config struct {
 	LifecycleImage      string            `toml:"lifecycle-image,omitempty"`
 	RegistryMirrors     map[string]string `toml:"registry-mirrors,omitempty"`
 	LayoutRepositoryDir string            `toml:"layout-repo-dir,omitempty"`
+	EgressAllowlist     []string          `toml:"egress-allowlist,omitempty"`
+	DockerHosts         []DockerHost      `toml:"docker-hosts,omitempty"`
 }
 
 type Registry struct {
@@ -37,7 +39,39 @@ type RunImage struct {
 }
 
 type TrustedBuilder struct {
+	// Name of the builder image, or a glob matching builder repositories, e.g. "gcr.io/my-org/*".
 	Name string `toml:"name"`
+
+	// Digests the builder must resolve to for it to be trusted.
+	Digests []string `toml:"digests,omitempty"`
+
+	// Paths to PEM encoded public keys. The builder must have a cosign signature made with one of them to be trusted.
+	SignatureKeys []string `toml:"signature-keys,omitempty"`
+
+	// Scope of the trust, either "builder" (the default) or "buildpacks" to trust the buildpacks of the builder,
+	// but not its lifecycle.
+	Scope string `toml:"scope,omitempty"`
+}
+
+// DockerHost is a named docker daemon builds can run on, using `pack build --on <name>`.
+type DockerHost struct {
+	Name string `toml:"name"`
+
+	// Address of the daemon, e.g. ssh://user@host or tcp://host:2376.
+	Host string `toml:"host"`
+
+	// Platform of the daemon, in the form os/arch[/variant]. Builds run on the daemon when the builder is available for
+	// its platform, but not for the platform of the local daemon.
+	Platform string `toml:"platform,omitempty"`
+
+	// Path of the private key used to connect to ssh:// hosts.
+	SSHIdentity string `toml:"ssh-identity,omitempty"`
+
+	// Directory with the ca.pem, cert.pem and key.pem files used to connect to tcp:// hosts over TLS.
+	TLSCertPath string `toml:"tls-cert-path,omitempty"`
+
+	// Lifecycle image to use when building on the daemon, instead of the configured lifecycle image.
+	LifecycleImage string `toml:"lifecycle-image,omitempty"`
 }
 
 const OfficialRegistryName = "official"
@@ -96,6 +130,16 @@ func MkdirAll(path string) error {
 	return os.MkdirAll(path, 0750)
 }
 
+// GetDockerHost returns the docker host with the given name
+func GetDockerHost(cfg Config, name string) (DockerHost, bool) {
+	for _, dockerHost := range cfg.DockerHosts {
+		if dockerHost.Name == name {
+			return dockerHost, true
+		}
+	}
+	return DockerHost{}, false
+}
+
 func SetRunImageMirrors(cfg Config, image string, mirrors []string) Config {
 	for i := range cfg.RunImages {
 		if cfg.RunImages[i].Image == image {
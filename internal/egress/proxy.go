@@ -0,0 +1,318 @@
+// Package egress provides an allowlisting HTTP(S) proxy used to restrict and record the hosts contacted during a build.
+package egress
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const proxyUser = "pack"
+
+// Report lists the hosts contacted through the proxy.
+type Report struct {
+	Contacted []string `toml:"contacted" json:"contacted"`
+	Blocked   []string `toml:"blocked" json:"blocked"`
+}
+
+// Proxy is an HTTP proxy that only forwards requests to allowed hosts. HTTPS traffic is tunnelled using CONNECT and
+// is not intercepted, so hosts are matched by name only.
+//
+// Clients must authenticate with the credentials included in URL, so that the proxy may safely listen on an address
+// reachable from build containers.
+type Proxy struct {
+	allow     []string
+	token     string
+	upstream  func(*url.URL) (*url.URL, error)
+	listener  net.Listener
+	server    *http.Server
+	transport *http.Transport
+
+	mu        sync.Mutex
+	contacted map[string]bool
+	blocked   map[string]bool
+}
+
+// NewProxy returns a proxy allowing the given hosts. A host may start with "*." to allow all of its subdomains.
+// Allowed requests are sent through the proxy upstream returns for their URL, if any, e.g. the proxy configured with
+// the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables. When upstream is nil, hosts are contacted directly.
+func NewProxy(allow []string, upstream func(*url.URL) (*url.URL, error)) (*Proxy, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return nil, errors.Wrap(err, "generating proxy token")
+	}
+
+	var normalized []string
+	for _, host := range allow {
+		normalized = append(normalized, strings.ToLower(strings.TrimSpace(host)))
+	}
+
+	if upstream == nil {
+		upstream = func(*url.URL) (*url.URL, error) { return nil, nil }
+	}
+
+	return &Proxy{
+		allow:    normalized,
+		token:    hex.EncodeToString(token),
+		upstream: upstream,
+		transport: &http.Transport{
+			Proxy:       func(r *http.Request) (*url.URL, error) { return upstream(r.URL) },
+			DialContext: (&net.Dialer{Timeout: 30 * time.Second}).DialContext,
+		},
+		contacted: map[string]bool{},
+		blocked:   map[string]bool{},
+	}, nil
+}
+
+// Start listens on addr, e.g. "0.0.0.0:0", and serves in the background until Close is called.
+func (p *Proxy) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return errors.Wrapf(err, "starting egress proxy on %s", addr)
+	}
+	p.listener = listener
+	p.server = &http.Server{Handler: p, ReadHeaderTimeout: 30 * time.Second}
+	go p.server.Serve(listener)
+	return nil
+}
+
+// Port returns the port the proxy is listening on.
+func (p *Proxy) Port() int {
+	return p.listener.Addr().(*net.TCPAddr).Port
+}
+
+// URL returns the proxy URL, including credentials, for clients connecting through host.
+func (p *Proxy) URL(host string) string {
+	return fmt.Sprintf("http://%s:%s@%s", proxyUser, p.token, net.JoinHostPort(host, fmt.Sprint(p.Port())))
+}
+
+// Close stops the proxy, closing any open connections.
+func (p *Proxy) Close() error {
+	if p.server == nil {
+		return nil
+	}
+	p.transport.CloseIdleConnections()
+	return p.server.Close()
+}
+
+// Allowed returns whether requests to host, with or without a port, are allowed.
+func (p *Proxy) Allowed(host string) bool {
+	host = hostname(host)
+	for _, pattern := range p.allow {
+		switch {
+		case pattern == "*":
+			return true
+		case strings.HasPrefix(pattern, "*."):
+			if strings.HasSuffix(host, pattern[1:]) {
+				return true
+			}
+		case host == pattern:
+			return true
+		}
+	}
+	return false
+}
+
+// Report returns the sorted hosts contacted and blocked so far.
+func (p *Proxy) Report() Report {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return Report{Contacted: sortedKeys(p.contacted), Blocked: sortedKeys(p.blocked)}
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !p.authorized(r) {
+		w.Header().Set("Proxy-Authenticate", `Basic realm="pack"`)
+		http.Error(w, "proxy authentication required", http.StatusProxyAuthRequired)
+		return
+	}
+
+	host := r.Host
+	if r.Method != http.MethodConnect && r.URL.Host != "" {
+		host = r.URL.Host
+	}
+	if !p.record(host) {
+		http.Error(w, fmt.Sprintf("pack egress policy: host %s is not allowed", hostname(host)), http.StatusForbidden)
+		return
+	}
+
+	if r.Method == http.MethodConnect {
+		p.tunnel(w, r)
+		return
+	}
+	p.forward(w, r)
+}
+
+func (p *Proxy) authorized(r *http.Request) bool {
+	auth := r.Header.Get("Proxy-Authorization")
+	r.Header.Del("Proxy-Authorization")
+
+	encoded, ok := strings.CutPrefix(auth, "Basic ")
+	if !ok {
+		return false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return false
+	}
+	return string(decoded) == proxyUser+":"+p.token
+}
+
+func (p *Proxy) record(host string) bool {
+	allowed := p.Allowed(host)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if allowed {
+		p.contacted[hostname(host)] = true
+	} else {
+		p.blocked[hostname(host)] = true
+	}
+	return allowed
+}
+
+func (p *Proxy) tunnel(w http.ResponseWriter, r *http.Request) {
+	upstream, err := p.dial(r.Host)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		upstream.Close()
+		http.Error(w, "hijacking not supported", http.StatusInternalServerError)
+		return
+	}
+	client, _, err := hijacker.Hijack()
+	if err != nil {
+		upstream.Close()
+		return
+	}
+	if _, err := client.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n")); err != nil {
+		client.Close()
+		upstream.Close()
+		return
+	}
+
+	go func() {
+		defer upstream.Close()
+		defer client.Close()
+		io.Copy(upstream, client)
+	}()
+	go func() {
+		defer upstream.Close()
+		defer client.Close()
+		io.Copy(client, upstream)
+	}()
+}
+
+// dial connects to host, through a CONNECT tunnel when there is an upstream proxy for it.
+func (p *Proxy) dial(host string) (net.Conn, error) {
+	proxyURL, err := p.upstream(&url.URL{Scheme: "https", Host: host})
+	if err != nil {
+		return nil, err
+	}
+	if proxyURL == nil {
+		return net.DialTimeout("tcp", host, 30*time.Second)
+	}
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	var conn net.Conn
+	if proxyURL.Scheme == "https" {
+		conn, err = tls.DialWithDialer(dialer, "tcp", proxyAddr(proxyURL), &tls.Config{ServerName: proxyURL.Hostname(), MinVersion: tls.VersionTLS12})
+	} else {
+		conn, err = dialer.Dial("tcp", proxyAddr(proxyURL))
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "connecting to proxy %s", proxyURL.Host)
+	}
+
+	connectReq := &http.Request{Method: http.MethodConnect, URL: &url.URL{Opaque: host}, Host: host, Header: http.Header{}}
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(proxyURL.User.Username() + ":" + password))
+		connectReq.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+
+	conn.SetDeadline(time.Now().Add(30 * time.Second))
+	if err := connectReq.Write(conn); err != nil {
+		conn.Close()
+		return nil, errors.Wrapf(err, "connecting to %s through proxy %s", host, proxyURL.Host)
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), connectReq)
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrapf(err, "connecting to %s through proxy %s", host, proxyURL.Host)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, errors.Errorf("connecting to %s through proxy %s: %s", host, proxyURL.Host, resp.Status)
+	}
+	conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+func (p *Proxy) forward(w http.ResponseWriter, r *http.Request) {
+	outReq := r.Clone(r.Context())
+	outReq.RequestURI = ""
+	for _, header := range []string{"Proxy-Connection", "Proxy-Authenticate", "Connection", "Keep-Alive", "Te", "Trailer", "Upgrade"} {
+		outReq.Header.Del(header)
+	}
+
+	resp, err := p.transport.RoundTrip(outReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	for key, values := range resp.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body)
+}
+
+// proxyAddr returns the address of the proxy, with the default port of its scheme if none is given.
+func proxyAddr(proxyURL *url.URL) string {
+	if proxyURL.Port() != "" {
+		return proxyURL.Host
+	}
+	if proxyURL.Scheme == "https" {
+		return net.JoinHostPort(proxyURL.Hostname(), "443")
+	}
+	return net.JoinHostPort(proxyURL.Hostname(), "80")
+}
+
+func hostname(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(strings.Trim(host, "[]"))
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,212 @@
+package egress_test
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/egress"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestProxy(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Proxy", testProxy, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testProxy(t *testing.T, when spec.G, it spec.S) {
+	var (
+		backend    *httptest.Server
+		tlsBackend *httptest.Server
+	)
+
+	it.Before(func() {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("some-response"))
+		})
+		backend = httptest.NewServer(handler)
+		tlsBackend = httptest.NewTLSServer(handler)
+	})
+
+	it.After(func() {
+		backend.Close()
+		tlsBackend.Close()
+	})
+
+	newProxy := func(allow ...string) *egress.Proxy {
+		proxy, err := egress.NewProxy(allow, nil)
+		h.AssertNil(t, err)
+		h.AssertNil(t, proxy.Start("127.0.0.1:0"))
+		return proxy
+	}
+
+	clientFor := func(proxyURL string) *http.Client {
+		u, err := url.Parse(proxyURL)
+		h.AssertNil(t, err)
+		return &http.Client{Transport: &http.Transport{
+			Proxy:           http.ProxyURL(u),
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+		}}
+	}
+
+	when("#Allowed", func() {
+		it("matches hosts exactly or by subdomain wildcard", func() {
+			proxy, err := egress.NewProxy([]string{"example.com", "*.npmjs.org"}, nil)
+			h.AssertNil(t, err)
+
+			h.AssertTrue(t, proxy.Allowed("example.com"))
+			h.AssertTrue(t, proxy.Allowed("EXAMPLE.com:443"))
+			h.AssertTrue(t, proxy.Allowed("registry.npmjs.org"))
+			h.AssertTrue(t, proxy.Allowed("a.b.npmjs.org:443"))
+			h.AssertFalse(t, proxy.Allowed("npmjs.org"))
+			h.AssertFalse(t, proxy.Allowed("sub.example.com"))
+			h.AssertFalse(t, proxy.Allowed("example.com.evil.io"))
+		})
+
+		it("allows everything with '*'", func() {
+			proxy, err := egress.NewProxy([]string{"*"}, nil)
+			h.AssertNil(t, err)
+			h.AssertTrue(t, proxy.Allowed("anything.example.com"))
+		})
+	})
+
+	when("the host is allowed", func() {
+		it("forwards http requests and records the host", func() {
+			proxy := newProxy("127.0.0.1")
+			defer proxy.Close()
+
+			resp, err := clientFor(proxy.URL("127.0.0.1")).Get(backend.URL)
+			h.AssertNil(t, err)
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(body), "some-response")
+			h.AssertEq(t, proxy.Report(), egress.Report{Contacted: []string{"127.0.0.1"}, Blocked: []string{}})
+		})
+
+		it("tunnels https requests", func() {
+			proxy := newProxy("127.0.0.1")
+			defer proxy.Close()
+
+			resp, err := clientFor(proxy.URL("127.0.0.1")).Get(tlsBackend.URL)
+			h.AssertNil(t, err)
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(body), "some-response")
+			h.AssertEq(t, proxy.Report().Contacted, []string{"127.0.0.1"})
+		})
+	})
+
+	when("there is an upstream proxy", func() {
+		var upstream *egress.Proxy
+
+		it.Before(func() {
+			upstream = newProxy("127.0.0.1")
+		})
+
+		it.After(func() {
+			upstream.Close()
+		})
+
+		newChainedProxy := func(noProxy string) *egress.Proxy {
+			upstreamURL, err := url.Parse(upstream.URL("127.0.0.1"))
+			h.AssertNil(t, err)
+			proxy, err := egress.NewProxy([]string{"127.0.0.1"}, func(u *url.URL) (*url.URL, error) {
+				if u.Hostname() == noProxy {
+					return nil, nil
+				}
+				return upstreamURL, nil
+			})
+			h.AssertNil(t, err)
+			h.AssertNil(t, proxy.Start("127.0.0.1:0"))
+			return proxy
+		}
+
+		it("forwards http requests through it", func() {
+			proxy := newChainedProxy("")
+			defer proxy.Close()
+
+			resp, err := clientFor(proxy.URL("127.0.0.1")).Get(backend.URL)
+			h.AssertNil(t, err)
+			defer resp.Body.Close()
+
+			h.AssertEq(t, resp.StatusCode, http.StatusOK)
+			h.AssertEq(t, upstream.Report().Contacted, []string{"127.0.0.1"})
+		})
+
+		it("tunnels https requests through it", func() {
+			proxy := newChainedProxy("")
+			defer proxy.Close()
+
+			resp, err := clientFor(proxy.URL("127.0.0.1")).Get(tlsBackend.URL)
+			h.AssertNil(t, err)
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(body), "some-response")
+			h.AssertEq(t, upstream.Report().Contacted, []string{"127.0.0.1"})
+		})
+
+		it("contacts hosts it is not used for directly", func() {
+			proxy := newChainedProxy("127.0.0.1")
+			defer proxy.Close()
+
+			resp, err := clientFor(proxy.URL("127.0.0.1")).Get(tlsBackend.URL)
+			h.AssertNil(t, err)
+			defer resp.Body.Close()
+
+			h.AssertEq(t, resp.StatusCode, http.StatusOK)
+			h.AssertEq(t, upstream.Report().Contacted, []string{})
+		})
+	})
+
+	when("the host is not allowed", func() {
+		it("rejects http requests and records the host as blocked", func() {
+			proxy := newProxy("example.com")
+			defer proxy.Close()
+
+			resp, err := clientFor(proxy.URL("127.0.0.1")).Get(backend.URL)
+			h.AssertNil(t, err)
+			defer resp.Body.Close()
+
+			h.AssertEq(t, resp.StatusCode, http.StatusForbidden)
+			h.AssertEq(t, proxy.Report(), egress.Report{Contacted: []string{}, Blocked: []string{"127.0.0.1"}})
+		})
+
+		it("rejects https requests", func() {
+			proxy := newProxy("example.com")
+			defer proxy.Close()
+
+			_, err := clientFor(proxy.URL("127.0.0.1")).Get(tlsBackend.URL)
+			h.AssertNotNil(t, err)
+			h.AssertEq(t, proxy.Report().Blocked, []string{"127.0.0.1"})
+		})
+	})
+
+	when("the client does not authenticate", func() {
+		it("rejects the request without recording it", func() {
+			proxy := newProxy("127.0.0.1")
+			defer proxy.Close()
+
+			resp, err := clientFor(fmt.Sprintf("http://127.0.0.1:%d", proxy.Port())).Get(backend.URL)
+			h.AssertNil(t, err)
+			defer resp.Body.Close()
+
+			h.AssertEq(t, resp.StatusCode, http.StatusProxyAuthRequired)
+			h.AssertEq(t, proxy.Report(), egress.Report{Contacted: []string{}, Blocked: []string{}})
+		})
+	})
+}
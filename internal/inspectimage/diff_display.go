@@ -0,0 +1,118 @@
+package inspectimage
+
+import (
+	"github.com/buildpacks/lifecycle/launch"
+
+	"github.com/buildpacks/pack/pkg/client"
+)
+
+type RunImageDiffDisplay struct {
+	Before BaseDisplay `json:"before" yaml:"before" toml:"before"`
+	After  BaseDisplay `json:"after" yaml:"after" toml:"after"`
+}
+
+type BuildpackDiffDisplay struct {
+	ID     string `json:"id" yaml:"id" toml:"id"`
+	Status string `json:"status" yaml:"status" toml:"status"`
+	Before string `json:"before,omitempty" yaml:"before,omitempty" toml:"before,omitempty"`
+	After  string `json:"after,omitempty" yaml:"after,omitempty" toml:"after,omitempty"`
+}
+
+type ProcessDiffDisplay struct {
+	Type   string          `json:"type" yaml:"type" toml:"type"`
+	Status string          `json:"status" yaml:"status" toml:"status"`
+	Before *ProcessDisplay `json:"before,omitempty" yaml:"before,omitempty" toml:"before,omitempty"`
+	After  *ProcessDisplay `json:"after,omitempty" yaml:"after,omitempty" toml:"after,omitempty"`
+}
+
+type BOMDiffDisplay struct {
+	Name      string `json:"name" yaml:"name" toml:"name"`
+	Buildpack string `json:"buildpack" yaml:"buildpack" toml:"buildpack"`
+	Status    string `json:"status" yaml:"status" toml:"status"`
+	Before    string `json:"before,omitempty" yaml:"before,omitempty" toml:"before,omitempty"`
+	After     string `json:"after,omitempty" yaml:"after,omitempty" toml:"after,omitempty"`
+}
+
+type LayerDiffDisplay struct {
+	Name      string `json:"name" yaml:"name" toml:"name"`
+	Buildpack string `json:"buildpack,omitempty" yaml:"buildpack,omitempty" toml:"buildpack,omitempty"`
+	Status    string `json:"status" yaml:"status" toml:"status"`
+	Before    string `json:"before,omitempty" yaml:"before,omitempty" toml:"before,omitempty"`
+	After     string `json:"after,omitempty" yaml:"after,omitempty" toml:"after,omitempty"`
+}
+
+type DiffDisplay struct {
+	ImageA     string                 `json:"image_a" yaml:"image_a" toml:"image_a"`
+	ImageB     string                 `json:"image_b" yaml:"image_b" toml:"image_b"`
+	RunImage   *RunImageDiffDisplay   `json:"run_image,omitempty" yaml:"run_image,omitempty" toml:"run_image,omitempty"`
+	Buildpacks []BuildpackDiffDisplay `json:"buildpacks" yaml:"buildpacks" toml:"buildpacks"`
+	Processes  []ProcessDiffDisplay   `json:"processes" yaml:"processes" toml:"processes"`
+	BOM        []BOMDiffDisplay       `json:"bom" yaml:"bom" toml:"bom"`
+	Layers     []LayerDiffDisplay     `json:"layers" yaml:"layers" toml:"layers"`
+}
+
+func NewDiffDisplay(diff *client.ImageDiff) *DiffDisplay {
+	display := &DiffDisplay{
+		ImageA:     diff.ImageA,
+		ImageB:     diff.ImageB,
+		Buildpacks: []BuildpackDiffDisplay{},
+		Processes:  []ProcessDiffDisplay{},
+		BOM:        []BOMDiffDisplay{},
+		Layers:     []LayerDiffDisplay{},
+	}
+
+	if diff.RunImage != nil {
+		display.RunImage = &RunImageDiffDisplay{
+			Before: displayBase(diff.RunImage.Before),
+			After:  displayBase(diff.RunImage.After),
+		}
+	}
+
+	for _, bp := range diff.Buildpacks {
+		display.Buildpacks = append(display.Buildpacks, BuildpackDiffDisplay{
+			ID:     bp.ID,
+			Status: string(bp.Status),
+			Before: bp.Before,
+			After:  bp.After,
+		})
+	}
+
+	for _, proc := range diff.Processes {
+		display.Processes = append(display.Processes, ProcessDiffDisplay{
+			Type:   proc.Type,
+			Status: string(proc.Status),
+			Before: displayProcess(proc.Before),
+			After:  displayProcess(proc.After),
+		})
+	}
+
+	for _, entry := range diff.BOM {
+		display.BOM = append(display.BOM, BOMDiffDisplay{
+			Name:      entry.Name,
+			Buildpack: entry.Buildpack,
+			Status:    string(entry.Status),
+			Before:    entry.Before,
+			After:     entry.After,
+		})
+	}
+
+	for _, layer := range diff.Layers {
+		display.Layers = append(display.Layers, LayerDiffDisplay{
+			Name:      layer.Name,
+			Buildpack: layer.Buildpack,
+			Status:    string(layer.Status),
+			Before:    layer.Before,
+			After:     layer.After,
+		})
+	}
+
+	return display
+}
+
+func displayProcess(proc *launch.Process) *ProcessDisplay {
+	if proc == nil || len(proc.Command.Entries) == 0 {
+		return nil
+	}
+	display := convertToDisplay(*proc, proc.Default)
+	return &display
+}
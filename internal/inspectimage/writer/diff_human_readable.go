@@ -0,0 +1,106 @@
+package writer
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/buildpacks/pack/internal/inspectimage"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type HumanReadableDiff struct{}
+
+func NewHumanReadableDiff() *HumanReadableDiff {
+	return &HumanReadableDiff{}
+}
+
+func (h *HumanReadableDiff) Print(logger logging.Logger, diff *client.ImageDiff) error {
+	logger.Infof("Comparing %s with %s\n", style.Symbol(diff.ImageA), style.Symbol(diff.ImageB))
+
+	if diff.Empty() {
+		logger.Info("\nNo differences found\n")
+		return nil
+	}
+
+	display := inspectimage.NewDiffDisplay(diff)
+
+	if display.RunImage != nil {
+		logger.Info("\nRun Image:\n")
+		logger.Infof("  Reference: %s -> %s\n", valueOrNone(display.RunImage.Before.Reference), valueOrNone(display.RunImage.After.Reference))
+		logger.Infof("  Top Layer: %s -> %s\n", valueOrNone(display.RunImage.Before.TopLayer), valueOrNone(display.RunImage.After.TopLayer))
+	}
+
+	var rows [][]string
+	for _, bp := range display.Buildpacks {
+		rows = append(rows, []string{bp.ID, bp.Status, valueOrNone(bp.Before), valueOrNone(bp.After)})
+	}
+	if err := writeDiffTable(logger, "Buildpacks", []string{"ID", "STATUS", "BEFORE", "AFTER"}, rows); err != nil {
+		return err
+	}
+
+	rows = nil
+	for _, proc := range display.Processes {
+		rows = append(rows, []string{proc.Type, proc.Status, processCommand(proc.Before), processCommand(proc.After)})
+	}
+	if err := writeDiffTable(logger, "Processes", []string{"TYPE", "STATUS", "BEFORE", "AFTER"}, rows); err != nil {
+		return err
+	}
+
+	rows = nil
+	for _, entry := range display.BOM {
+		rows = append(rows, []string{entry.Buildpack, entry.Name, entry.Status, valueOrNone(entry.Before), valueOrNone(entry.After)})
+	}
+	if err := writeDiffTable(logger, "BOM", []string{"BUILDPACK", "NAME", "STATUS", "BEFORE", "AFTER"}, rows); err != nil {
+		return err
+	}
+
+	rows = nil
+	for _, layer := range display.Layers {
+		rows = append(rows, []string{layer.Name, layer.Status, valueOrNone(shortDigest(layer.Before)), valueOrNone(shortDigest(layer.After))})
+	}
+	return writeDiffTable(logger, "Layers", []string{"NAME", "STATUS", "BEFORE", "AFTER"}, rows)
+}
+
+func writeDiffTable(logger logging.Logger, title string, header []string, rows [][]string) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	buf := &bytes.Buffer{}
+	tw := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "  %s\n", strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintf(tw, "  %s\n", strings.Join(row, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	logger.Infof("\n%s:\n%s", title, buf.String())
+	return nil
+}
+
+func processCommand(proc *inspectimage.ProcessDisplay) string {
+	if proc == nil {
+		return "(none)"
+	}
+	return strings.Join(append([]string{proc.Command}, proc.Args...), " ")
+}
+
+func shortDigest(digest string) string {
+	if algorithm, hex, ok := strings.Cut(digest, ":"); ok && len(hex) > 12 {
+		return algorithm + ":" + hex[:12]
+	}
+	return digest
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
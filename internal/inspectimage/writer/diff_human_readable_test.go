@@ -0,0 +1,115 @@
+package writer_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/buildpacks/lifecycle/launch"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/inspectimage/writer"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestHumanReadableDiff(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Human Readable Diff Writer", testHumanReadableDiff, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testHumanReadableDiff(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert = h.NewAssertionManager(t)
+		outBuf bytes.Buffer
+		diff   *client.ImageDiff
+	)
+
+	it.Before(func() {
+		diff = &client.ImageDiff{
+			ImageA: "some/app:v1",
+			ImageB: "some/app:v2",
+			RunImage: &client.RunImageDiff{
+				Before: files.RunImageForRebase{TopLayer: "sha256:old-top-layer", Reference: "some/run@sha256:old"},
+				After:  files.RunImageForRebase{TopLayer: "sha256:new-top-layer", Reference: "some/run@sha256:new"},
+			},
+			Buildpacks: []client.BuildpackDiff{
+				{ID: "some/bp", Status: client.DiffChanged, Before: "1.0.0", After: "1.1.0"},
+				{ID: "new/bp", Status: client.DiffAdded, After: "0.1.0"},
+			},
+			Processes: []client.ProcessDiff{
+				{
+					Type:   "web",
+					Status: client.DiffChanged,
+					Before: &launch.Process{Type: "web", Command: launch.NewRawCommand([]string{"/start"}), Args: []string{"--port", "8080"}},
+					After:  &launch.Process{Type: "web", Command: launch.NewRawCommand([]string{"/start"}), Args: []string{"--port", "9090"}},
+				},
+				{
+					Type:   "worker",
+					Status: client.DiffRemoved,
+					Before: &launch.Process{Type: "worker", Command: launch.NewRawCommand([]string{"/work"})},
+				},
+			},
+			BOM: []client.BOMDiff{
+				{Name: "node", Buildpack: "some/bp", Status: client.DiffChanged, Before: "18.0.0", After: "20.0.0"},
+			},
+			Layers: []client.LayerDiff{
+				{
+					Name:      "some/bp:node",
+					Buildpack: "some/bp",
+					Status:    client.DiffChanged,
+					Before:    "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+					After:     "sha256:2222222222222222222222222222222222222222222222222222222222222222",
+				},
+			},
+		}
+	})
+
+	it.After(func() {
+		outBuf.Reset()
+	})
+
+	when("#Print", func() {
+		it("prints every difference", func() {
+			humanReadableWriter := writer.NewHumanReadableDiff()
+
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			assert.Succeeds(humanReadableWriter.Print(logger, diff))
+
+			assert.Contains(outBuf.String(), "Comparing 'some/app:v1' with 'some/app:v2'")
+			assert.Contains(outBuf.String(), `Run Image:
+  Reference: some/run@sha256:old -> some/run@sha256:new
+  Top Layer: sha256:old-top-layer -> sha256:new-top-layer`)
+			assert.Contains(outBuf.String(), `Buildpacks:
+  ID       STATUS   BEFORE  AFTER
+  some/bp  changed  1.0.0   1.1.0
+  new/bp   added    (none)  0.1.0`)
+			assert.Contains(outBuf.String(), `Processes:
+  TYPE    STATUS   BEFORE              AFTER
+  web     changed  /start --port 8080  /start --port 9090
+  worker  removed  /work               (none)`)
+			assert.Contains(outBuf.String(), `BOM:
+  BUILDPACK  NAME  STATUS   BEFORE  AFTER
+  some/bp    node  changed  18.0.0  20.0.0`)
+			assert.Contains(outBuf.String(), `Layers:
+  NAME          STATUS   BEFORE               AFTER
+  some/bp:node  changed  sha256:111111111111  sha256:222222222222`)
+		})
+
+		when("there are no differences", func() {
+			it("says so", func() {
+				humanReadableWriter := writer.NewHumanReadableDiff()
+
+				logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+				assert.Succeeds(humanReadableWriter.Print(logger, &client.ImageDiff{ImageA: "some/app:v1", ImageB: "some/app:v1"}))
+
+				assert.Contains(outBuf.String(), "No differences found")
+				assert.NotContains(outBuf.String(), "Buildpacks:")
+			})
+		})
+	})
+}
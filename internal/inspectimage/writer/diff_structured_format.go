@@ -0,0 +1,33 @@
+package writer
+
+import (
+	"github.com/buildpacks/pack/internal/inspectimage"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type StructuredDiffFormat struct {
+	MarshalFunc func(interface{}) ([]byte, error)
+}
+
+func NewJSONDiff() *StructuredDiffFormat {
+	return &StructuredDiffFormat{MarshalFunc: NewJSON().MarshalFunc}
+}
+
+func NewYAMLDiff() *StructuredDiffFormat {
+	return &StructuredDiffFormat{MarshalFunc: NewYAML().MarshalFunc}
+}
+
+func NewTOMLDiff() *StructuredDiffFormat {
+	return &StructuredDiffFormat{MarshalFunc: NewTOML().MarshalFunc}
+}
+
+func (w *StructuredDiffFormat) Print(logger logging.Logger, diff *client.ImageDiff) error {
+	out, err := w.MarshalFunc(inspectimage.NewDiffDisplay(diff))
+	if err != nil {
+		return err
+	}
+
+	_, err = logger.Writer().Write(out)
+	return err
+}
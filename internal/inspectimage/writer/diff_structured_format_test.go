@@ -0,0 +1,105 @@
+package writer_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/buildpacks/lifecycle/launch"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/inspectimage/writer"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestStructuredDiffFormat(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Structured Diff Writer", testStructuredDiffFormat, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testStructuredDiffFormat(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert = h.NewAssertionManager(t)
+		outBuf bytes.Buffer
+		diff   *client.ImageDiff
+	)
+
+	it.Before(func() {
+		diff = &client.ImageDiff{
+			ImageA: "some/app:v1",
+			ImageB: "some/app:v2",
+			Buildpacks: []client.BuildpackDiff{
+				{ID: "some/bp", Status: client.DiffChanged, Before: "1.0.0", After: "1.1.0"},
+			},
+			Processes: []client.ProcessDiff{
+				{
+					Type:   "worker",
+					Status: client.DiffAdded,
+					After:  &launch.Process{Type: "worker", Command: launch.NewRawCommand([]string{"/work"})},
+				},
+			},
+		}
+	})
+
+	it.After(func() {
+		outBuf.Reset()
+	})
+
+	when("#Print", func() {
+		it("prints json", func() {
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			assert.Succeeds(writer.NewJSONDiff().Print(logger, diff))
+
+			assert.EqualJSON(outBuf.String(), `{
+  "image_a": "some/app:v1",
+  "image_b": "some/app:v2",
+  "buildpacks": [
+    {"id": "some/bp", "status": "changed", "before": "1.0.0", "after": "1.1.0"}
+  ],
+  "processes": [
+    {
+      "type": "worker",
+      "status": "added",
+      "after": {"type": "worker", "shell": "bash", "command": "/work", "default": false, "args": null, "working-dir": ""}
+    }
+  ],
+  "bom": [],
+  "layers": []
+}`)
+		})
+
+		it("prints yaml", func() {
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			assert.Succeeds(writer.NewYAMLDiff().Print(logger, diff))
+
+			assert.ContainsYAML(outBuf.String(), `---
+image_a: some/app:v1
+image_b: some/app:v2
+buildpacks:
+- id: some/bp
+  status: changed
+  before: 1.0.0
+  after: 1.1.0
+`)
+		})
+
+		it("prints toml", func() {
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			assert.Succeeds(writer.NewTOMLDiff().Print(logger, diff))
+
+			assert.ContainsTOML(outBuf.String(), `image_a = "some/app:v1"
+image_b = "some/app:v2"
+
+[[buildpacks]]
+  id = "some/bp"
+  status = "changed"
+  before = "1.0.0"
+  after = "1.1.0"
+`)
+		})
+	})
+}
@@ -22,6 +22,10 @@ type InspectImageWriter interface {
 	) error
 }
 
+type DiffWriter interface {
+	Print(logger logging.Logger, diff *client.ImageDiff) error
+}
+
 func NewFactory() *Factory {
 	return &Factory{}
 }
@@ -49,3 +53,18 @@ func (f *Factory) Writer(kind string, bom bool) (InspectImageWriter, error) {
 
 	return nil, fmt.Errorf("output format %s is not supported", style.Symbol(kind))
 }
+
+func (f *Factory) DiffWriter(kind string) (DiffWriter, error) {
+	switch kind {
+	case "human-readable":
+		return NewHumanReadableDiff(), nil
+	case "json":
+		return NewJSONDiff(), nil
+	case "yaml":
+		return NewYAMLDiff(), nil
+	case "toml":
+		return NewTOMLDiff(), nil
+	}
+
+	return nil, fmt.Errorf("output format %s is not supported", style.Symbol(kind))
+}
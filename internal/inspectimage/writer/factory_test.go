@@ -145,4 +145,42 @@ func testFactory(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 	})
+
+	when("DiffWriter", func() {
+		when("output format is human-readable", func() {
+			it("returns a HumanReadableDiff writer", func() {
+				factory := writer.NewFactory()
+
+				returnedWriter, err := factory.DiffWriter("human-readable")
+				assert.Nil(err)
+
+				_, ok := returnedWriter.(*writer.HumanReadableDiff)
+				assert.TrueWithMessage(ok, fmt.Sprintf("expected %T to be assignable to type `*writer.HumanReadableDiff`", returnedWriter))
+			})
+		})
+
+		for _, format := range []string{"json", "yaml", "toml"} {
+			format := format
+			when(fmt.Sprintf("output format is %s", format), func() {
+				it("returns a StructuredDiffFormat writer", func() {
+					factory := writer.NewFactory()
+
+					returnedWriter, err := factory.DiffWriter(format)
+					assert.Nil(err)
+
+					_, ok := returnedWriter.(*writer.StructuredDiffFormat)
+					assert.TrueWithMessage(ok, fmt.Sprintf("expected %T to be assignable to type `*writer.StructuredDiffFormat`", returnedWriter))
+				})
+			})
+		}
+
+		when("output format is not supported", func() {
+			it("returns an error", func() {
+				factory := writer.NewFactory()
+
+				_, err := factory.DiffWriter("mind-diff")
+				assert.ErrorWithMessage(err, "output format 'mind-diff' is not supported")
+			})
+		})
+	})
 }
@@ -0,0 +1,74 @@
+// Package parallel runs independent units of work on a bounded pool of goroutines.
+package parallel
+
+import (
+	"errors"
+	"sync"
+)
+
+// DefaultLimit is the number of concurrent workers used when a non-positive limit is provided.
+const DefaultLimit = 4
+
+// Run invokes fn for each index in [0, n) using at most limit concurrent goroutines.
+// It always waits for every invocation to complete. Errors are returned joined in index order,
+// so that the result does not depend on scheduling; nil is returned if every invocation succeeded.
+func Run(limit, n int, fn func(i int) error) error {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	errs := make([]error, n)
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// Map invokes fn for each item using at most limit concurrent goroutines and returns the results in the order of items.
+// Errors are aggregated as described by Run.
+func Map[T, R any](limit int, items []T, fn func(item T) (R, error)) ([]R, error) {
+	results := make([]R, len(items))
+	err := Run(limit, len(items), func(i int) error {
+		var err error
+		results[i], err = fn(items[i])
+		return err
+	})
+	return results, err
+}
+
+// Limiter bounds the number of units of work running at once across every caller sharing it,
+// e.g. nested Run calls. Only leaf work should hold a slot: work waiting on other work that needs a slot
+// could otherwise deadlock. A nil Limiter doesn't limit.
+type Limiter struct {
+	sem chan struct{}
+}
+
+// NewLimiter returns a Limiter running at most limit units of work at once, or DefaultLimit if limit is not positive.
+func NewLimiter(limit int) *Limiter {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	return &Limiter{sem: make(chan struct{}, limit)}
+}
+
+// Do runs fn once a slot is free.
+func (l *Limiter) Do(fn func() error) error {
+	if l == nil {
+		return fn()
+	}
+	l.sem <- struct{}{}
+	defer func() { <-l.sem }()
+	return fn()
+}
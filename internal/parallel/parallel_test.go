@@ -0,0 +1,126 @@
+package parallel_test
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/parallel"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestParallel(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "parallel", testParallel, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testParallel(t *testing.T, when spec.G, it spec.S) {
+	when("#Run", func() {
+		it("never exceeds the limit of concurrent workers", func() {
+			var current, max int32
+			err := parallel.Run(3, 20, func(i int) error {
+				n := atomic.AddInt32(&current, 1)
+				for {
+					m := atomic.LoadInt32(&max)
+					if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&current, -1)
+				return nil
+			})
+
+			h.AssertNil(t, err)
+			h.AssertTrue(t, max <= 3)
+			h.AssertTrue(t, max > 1)
+		})
+
+		it("uses the default limit when the limit is not positive", func() {
+			var calls int32
+			h.AssertNil(t, parallel.Run(0, 10, func(i int) error {
+				atomic.AddInt32(&calls, 1)
+				return nil
+			}))
+			h.AssertEq(t, calls, int32(10))
+		})
+
+		it("runs every invocation and aggregates errors in index order", func() {
+			var calls int32
+			err := parallel.Run(4, 6, func(i int) error {
+				atomic.AddInt32(&calls, 1)
+				if i%2 == 1 {
+					// finish later invocations first to prove ordering is not by completion
+					time.Sleep(time.Duration(6-i) * time.Millisecond)
+					return fmt.Errorf("error-%d", i)
+				}
+				return nil
+			})
+
+			h.AssertEq(t, calls, int32(6))
+			h.AssertNotNil(t, err)
+			h.AssertEq(t, err.Error(), "error-1\nerror-3\nerror-5")
+		})
+
+		it("returns errors that can be unwrapped", func() {
+			sentinel := errors.New("sentinel")
+			err := parallel.Run(2, 2, func(i int) error {
+				if i == 1 {
+					return fmt.Errorf("wrapped: %w", sentinel)
+				}
+				return nil
+			})
+			h.AssertTrue(t, errors.Is(err, sentinel))
+		})
+	})
+
+	when("Limiter", func() {
+		it("caps the work of nested runs sharing it", func() {
+			var current, max int32
+			limiter := parallel.NewLimiter(2)
+			err := parallel.Run(4, 4, func(int) error {
+				return parallel.Run(4, 4, func(int) error {
+					return limiter.Do(func() error {
+						n := atomic.AddInt32(&current, 1)
+						for {
+							m := atomic.LoadInt32(&max)
+							if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+								break
+							}
+						}
+						time.Sleep(time.Millisecond)
+						atomic.AddInt32(&current, -1)
+						return nil
+					})
+				})
+			})
+
+			h.AssertNil(t, err)
+			h.AssertTrue(t, max <= 2)
+		})
+
+		it("doesn't limit when nil", func() {
+			var limiter *parallel.Limiter
+			h.AssertNil(t, limiter.Do(func() error { return nil }))
+		})
+	})
+
+	when("#Map", func() {
+		it("returns results in input order", func() {
+			results, err := parallel.Map(2, []int{5, 1, 3}, func(item int) (string, error) {
+				time.Sleep(time.Duration(item) * time.Millisecond)
+				return fmt.Sprintf("item-%d", item), nil
+			})
+
+			h.AssertNil(t, err)
+			h.AssertEq(t, results, []string{"item-5", "item-1", "item-3"})
+		})
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"sync"
 
 	"github.com/pkg/errors"
 
@@ -15,6 +16,27 @@ var (
 	validCharsRegexp  = regexp.MustCompile(fmt.Sprintf("^%s$", validCharsPattern))
 )
 
+// Index locates buildpacks in a buildpack registry
+type Index interface {
+	// LocateBuildpack returns the buildpack with the given registry ID, e.g. "<namespace>/<name>@<version>", where
+	// version may be a semver range such as "^1.2"
+	LocateBuildpack(bp string) (Buildpack, error)
+
+	// Buildpacks returns every version of every buildpack in the registry
+	Buildpacks() ([]Buildpack, error)
+}
+
+// rootLocks holds a mutex per cache root, so that indexes sharing a root, e.g. while buildpacks are
+// fetched concurrently, don't refresh it at the same time
+var rootLocks sync.Map
+
+// lockRoot locks the cache root and returns the function unlocking it
+func lockRoot(root string) (unlock func()) {
+	mu, _ := rootLocks.LoadOrStore(root, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
 // IndexPath resolves the path for a specific namespace and name of buildpack
 func IndexPath(rootDir, ns, name string) (string, error) {
 	if err := validateField("namespace", ns); err != nil {
@@ -6,11 +6,13 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/go-git/go-git/v5"
@@ -77,7 +79,9 @@ func NewRegistryCache(logger logging.Logger, home, registryURL string) (Cache, e
 
 // LocateBuildpack stored in registry
 func (r *Cache) LocateBuildpack(bp string) (Buildpack, error) {
-	err := r.Refresh()
+	defer lockRoot(r.Root)()
+
+	err := r.refresh()
 	if err != nil {
 		return Buildpack{}, errors.Wrap(err, "refreshing cache")
 	}
@@ -92,6 +96,12 @@ func (r *Cache) LocateBuildpack(bp string) (Buildpack, error) {
 		return Buildpack{}, errors.Wrap(err, "reading entry")
 	}
 
+	return locateVersion(entry, bp, version)
+}
+
+// locateVersion returns the given version of the buildpack in entry, or its highest version if version is empty.
+// If version is a semver range, the highest version in the range that has not been yanked is returned.
+func locateVersion(entry Entry, bp, version string) (Buildpack, error) {
 	if len(entry.Buildpacks) > 0 {
 		if version == "" {
 			highestVersion := entry.Buildpacks[0]
@@ -110,14 +120,65 @@ func (r *Cache) LocateBuildpack(bp string) (Buildpack, error) {
 				return bpIndex, Validate(bpIndex)
 			}
 		}
+
+		if versionRange, err := ParseVersionRange(version); err == nil {
+			if highest, ok := highestInRange(entry, versionRange); ok {
+				return highest, Validate(highest)
+			}
+		}
 		return Buildpack{}, fmt.Errorf("could not find version for buildpack: %s", bp)
 	}
 
 	return Buildpack{}, fmt.Errorf("no entries for buildpack: %s", bp)
 }
 
+// Buildpacks returns every version of every buildpack stored in registry
+func (r *Cache) Buildpacks() ([]Buildpack, error) {
+	defer lockRoot(r.Root)()
+
+	if err := r.refresh(); err != nil {
+		return nil, errors.Wrap(err, "refreshing cache")
+	}
+
+	var buildpacks []Buildpack
+	err := filepath.WalkDir(r.Root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Dir(path) == r.Root {
+			return nil
+		}
+
+		ns, name, ok := strings.Cut(d.Name(), "_")
+		if !ok {
+			return nil
+		}
+		entry, err := r.readEntry(ns, name)
+		if err != nil {
+			return err
+		}
+		buildpacks = append(buildpacks, entry.Buildpacks...)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading (%s)", r.Root)
+	}
+	return buildpacks, nil
+}
+
 // Refresh local Registry Cache
 func (r *Cache) Refresh() error {
+	defer lockRoot(r.Root)()
+	return r.refresh()
+}
+
+func (r *Cache) refresh() error {
 	r.logger.Debugf("Refreshing registry cache for %s/%s", r.url.Host, r.url.Path)
 
 	if err := r.Initialize(); err != nil {
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -129,6 +130,28 @@ func testRegistryCache(t *testing.T, when spec.G, it spec.S) {
 			h.AssertEq(t, bp.Version, "1.1.0")
 		})
 
+		it("locates buildpacks concurrently on a cold cache", func() {
+			var wg sync.WaitGroup
+			errs := make([]error, 8)
+			for i := range errs {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					registryCache, err := NewRegistryCache(logger, tmpDir, registryFixture)
+					if err != nil {
+						errs[i] = err
+						return
+					}
+					_, errs[i] = registryCache.LocateBuildpack("example/java")
+				}(i)
+			}
+			wg.Wait()
+
+			for _, err := range errs {
+				h.AssertNil(t, err)
+			}
+		})
+
 		it("returns error if can't parse buildpack id", func() {
 			_, err := registryCache.LocateBuildpack("quack")
 			h.AssertError(t, err, "parsing buildpacks registry id")
@@ -148,6 +171,40 @@ func testRegistryCache(t *testing.T, when spec.G, it spec.S) {
 			_, err := registryCache.LocateBuildpack("example/foo@3.5.6")
 			h.AssertError(t, err, "could not find version")
 		})
+
+		it("locates the highest version in a range", func() {
+			for versionRange, expected := range map[string]string{
+				"^1.0":        "1.2.0",
+				"~1.1":        "1.1.0",
+				">=1.0 <1.2":  "1.1.0",
+				">=1.0, <1.2": "1.1.0",
+			} {
+				bp, err := registryCache.LocateBuildpack("example/foo@" + versionRange)
+				h.AssertNil(t, err)
+				h.AssertEq(t, bp.Version, expected)
+			}
+		})
+
+		it("returns error if no version is in the range", func() {
+			_, err := registryCache.LocateBuildpack("example/foo@^2.0")
+			h.AssertError(t, err, "could not find version")
+		})
+	})
+
+	when("#Buildpacks", func() {
+		it("returns every version of every buildpack", func() {
+			registryCache, err := NewRegistryCache(logger, tmpDir, registryFixture)
+			h.AssertNil(t, err)
+
+			buildpacks, err := registryCache.Buildpacks()
+			h.AssertNil(t, err)
+
+			var ids []string
+			for _, bp := range buildpacks {
+				ids = append(ids, bp.Namespace+"/"+bp.Name+"@"+bp.Version)
+			}
+			h.AssertEq(t, ids, []string{"example/foo@1.0.0", "example/foo@1.1.0", "example/foo@1.2.0", "example/java@1.0.0"})
+		})
 	})
 
 	when("#Refresh", func() {
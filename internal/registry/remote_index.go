@@ -0,0 +1,424 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/registry"
+)
+
+const (
+	// IndexMediaType is the media type of the layers of an index stored as an OCI artifact. Each layer is an Entry.
+	IndexMediaType types.MediaType = "application/vnd.buildpacks.registry.index.v1+json"
+
+	// IndexConfigMediaType is the config media type of an index stored as an OCI artifact.
+	IndexConfigMediaType types.MediaType = "application/vnd.buildpacks.registry.index.config.v1+json"
+
+	remoteIndexStateFile = "state.json"
+	remoteIndexFile      = "index.json"
+	remoteIndexBlobsDir  = "blobs"
+
+	// remoteIndexTimeout bounds the download of HTTP indexes, so that an unresponsive server can't hang pack
+	remoteIndexTimeout = 5 * time.Minute
+)
+
+// RemoteIndex is a registry index served as a JSON Entry over HTTP, or stored as an OCI artifact whose layers are
+// Entries. Later entries for the same buildpack version replace earlier ones.
+//
+// The index is cached in the pack home. HTTP indexes are only downloaded again when their ETag or modification time
+// changes, and only the new layers of OCI indexes are downloaded.
+type RemoteIndex struct {
+	ctx        context.Context
+	logger     logging.Logger
+	keychain   authn.Keychain
+	httpClient *http.Client
+	kind       string
+	url        string
+	Root       string
+}
+
+// remoteIndexState records what was downloaded, so that unchanged indexes are not downloaded again.
+type remoteIndexState struct {
+	ETag         string   `json:"etag,omitempty"`
+	LastModified string   `json:"lastModified,omitempty"`
+	Manifest     string   `json:"manifest,omitempty"`
+	Layers       []string `json:"layers,omitempty"`
+}
+
+// NewRemoteIndex creates a remote index of the given registry type, http or oci, cached in home. registryURL is the URL
+// of the JSON index for http indexes, and an image reference for oci indexes. Requests to the index are canceled with
+// ctx.
+func NewRemoteIndex(ctx context.Context, logger logging.Logger, home, registryType, registryURL string, keychain authn.Keychain) (*RemoteIndex, error) {
+	if _, err := os.Stat(home); err != nil {
+		return nil, errors.Wrapf(err, "finding home %s", home)
+	}
+
+	switch registryType {
+	case registry.TypeHTTP:
+		if _, err := url.Parse(registryURL); err != nil {
+			return nil, errors.Wrapf(err, "parsing registry url %s", registryURL)
+		}
+	case registry.TypeOCI:
+		if _, err := name.ParseReference(registryURL, name.WeakValidation); err != nil {
+			return nil, errors.Wrapf(err, "parsing registry reference %s", registryURL)
+		}
+	default:
+		return nil, errors.Errorf("unsupported remote registry type %s", style.Symbol(registryType))
+	}
+
+	key := sha256.New()
+	key.Write([]byte(registryType + ":" + registryURL))
+	cacheDir := fmt.Sprintf("%s-%s", defaultRegistryDir, hex.EncodeToString(key.Sum(nil)))
+
+	return &RemoteIndex{
+		ctx:        ctx,
+		logger:     logger,
+		keychain:   keychain,
+		httpClient: &http.Client{Timeout: remoteIndexTimeout},
+		kind:       registryType,
+		url:        registryURL,
+		Root:       filepath.Join(home, cacheDir),
+	}, nil
+}
+
+// LocateBuildpack stored in the index
+func (r *RemoteIndex) LocateBuildpack(bp string) (Buildpack, error) {
+	ns, bpName, version, err := buildpack.ParseRegistryID(bp)
+	if err != nil {
+		return Buildpack{}, errors.Wrap(err, "parsing buildpacks registry id")
+	}
+
+	buildpacks, err := r.Buildpacks()
+	if err != nil {
+		return Buildpack{}, err
+	}
+
+	entry := Entry{}
+	for _, b := range buildpacks {
+		if b.Namespace == ns && b.Name == bpName {
+			entry.Buildpacks = append(entry.Buildpacks, b)
+		}
+	}
+	if len(entry.Buildpacks) == 0 {
+		return Buildpack{}, errors.Wrap(fmt.Errorf("no entries for buildpack: %s", bp), "reading entry")
+	}
+	return locateVersion(entry, bp, version)
+}
+
+// Buildpacks refreshes the cache and returns every buildpack version in the index
+func (r *RemoteIndex) Buildpacks() ([]Buildpack, error) {
+	defer lockRoot(r.Root)()
+
+	if err := r.refresh(); err != nil {
+		return nil, errors.Wrap(err, "refreshing cache")
+	}
+
+	state, err := r.readState()
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	if r.kind == registry.TypeHTTP {
+		files = []string{filepath.Join(r.Root, remoteIndexFile)}
+	} else {
+		for _, digest := range state.Layers {
+			files = append(files, r.blobPath(digest))
+		}
+	}
+
+	var buildpacks []Buildpack
+	positions := map[string]int{}
+	for _, file := range files {
+		entry, err := readEntryFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range entry.Buildpacks {
+			key := b.Namespace + "/" + b.Name + "@" + b.Version
+			if i, ok := positions[key]; ok {
+				buildpacks[i] = b
+				continue
+			}
+			positions[key] = len(buildpacks)
+			buildpacks = append(buildpacks, b)
+		}
+	}
+	return buildpacks, nil
+}
+
+// Refresh downloads the parts of the index that changed since the last refresh
+func (r *RemoteIndex) Refresh() error {
+	defer lockRoot(r.Root)()
+	return r.refresh()
+}
+
+func (r *RemoteIndex) refresh() error {
+	r.logger.Debugf("Refreshing registry cache for %s", r.url)
+
+	if err := os.MkdirAll(filepath.Join(r.Root, remoteIndexBlobsDir), 0750); err != nil {
+		return errors.Wrapf(err, "initializing (%s)", r.Root)
+	}
+
+	if r.kind == registry.TypeHTTP {
+		return r.refreshHTTP()
+	}
+	return r.refreshOCI()
+}
+
+func (r *RemoteIndex) refreshHTTP() error {
+	state, err := r.readState()
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(r.ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return errors.Wrapf(err, "creating request for %s", r.url)
+	}
+	if err := r.authorize(req); err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(r.Root, remoteIndexFile)); err == nil {
+		if state.ETag != "" {
+			req.Header.Set("If-None-Match", state.ETag)
+		}
+		if state.LastModified != "" {
+			req.Header.Set("If-Modified-Since", state.LastModified)
+		}
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "fetching index %s", r.url)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		r.logger.Debugf("Registry index %s is up to date", r.url)
+		return nil
+	case http.StatusOK:
+	default:
+		return errors.Errorf("fetching index %s: unexpected status %s", r.url, resp.Status)
+	}
+
+	if err := writeFileAtomic(filepath.Join(r.Root, remoteIndexFile), resp.Body); err != nil {
+		return errors.Wrapf(err, "writing index %s", r.url)
+	}
+	return r.writeState(remoteIndexState{ETag: resp.Header.Get("ETag"), LastModified: resp.Header.Get("Last-Modified")})
+}
+
+// authorize adds the credentials of the index host in the keychain, if any, to req
+func (r *RemoteIndex) authorize(req *http.Request) error {
+	if r.keychain == nil {
+		return nil
+	}
+
+	reg, err := name.NewRegistry(req.URL.Host, name.WeakValidation)
+	if err != nil {
+		return nil
+	}
+	authenticator, err := r.keychain.Resolve(reg)
+	if err != nil {
+		return errors.Wrapf(err, "resolving credentials for %s", req.URL.Host)
+	}
+	auth, err := authenticator.Authorization()
+	if err != nil {
+		return errors.Wrapf(err, "resolving credentials for %s", req.URL.Host)
+	}
+
+	switch {
+	case auth.RegistryToken != "":
+		req.Header.Set("Authorization", "Bearer "+auth.RegistryToken)
+	case auth.Username != "" || auth.Password != "":
+		req.SetBasicAuth(auth.Username, auth.Password)
+	case auth.Auth != "":
+		req.Header.Set("Authorization", "Basic "+auth.Auth)
+	}
+	return nil
+}
+
+func (r *RemoteIndex) refreshOCI() error {
+	ref, err := name.ParseReference(r.url, name.WeakValidation)
+	if err != nil {
+		return errors.Wrapf(err, "parsing registry reference %s", r.url)
+	}
+
+	state, err := r.readState()
+	if err != nil {
+		return err
+	}
+
+	desc, err := remote.Head(ref, r.remoteOptions()...)
+	if err != nil {
+		return errors.Wrapf(err, "fetching index %s", r.url)
+	}
+	if desc.Digest.String() == state.Manifest {
+		r.logger.Debugf("Registry index %s is up to date", r.url)
+		return nil
+	}
+
+	img, err := remote.Image(ref, r.remoteOptions()...)
+	if err != nil {
+		return errors.Wrapf(err, "fetching index %s", r.url)
+	}
+	manifest, err := img.Manifest()
+	if err != nil {
+		return errors.Wrapf(err, "reading index %s", r.url)
+	}
+
+	newState := remoteIndexState{Manifest: desc.Digest.String()}
+	for _, layerDesc := range manifest.Layers {
+		digest := layerDesc.Digest.String()
+		newState.Layers = append(newState.Layers, digest)
+		if _, err := os.Stat(r.blobPath(digest)); err == nil {
+			continue
+		}
+
+		r.logger.Debugf("Downloading registry index layer %s", digest)
+		if err := r.downloadLayer(img, layerDesc.Digest); err != nil {
+			return errors.Wrapf(err, "downloading index layer %s", digest)
+		}
+	}
+	return r.writeState(newState)
+}
+
+func (r *RemoteIndex) downloadLayer(img v1.Image, digest v1.Hash) error {
+	layer, err := img.LayerByDigest(digest)
+	if err != nil {
+		return err
+	}
+	rc, err := layer.Compressed()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return writeFileAtomic(r.blobPath(digest.String()), rc)
+}
+
+// Add appends an Entry containing b to the index. Only OCI indexes may be updated by pack; the previous layers are not
+// downloaded again by other users of the index.
+func (r *RemoteIndex) Add(b Buildpack) error {
+	if r.kind != registry.TypeOCI {
+		return errors.Errorf("registry type %s cannot be updated by pack, update the index at %s directly", style.Symbol(r.kind), r.url)
+	}
+
+	ref, err := name.ParseReference(r.url, name.WeakValidation)
+	if err != nil {
+		return errors.Wrapf(err, "parsing registry reference %s", r.url)
+	}
+
+	var base v1.Image
+	base, err = remote.Image(ref, r.remoteOptions()...)
+	if err != nil {
+		var terr *transport.Error
+		if !errors.As(err, &terr) || terr.StatusCode != http.StatusNotFound {
+			return errors.Wrapf(err, "fetching index %s", r.url)
+		}
+		base = mutate.ConfigMediaType(mutate.MediaType(empty.Image, types.OCIManifestSchema1), IndexConfigMediaType)
+	}
+
+	contents, err := json.Marshal(Entry{Buildpacks: []Buildpack{b}})
+	if err != nil {
+		return errors.Wrap(err, "converting buildpack to json")
+	}
+	img, err := mutate.AppendLayers(base, static.NewLayer(contents, IndexMediaType))
+	if err != nil {
+		return errors.Wrap(err, "adding entry to index")
+	}
+
+	if err := remote.Write(ref, img, r.remoteOptions()...); err != nil {
+		return errors.Wrapf(err, "writing index %s", r.url)
+	}
+	return nil
+}
+
+func (r *RemoteIndex) remoteOptions() []remote.Option {
+	opts := []remote.Option{remote.WithContext(r.ctx)}
+	if r.keychain != nil {
+		opts = append(opts, remote.WithAuthFromKeychain(r.keychain))
+	}
+	return opts
+}
+
+func (r *RemoteIndex) blobPath(digest string) string {
+	return filepath.Join(r.Root, remoteIndexBlobsDir, strings.ReplaceAll(digest, ":", "-"))
+}
+
+func (r *RemoteIndex) readState() (remoteIndexState, error) {
+	var state remoteIndexState
+	contents, err := os.ReadFile(filepath.Join(r.Root, remoteIndexStateFile))
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, errors.Wrap(err, "reading registry cache state")
+	}
+	if err := json.Unmarshal(contents, &state); err != nil {
+		return remoteIndexState{}, errors.Wrap(err, "parsing registry cache state")
+	}
+	return state, nil
+}
+
+func (r *RemoteIndex) writeState(state remoteIndexState) error {
+	contents, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(r.Root, remoteIndexStateFile), strings.NewReader(string(contents)))
+}
+
+func readEntryFile(path string) (Entry, error) {
+	contents, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return Entry{}, errors.Wrap(err, "reading index")
+	}
+	var entry Entry
+	if err := json.Unmarshal(contents, &entry); err != nil {
+		return Entry{}, errors.Wrap(err, "parsing index")
+	}
+	return entry, nil
+}
+
+// writeFileAtomic writes to a temporary file renamed to path, so that concurrent runs of pack never read partial files
+func writeFileAtomic(path string, contents io.Reader) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, contents); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
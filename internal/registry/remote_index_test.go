@@ -0,0 +1,203 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/registry"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRemoteIndex(t *testing.T) {
+	color.Disable(true)
+	spec.Run(t, "RemoteIndex", testRemoteIndex, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+type staticKeychain struct {
+	username string
+	password string
+}
+
+func (k staticKeychain) Resolve(authn.Resource) (authn.Authenticator, error) {
+	return authn.FromConfig(authn.AuthConfig{Username: k.username, Password: k.password}), nil
+}
+
+func testRemoteIndex(t *testing.T, when spec.G, it spec.S) {
+	var (
+		tmpDir   string
+		outBuf   bytes.Buffer
+		logger   logging.Logger
+		server   *h.RegistryIndexServer
+		keychain authn.Keychain
+	)
+
+	it.Before(func() {
+		var err error
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf, logging.WithVerbose())
+
+		tmpDir, err = os.MkdirTemp("", "remote-index")
+		h.AssertNil(t, err)
+
+		server = h.NewRegistryIndexServer("some-user", "some-password")
+		keychain = staticKeychain{username: "some-user", password: "some-password"}
+	})
+
+	it.After(func() {
+		server.Close()
+		_ = os.RemoveAll(tmpDir)
+	})
+
+	when("#NewRemoteIndex", func() {
+		it("fails for an unsupported type", func() {
+			_, err := NewRemoteIndex(context.TODO(), logger, tmpDir, "git", "https://example.com/index", keychain)
+			h.AssertError(t, err, "unsupported remote registry type 'git'")
+		})
+
+		it("fails for an invalid oci reference", func() {
+			_, err := NewRemoteIndex(context.TODO(), logger, tmpDir, registry.TypeOCI, "Not A Reference", keychain)
+			h.AssertError(t, err, "parsing registry reference")
+		})
+	})
+
+	when("http", func() {
+		it.Before(func() {
+			server.SetIndex(`{"buildpacks": [
+  {"ns": "example", "name": "java", "version": "1.0.0", "addr": "example.com/some/package@sha256:8c27fe111c11b722081701dfed3bd55e039b9ce92865473cf4cdfa918071c566"},
+  {"ns": "example", "name": "java", "version": "1.2.0", "addr": "example.com/some/package@sha256:2560f05307e8de9d830f144d09556e19dd1eb7d928aee900ed02208ae9727e7a"}
+]}`)
+		})
+
+		it("locates buildpacks using the credentials in the keychain", func() {
+			index, err := NewRemoteIndex(context.TODO(), logger, tmpDir, registry.TypeHTTP, server.IndexURL(), keychain)
+			h.AssertNil(t, err)
+
+			bp, err := index.LocateBuildpack("example/java")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp.Version, "1.2.0")
+
+			bp, err = index.LocateBuildpack("example/java@1.0.0")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp.Address, "example.com/some/package@sha256:8c27fe111c11b722081701dfed3bd55e039b9ce92865473cf4cdfa918071c566")
+		})
+
+		it("only downloads the index again when it changes", func() {
+			index, err := NewRemoteIndex(context.TODO(), logger, tmpDir, registry.TypeHTTP, server.IndexURL(), keychain)
+			h.AssertNil(t, err)
+
+			_, err = index.LocateBuildpack("example/java")
+			h.AssertNil(t, err)
+			_, err = index.LocateBuildpack("example/java")
+			h.AssertNil(t, err)
+			h.AssertEq(t, server.Downloads(), 1)
+
+			server.SetIndex(`{"buildpacks": [
+  {"ns": "example", "name": "java", "version": "2.0.0", "addr": "example.com/some/package@sha256:2560f05307e8de9d830f144d09556e19dd1eb7d928aee900ed02208ae9727e7a"}
+]}`)
+			bp, err := index.LocateBuildpack("example/java")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp.Version, "2.0.0")
+			h.AssertEq(t, server.Downloads(), 2)
+		})
+
+		it("fails without valid credentials", func() {
+			index, err := NewRemoteIndex(context.TODO(), logger, tmpDir, registry.TypeHTTP, server.IndexURL(), staticKeychain{username: "some-user", password: "wrong"})
+			h.AssertNil(t, err)
+
+			_, err = index.LocateBuildpack("example/java")
+			h.AssertError(t, err, "unexpected status 401 Unauthorized")
+		})
+
+		it("stops fetching the index when the context is canceled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			index, err := NewRemoteIndex(ctx, logger, tmpDir, registry.TypeHTTP, server.IndexURL(), keychain)
+			h.AssertNil(t, err)
+
+			_, err = index.LocateBuildpack("example/java")
+			h.AssertError(t, err, "context canceled")
+			h.AssertEq(t, server.Downloads(), 0)
+		})
+
+		it("fails for unknown buildpacks", func() {
+			index, err := NewRemoteIndex(context.TODO(), logger, tmpDir, registry.TypeHTTP, server.IndexURL(), keychain)
+			h.AssertNil(t, err)
+
+			_, err = index.LocateBuildpack("example/ruby")
+			h.AssertError(t, err, "no entries for buildpack: example/ruby")
+		})
+
+		it("cannot be updated", func() {
+			index, err := NewRemoteIndex(context.TODO(), logger, tmpDir, registry.TypeHTTP, server.IndexURL(), keychain)
+			h.AssertNil(t, err)
+
+			err = index.Add(Buildpack{Namespace: "example", Name: "java", Version: "3.0.0"})
+			h.AssertError(t, err, "registry type 'http' cannot be updated by pack")
+		})
+	})
+
+	when("oci", func() {
+		var indexRef string
+
+		it.Before(func() {
+			indexRef = server.Host() + "/buildpacks/index:latest"
+		})
+
+		it("adds entries and locates buildpacks", func() {
+			index, err := NewRemoteIndex(context.TODO(), logger, tmpDir, registry.TypeOCI, indexRef, keychain)
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, index.Add(Buildpack{Namespace: "example", Name: "java", Version: "1.0.0", Address: "example.com/some/package@sha256:8c27fe111c11b722081701dfed3bd55e039b9ce92865473cf4cdfa918071c566"}))
+			h.AssertNil(t, index.Add(Buildpack{Namespace: "example", Name: "java", Version: "1.2.0", Address: "example.com/some/package@sha256:2560f05307e8de9d830f144d09556e19dd1eb7d928aee900ed02208ae9727e7a"}))
+
+			bp, err := index.LocateBuildpack("example/java")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp.Version, "1.2.0")
+		})
+
+		it("replaces earlier entries for the same version", func() {
+			index, err := NewRemoteIndex(context.TODO(), logger, tmpDir, registry.TypeOCI, indexRef, keychain)
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, index.Add(Buildpack{Namespace: "example", Name: "java", Version: "1.0.0", Address: "example.com/some/package@sha256:8c27fe111c11b722081701dfed3bd55e039b9ce92865473cf4cdfa918071c566"}))
+			h.AssertNil(t, index.Add(Buildpack{Namespace: "example", Name: "java", Version: "1.0.0", Yanked: true}))
+
+			buildpacks, err := index.Buildpacks()
+			h.AssertNil(t, err)
+			h.AssertEq(t, buildpacks, []Buildpack{{Namespace: "example", Name: "java", Version: "1.0.0", Yanked: true}})
+		})
+
+		it("only downloads new layers", func() {
+			index, err := NewRemoteIndex(context.TODO(), logger, tmpDir, registry.TypeOCI, indexRef, keychain)
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, index.Add(Buildpack{Namespace: "example", Name: "java", Version: "1.0.0"}))
+			h.AssertNil(t, index.Refresh())
+			h.AssertContains(t, outBuf.String(), "Downloading registry index layer")
+
+			outBuf.Reset()
+			h.AssertNil(t, index.Refresh())
+			h.AssertContains(t, outBuf.String(), "is up to date")
+
+			outBuf.Reset()
+			h.AssertNil(t, index.Add(Buildpack{Namespace: "example", Name: "java", Version: "2.0.0"}))
+			h.AssertNil(t, index.Refresh())
+			h.AssertEq(t, bytes.Count(outBuf.Bytes(), []byte("Downloading registry index layer")), 1)
+		})
+
+		it("fails without valid credentials", func() {
+			index, err := NewRemoteIndex(context.TODO(), logger, tmpDir, registry.TypeOCI, indexRef, staticKeychain{username: "some-user", password: "wrong"})
+			h.AssertNil(t, err)
+
+			_, err = index.LocateBuildpack("example/java")
+			h.AssertError(t, err, "fetching index")
+		})
+	})
+}
@@ -0,0 +1,40 @@
+package registry
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver"
+)
+
+// comparatorSeparator matches whitespace between two comparators of a range, e.g. ">=1.0 <2.0"
+var comparatorSeparator = regexp.MustCompile(`([^\s,|])\s+([<>=!~^])`)
+
+// ParseVersionRange parses a semver range such as "^1.2", "~1.2" or ">=1.0 <2.0". Comparators may be separated by
+// whitespace or commas.
+func ParseVersionRange(versionRange string) (*semver.Constraints, error) {
+	return semver.NewConstraint(comparatorSeparator.ReplaceAllString(strings.TrimSpace(versionRange), "$1, $2"))
+}
+
+// highestInRange returns the highest version in entry that satisfies the given range. Yanked versions are ignored.
+func highestInRange(entry Entry, constraints *semver.Constraints) (Buildpack, bool) {
+	var (
+		highest        Buildpack
+		highestVersion *semver.Version
+	)
+	for _, bp := range entry.Buildpacks {
+		if bp.Yanked {
+			continue
+		}
+
+		version, err := semver.NewVersion(bp.Version)
+		if err != nil || !constraints.Check(version) {
+			continue
+		}
+
+		if highestVersion == nil || version.GreaterThan(highestVersion) {
+			highest, highestVersion = bp, version
+		}
+	}
+	return highest, highestVersion != nil
+}
@@ -0,0 +1,51 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestVersion(t *testing.T) {
+	color.Disable(true)
+	spec.Run(t, "Version", testVersion, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testVersion(t *testing.T, when spec.G, it spec.S) {
+	when("#ParseVersionRange", func() {
+		it("accepts comparators separated by whitespace", func() {
+			versionRange, err := ParseVersionRange(">=1.0 <2.0 || ^3.1")
+			h.AssertNil(t, err)
+
+			h.AssertTrue(t, versionRange.Check(semver.MustParse("1.5.0")))
+			h.AssertTrue(t, versionRange.Check(semver.MustParse("3.2.0")))
+			h.AssertFalse(t, versionRange.Check(semver.MustParse("2.0.0")))
+		})
+
+		it("fails for invalid ranges", func() {
+			_, err := ParseVersionRange("latest")
+			h.AssertNotNil(t, err)
+		})
+	})
+
+	when("#highestInRange", func() {
+		it("ignores yanked versions", func() {
+			versionRange, err := ParseVersionRange("^1.0")
+			h.AssertNil(t, err)
+
+			bp, ok := highestInRange(Entry{Buildpacks: []Buildpack{
+				{Version: "1.0.0"},
+				{Version: "1.2.0", Yanked: true},
+				{Version: "1.1.0"},
+				{Version: "2.0.0"},
+			}}, versionRange)
+			h.AssertTrue(t, ok)
+			h.AssertEq(t, bp.Version, "1.1.0")
+		})
+	})
+}
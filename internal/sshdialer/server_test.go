@@ -499,6 +499,11 @@ func (s *SSHServer) handleTunnel(newChannel ssh.NewChannel) {
 		}
 
 		hostPort := fmt.Sprintf("%s:%d", tcpExtraData.HostLocal, tcpExtraData.PortLocal)
+		if hostPort == fmt.Sprintf("%s:%d", s.hostIPv4, s.portIPv4) {
+			// the server acts as its own jump host
+			s.handleJump(newChannel, hostPort)
+			return
+		}
 		if hostPort != dockerTCPSocket {
 			err = newChannel.Reject(ssh.ConnectionFailed, fmt.Sprintf("bad socket: '%s:%d'", tcpExtraData.HostLocal, tcpExtraData.PortLocal))
 			if err != nil {
@@ -526,6 +531,38 @@ func (s *SSHServer) handleTunnel(newChannel ssh.NewChannel) {
 	<-conn.closed
 }
 
+// handleJump forwards the channel to the ssh server at hostPort, like a ProxyJump host does
+func (s *SSHServer) handleJump(newChannel ssh.NewChannel, hostPort string) {
+	conn, err := net.Dial("tcp", hostPort)
+	if err != nil {
+		err = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "err: %v\n", err)
+		}
+		return
+	}
+	defer conn.Close()
+
+	ch, reqs, err := newChannel.Accept()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "err: %v\n", err)
+		return
+	}
+	defer ch.Close()
+	go ssh.DiscardRequests(reqs)
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(conn, ch)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(ch, conn)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
 type listener struct {
 	conns  chan net.Conn
 	closed chan struct{}
@@ -0,0 +1,177 @@
+package sshdialer
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	urlPkg "net/url"
+	"os"
+	osUser "os/user"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/docker/docker/pkg/homedir"
+	"github.com/kevinburke/ssh_config"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// hostConfig is a host to connect to, resolved against the OpenSSH client config
+type hostConfig struct {
+	hostname       string
+	port           string
+	user           string
+	identityFiles  []string
+	proxyJump      string
+	hostKeyAlias   string
+	knownHostsFile string
+	hashKnownHosts bool
+	acceptNew      bool
+}
+
+// addr returns the address to dial for the host
+func (h hostConfig) addr() string {
+	return net.JoinHostPort(h.hostname, h.port)
+}
+
+var systemSSHConfigPath = filepath.Join("/", "etc", "ssh", "ssh_config")
+
+// resolveHost resolves alias against ~/.ssh/config and /etc/ssh/ssh_config,
+// the user and port given explicitly (e.g. in the docker host URL) take precedence over the config,
+// like the options given on the ssh command line do
+func resolveHost(alias, user, port string, logger logging.Logger) (hostConfig, error) {
+	configs, err := readSSHConfigs(logger)
+	if err != nil {
+		return hostConfig{}, err
+	}
+
+	get := func(key string) string {
+		for _, cfg := range configs {
+			if values, err := cfg.GetAll(alias, key); err == nil && len(values) > 0 {
+				return values[0]
+			}
+		}
+		return ""
+	}
+
+	if user == "" {
+		user = get("User")
+	}
+	if port == "" {
+		port = get("Port")
+	}
+	host := defaultHostConfig(alias, user, port)
+
+	expand := func(s string) string {
+		return expandTokens(s, alias, host.user)
+	}
+
+	if hostname := get("HostName"); hostname != "" {
+		host.hostname = expand(hostname)
+	}
+	for _, cfg := range configs {
+		identityFiles, err := cfg.GetAll(alias, "IdentityFile")
+		if err != nil {
+			continue
+		}
+		for _, identityFile := range identityFiles {
+			host.identityFiles = append(host.identityFiles, expand(identityFile))
+		}
+	}
+	if proxyJump := get("ProxyJump"); !strings.EqualFold(proxyJump, "none") {
+		host.proxyJump = proxyJump
+	}
+	host.hostKeyAlias = get("HostKeyAlias")
+	if knownHostsFiles := strings.Fields(get("UserKnownHostsFile")); len(knownHostsFiles) > 0 {
+		host.knownHostsFile = expand(knownHostsFiles[0])
+	}
+	host.hashKnownHosts = strings.EqualFold(get("HashKnownHosts"), "yes")
+	host.acceptNew = strings.EqualFold(get("StrictHostKeyChecking"), "accept-new")
+
+	return host, nil
+}
+
+func defaultHostConfig(alias, user, port string) hostConfig {
+	if user == "" {
+		if u, err := osUser.Current(); err == nil {
+			user = u.Username
+		}
+	}
+	if port == "" {
+		port = defaultSSHPort
+	}
+	return hostConfig{
+		hostname:       alias,
+		port:           port,
+		user:           user,
+		knownHostsFile: filepath.Join(homedir.Get(), ".ssh", "known_hosts"),
+	}
+}
+
+// readSSHConfigs reads the user's and the system wide ssh config, in the order they take precedence,
+// files that don't exist are skipped, and so are files that can't be parsed, e.g. because they
+// contain Match directives which ssh_config doesn't support, so hosts are connected to as given
+func readSSHConfigs(logger logging.Logger) ([]*ssh_config.Config, error) {
+	paths := []string{filepath.Join(homedir.Get(), ".ssh", "config")}
+	if runtime.GOOS != "windows" {
+		paths = append(paths, systemSSHConfigPath)
+	}
+
+	var configs []*ssh_config.Config
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read ssh config: %w", err)
+		}
+		cfg, err := ssh_config.Decode(bytes.NewReader(b))
+		if err != nil {
+			if logger != nil {
+				logger.Debugf("ignoring ssh config %s: %s", path, err)
+			}
+			continue
+		}
+		configs = append(configs, cfg)
+	}
+	return configs, nil
+}
+
+// expandTokens expands the leading ~ and the %d, %h, %r and %% tokens ssh_config supports in paths and host names
+func expandTokens(s, alias, user string) string {
+	home := homedir.Get()
+	if s == "~" || strings.HasPrefix(s, "~/") {
+		s = home + s[1:]
+	}
+	return strings.NewReplacer("%%", "%", "%d", home, "%h", alias, "%r", user).Replace(s)
+}
+
+// jumpHosts resolves the comma separated [user@]host[:port] hosts of a ProxyJump setting,
+// the ProxyJump settings of the jump hosts themselves are not followed
+func jumpHosts(proxyJump string, logger logging.Logger) ([]hostConfig, error) {
+	if proxyJump == "" {
+		return nil, nil
+	}
+
+	var hosts []hostConfig
+	for _, jump := range strings.Split(proxyJump, ",") {
+		jump = strings.TrimSpace(jump)
+		if !strings.HasPrefix(jump, "ssh://") {
+			jump = "ssh://" + jump
+		}
+		jumpURL, err := urlPkg.Parse(jump)
+		if err != nil || jumpURL.Hostname() == "" {
+			return nil, fmt.Errorf("invalid ProxyJump host %q", strings.TrimPrefix(jump, "ssh://"))
+		}
+
+		host, err := resolveHost(jumpURL.Hostname(), jumpURL.User.Username(), jumpURL.Port(), logger)
+		if err != nil {
+			return nil, err
+		}
+		host.proxyJump = ""
+		hosts = append(hosts, host)
+	}
+	return hosts, nil
+}
@@ -17,10 +17,11 @@ import (
 	"time"
 
 	"github.com/docker/cli/cli/connhelper"
-	"github.com/docker/docker/pkg/homedir"
 	"golang.org/x/crypto/ssh"
 	"golang.org/x/crypto/ssh/agent"
 	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/buildpacks/pack/pkg/logging"
 )
 
 type SecretCallback func() (string, error)
@@ -32,25 +33,24 @@ type Config struct {
 	PasswordCallback   SecretCallback
 	PassPhraseCallback SecretCallback
 	HostKeyCallback    HostKeyCallback
+	// AcceptNewHostKeys adds the keys of hosts that aren't in known_hosts yet to it instead of
+	// asking HostKeyCallback, like StrictHostKeyChecking=accept-new does; changed keys are still rejected
+	AcceptNewHostKeys bool
+	// Logger receives debug output, e.g. about ssh config files that are ignored, it may be nil
+	Logger logging.Logger
 }
 
 const defaultSSHPort = "22"
 
 func NewDialContext(url *urlPkg.URL, config Config) (func(ctx context.Context, network, addr string) (net.Conn, error), error) {
-	sshConfig, err := NewSSHClientConfig(url, config)
+	host, err := resolveHost(url.Hostname(), url.User.Username(), url.Port(), config.Logger)
 	if err != nil {
 		return nil, err
 	}
 
-	port := url.Port()
-	if port == "" {
-		port = defaultSSHPort
-	}
-	host := url.Hostname()
-
-	sshClient, err := ssh.Dial("tcp", net.JoinHostPort(host, port), sshConfig)
+	sshClient, err := dialHost(host, url.User, config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to dial ssh: %w", err)
+		return nil, err
 	}
 	defer func() {
 		if sshClient != nil {
@@ -214,19 +214,102 @@ func tryGetStdioDialContext(url *urlPkg.URL, sshClient *ssh.Client, identity str
 	return nil, nil
 }
 
+// dialHost connects to host, through the hosts of its ProxyJump setting if it has one
+func dialHost(host hostConfig, userInfo *urlPkg.Userinfo, config Config) (*ssh.Client, error) {
+	jumps, err := jumpHosts(host.proxyJump, config.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	var sshClient *ssh.Client
+	for _, jump := range jumps {
+		jumpConfig, err := newSSHClientConfig(jump, nil, config)
+		if err != nil {
+			if sshClient != nil {
+				sshClient.Close()
+			}
+			return nil, err
+		}
+		sshClient, err = dialVia(sshClient, jump.addr(), jumpConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial ssh jump host %s: %w", jump.addr(), err)
+		}
+	}
+
+	sshConfig, err := newSSHClientConfig(host, userInfo, config)
+	if err != nil {
+		if sshClient != nil {
+			sshClient.Close()
+		}
+		return nil, err
+	}
+	sshClient, err = dialVia(sshClient, host.addr(), sshConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial ssh: %w", err)
+	}
+	return sshClient, nil
+}
+
+// dialVia connects to addr directly, or through the jump host client when it isn't nil,
+// the jump host client is closed once the returned client is
+func dialVia(jumpClient *ssh.Client, addr string, sshConfig *ssh.ClientConfig) (*ssh.Client, error) {
+	if jumpClient == nil {
+		return ssh.Dial("tcp", addr, sshConfig)
+	}
+
+	conn, err := jumpClient.Dial("tcp", addr)
+	if err != nil {
+		jumpClient.Close()
+		return nil, err
+	}
+	clientConn, chans, reqs, err := ssh.NewClientConn(conn, addr, sshConfig)
+	if err != nil {
+		conn.Close()
+		jumpClient.Close()
+		return nil, err
+	}
+
+	sshClient := ssh.NewClient(clientConn, chans, reqs)
+	go func() {
+		_ = sshClient.Wait()
+		jumpClient.Close()
+	}()
+	return sshClient, nil
+}
+
 func NewSSHClientConfig(url *urlPkg.URL, config Config) (*ssh.ClientConfig, error) {
+	host, err := resolveHost(url.Hostname(), url.User.Username(), url.Port(), config.Logger)
+	if err != nil {
+		return nil, err
+	}
+	return newSSHClientConfig(host, url.User, config)
+}
+
+func newSSHClientConfig(host hostConfig, userInfo *urlPkg.Userinfo, config Config) (*ssh.ClientConfig, error) {
 	var (
 		authMethods []ssh.AuthMethod
 		signers     []ssh.Signer
 	)
 
-	if pw, found := url.User.Password(); found {
+	if pw, found := userInfo.Password(); found {
 		authMethods = append(authMethods, ssh.Password(pw))
 	}
 
 	// add signer from explicit identity parameter
 	if config.Identity != "" {
-		signer, err := loadSignerFromFile(config.Identity, []byte(config.Identity), config.PassPhraseCallback)
+		signer, err := loadSignerFromFile(config.Identity, []byte(config.PassPhrase), config.PassPhraseCallback)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse identity file: %w", err)
+		}
+		signers = append(signers, signer)
+	}
+
+	// add signers from the IdentityFile settings of the ssh config, skipping the ones that don't exist like ssh does
+	for _, identityFile := range host.identityFiles {
+		if _, err := os.Stat(identityFile); err != nil {
+			continue
+		}
+		signer, err := loadSignerFromFile(identityFile, []byte(config.PassPhrase), config.PassPhraseCallback)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse identity file: %w", err)
 		}
@@ -261,9 +344,9 @@ func NewSSHClientConfig(url *urlPkg.URL, config Config) (*ssh.ClientConfig, erro
 
 	const sshTimeout = 5
 	clientConfig := &ssh.ClientConfig{
-		User:            url.User.Username(),
+		User:            host.user,
 		Auth:            authMethods,
-		HostKeyCallback: createHostKeyCallback(config.HostKeyCallback),
+		HostKeyCallback: createHostKeyCallback(host, config),
 		HostKeyAlgorithms: []string{
 			ssh.KeyAlgoECDSA256,
 			ssh.KeyAlgoECDSA384,
@@ -375,25 +458,38 @@ func loadSignerFromFile(path string, passphrase []byte, passPhraseCallback Secre
 	return signer, nil
 }
 
-func createHostKeyCallback(userCallback HostKeyCallback) ssh.HostKeyCallback {
+func createHostKeyCallback(host hostConfig, config Config) ssh.HostKeyCallback {
 	return func(hostPort string, remote net.Addr, pubKey ssh.PublicKey) error {
-		knownHosts := filepath.Join(homedir.Get(), ".ssh", "known_hosts")
+		if host.hostKeyAlias != "" {
+			hostPort = net.JoinHostPort(host.hostKeyAlias, defaultSSHPort)
+		}
 
-		fileCallback, err := knownhosts.New(knownHosts)
+		var unknown bool
+		fileCallback, err := knownhosts.New(host.knownHostsFile)
 		if err != nil {
 			if os.IsNotExist(err) {
 				err = errKeyUnknown
+				unknown = true
 			}
 		} else {
 			err = fileCallback(hostPort, remote, pubKey)
 			if err == nil {
 				return nil
 			}
+			var keyErr *knownhosts.KeyError
+			unknown = errors.As(err, &keyErr) && len(keyErr.Want) == 0
 		}
 
-		if userCallback != nil {
-			err = userCallback(hostPort, pubKey)
+		if unknown && (config.AcceptNewHostKeys || host.acceptNew) {
+			return addKnownHost(host, hostPort, pubKey)
+		}
+
+		if config.HostKeyCallback != nil {
+			err = config.HostKeyCallback(hostPort, pubKey)
 			if err == nil {
+				if unknown {
+					return addKnownHost(host, hostPort, pubKey)
+				}
 				return nil
 			}
 		}
@@ -402,6 +498,38 @@ func createHostKeyCallback(userCallback HostKeyCallback) ssh.HostKeyCallback {
 	}
 }
 
+// addKnownHost appends the key of hostPort to the known_hosts file of host
+func addKnownHost(host hostConfig, hostPort string, pubKey ssh.PublicKey) error {
+	if err := os.MkdirAll(filepath.Dir(host.knownHostsFile), 0700); err != nil {
+		return fmt.Errorf("failed to add host key to known_hosts: %w", err)
+	}
+
+	f, err := os.OpenFile(host.knownHostsFile, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to add host key to known_hosts: %w", err)
+	}
+	defer f.Close()
+
+	address := knownhosts.Normalize(hostPort)
+	if host.hashKnownHosts {
+		address = knownhosts.HashHostname(address)
+	}
+	line := knownhosts.Line([]string{address}, pubKey) + "\n"
+
+	// don't append to the last entry when the file doesn't end with a newline
+	if fi, err := f.Stat(); err == nil && fi.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := f.ReadAt(last, fi.Size()-1); err == nil && last[0] != '\n' {
+			line = "\n" + line
+		}
+	}
+
+	if _, err := f.WriteString(line); err != nil {
+		return fmt.Errorf("failed to add host key to known_hosts: %w", err)
+	}
+	return nil
+}
+
 var ErrKeyMismatchMsg = "key mismatch"
 var ErrKeyUnknownMsg = "key is unknown"
 
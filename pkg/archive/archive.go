@@ -4,10 +4,14 @@ package archive // import "github.com/buildpacks/pack/pkg/archive"
 import (
 	"archive/tar"
 	"archive/zip"
+	"bufio"
+	"compress/gzip"
 	"io"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/docker/docker/pkg/ioutils"
@@ -55,6 +59,13 @@ func ReadZipAsTar(srcPath, basePath string, uid, gid int, mode int64, normalizeM
 	})
 }
 
+// ReadTarAsTar returns a reader to a tar of the entries of the tar, or gzipped tar, at srcPath, placed under basePath
+func ReadTarAsTar(srcPath, basePath string, uid, gid int, mode int64, normalizeModTime bool, fileFilter func(string) bool) io.ReadCloser {
+	return GenerateTar(func(tw TarWriter) error {
+		return WriteTarToTar(tw, srcPath, basePath, uid, gid, mode, normalizeModTime, fileFilter)
+	})
+}
+
 func GenerateTar(genFn func(TarWriter) error) io.ReadCloser {
 	return GenerateTarWithWriter(genFn, DefaultTarWriterFactory())
 }
@@ -347,6 +358,75 @@ func WriteZipToTar(tw TarWriter, srcZip, basePath string, uid, gid int, mode int
 	return nil
 }
 
+// WriteTarToTar writes the entries of the tar, or gzipped tar, at srcTar to tw, placed under basePath. Entries are
+// filtered by their path relative to the root of the archive.
+func WriteTarToTar(tw TarWriter, srcTar, basePath string, uid, gid int, mode int64, normalizeModTime bool, fileFilter func(string) bool) error {
+	f, err := os.Open(filepath.Clean(srcTar))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r, err := uncompressedReader(f)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "reading tar %s", srcTar)
+		}
+
+		name := entryPath(header.Name)
+		if name == "" || (fileFilter != nil && !fileFilter(name)) {
+			continue
+		}
+
+		header.Name = filepath.ToSlash(filepath.Join(basePath, name))
+		if header.Typeflag == tar.TypeLink {
+			header.Linkname = filepath.ToSlash(filepath.Join(basePath, entryPath(header.Linkname)))
+		}
+		// the records may override the name of the entry
+		header.PAXRecords = nil
+		finalizeHeader(header, uid, gid, mode, normalizeModTime)
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if header.Typeflag == tar.TypeReg {
+			if _, err := io.Copy(tw, tr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// entryPath returns the path of a tar entry relative to the root of the archive, or an empty string for the root
+// itself and entries outside of it
+func entryPath(name string) string {
+	name = path.Clean("/" + filepath.ToSlash(name))
+	if name == "/" {
+		return ""
+	}
+	return strings.TrimPrefix(name, "/")
+}
+
+// uncompressedReader returns a reader of the contents of r, which are gunzipped if they are gzip compressed
+func uncompressedReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		return gzip.NewReader(br)
+	}
+	return io.NopCloser(br), nil
+}
+
 // NormalizeHeader normalizes a tar.Header
 //
 // Normalizes the following:
@@ -380,6 +460,24 @@ func IsZip(path string) (bool, error) {
 	}
 }
 
+// IsTar detects whether or not a File is a tar or gzipped tar archive
+func IsTar(path string) (bool, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	r, err := uncompressedReader(f)
+	if err != nil {
+		return false, nil
+	}
+	defer r.Close()
+
+	_, err = tar.NewReader(r).Next()
+	return err == nil, nil
+}
+
 func isFatFile(header zip.FileHeader) bool {
 	var (
 		creatorFAT  uint16 = 0 // nolint:revive
@@ -2,6 +2,7 @@ package archive_test
 
 import (
 	"archive/tar"
+	"compress/gzip"
 	"net"
 	"os"
 	"path/filepath"
@@ -96,6 +97,39 @@ func testArchive(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("#ReadTarAsTar", func() {
+		var src string
+		it.Before(func() {
+			tarBuilder := archive.TarBuilder{}
+			tarBuilder.AddDir("./", 0755, archive.NormalizedDateTime)
+			tarBuilder.AddFile("./some-file.txt", 0644, archive.NormalizedDateTime, []byte("some-content"))
+			tarBuilder.AddDir("./sub-dir", 0755, archive.NormalizedDateTime)
+			tarBuilder.AddFile("./sub-dir/excluded.txt", 0644, archive.NormalizedDateTime, []byte("excluded"))
+
+			src = filepath.Join(tmpDir, "app.tgz")
+			file, err := os.Create(src)
+			h.AssertNil(t, err)
+			defer file.Close()
+			gzw := gzip.NewWriter(file)
+			_, err = tarBuilder.WriteTo(gzw, archive.DefaultTarWriterFactory())
+			h.AssertNil(t, err)
+			h.AssertNil(t, gzw.Close())
+		})
+
+		it("returns a TarReader of the gzipped tar", func() {
+			rc := archive.ReadTarAsTar(src, "/nested/dir/dir-in-archive", 1234, 2345, 0777, true, func(path string) bool {
+				return path != "sub-dir/excluded.txt"
+			})
+
+			tr := tar.NewReader(rc)
+			verify := h.NewTarVerifier(t, tr, 1234, 2345)
+			verify.NextFile("/nested/dir/dir-in-archive/some-file.txt", "some-content", int64(os.ModePerm))
+			verify.NextDirectory("/nested/dir/dir-in-archive/sub-dir", int64(os.ModePerm))
+			verify.NoMoreFilesExist()
+			h.AssertNil(t, rc.Close())
+		})
+	})
+
 	when("#ReadTarEntry", func() {
 		var (
 			err     error
@@ -666,6 +700,30 @@ func testArchive(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 	})
+
+	when("#IsTar", func() {
+		it("returns true for a tar file", func() {
+			path := filepath.Join(tmpDir, "app.tar")
+			h.AssertNil(t, archive.CreateSingleFileTar(path, "some-file.txt", "some-content"))
+			isTar, err := archive.IsTar(path)
+			h.AssertNil(t, err)
+			h.AssertTrue(t, isTar)
+		})
+
+		it("returns false for a zip file", func() {
+			isTar, err := archive.IsTar(filepath.Join("testdata", "zip-to-tar.zip"))
+			h.AssertNil(t, err)
+			h.AssertFalse(t, isTar)
+		})
+
+		it("returns false for a file with other content", func() {
+			path := filepath.Join(tmpDir, "file.txt")
+			h.AssertNil(t, os.WriteFile(path, []byte("content"), 0600))
+			isTar, err := archive.IsTar(path)
+			h.AssertNil(t, err)
+			h.AssertFalse(t, isTar)
+		})
+	})
 }
 
 func fileMode(t *testing.T, path string) int64 {
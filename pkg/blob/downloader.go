@@ -117,10 +117,12 @@ func (d *downloader) handleHTTP(ctx context.Context, uri string) (string, error)
 	}
 	defer reader.Close()
 
-	fh, err := os.Create(cachePath)
+	// write to a temporary file first so that concurrent downloads of the same URI never observe a partial blob
+	fh, err := os.CreateTemp(cacheDir, filepath.Base(cachePath)+".*.tmp")
 	if err != nil {
 		return "", errors.Wrapf(err, "create cache path %s", style.Symbol(cachePath))
 	}
+	defer os.Remove(fh.Name())
 	defer fh.Close()
 
 	_, err = io.Copy(fh, reader)
@@ -128,6 +130,14 @@ func (d *downloader) handleHTTP(ctx context.Context, uri string) (string, error)
 		return "", errors.Wrap(err, "writing cache")
 	}
 
+	if err = fh.Close(); err != nil {
+		return "", errors.Wrap(err, "writing cache")
+	}
+
+	if err = os.Rename(fh.Name(), cachePath); err != nil {
+		return "", errors.Wrapf(err, "create cache path %s", style.Symbol(cachePath))
+	}
+
 	if err = os.WriteFile(etagFile, []byte(etag), 0744); err != nil {
 		return "", errors.Wrap(err, "writing etag")
 	}
@@ -353,6 +353,31 @@ func (b *PackageBuilder) resolvedStacks() []dist.Stack {
 }
 
 func (b *PackageBuilder) SaveAsFile(path, imageOS string, labels map[string]string) error {
+	tmpDir, err := os.MkdirTemp("", "package-file")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	layoutDir := filepath.Join(tmpDir, "oci-layout")
+	if err := b.SaveAsLayout(layoutDir, imageOS, labels); err != nil {
+		return err
+	}
+
+	outputFile, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "creating output file")
+	}
+	defer outputFile.Close()
+
+	tw := tar.NewWriter(outputFile)
+	defer tw.Close()
+
+	return archive.WriteDirToTar(tw, layoutDir, "/", 0, 0, 0755, true, false, nil)
+}
+
+// SaveAsLayout saves the package as an OCI image layout in the directory at path
+func (b *PackageBuilder) SaveAsLayout(path, imageOS string, labels map[string]string) error {
 	if err := b.validate(); err != nil {
 		return err
 	}
@@ -391,12 +416,8 @@ func (b *PackageBuilder) SaveAsFile(path, imageOS string, labels map[string]stri
 			return err
 		}
 	}
-	layoutDir, err := os.MkdirTemp(tmpDir, "oci-layout")
-	if err != nil {
-		return errors.Wrap(err, "creating oci-layout temp dir")
-	}
 
-	p, err := layout.Write(layoutDir, empty.Index)
+	p, err := layout.Write(path, empty.Index)
 	if err != nil {
 		return errors.Wrap(err, "writing index")
 	}
@@ -405,16 +426,7 @@ func (b *PackageBuilder) SaveAsFile(path, imageOS string, labels map[string]stri
 		return errors.Wrap(err, "writing layout")
 	}
 
-	outputFile, err := os.Create(path)
-	if err != nil {
-		return errors.Wrap(err, "creating output file")
-	}
-	defer outputFile.Close()
-
-	tw := tar.NewWriter(outputFile)
-	defer tw.Close()
-
-	return archive.WriteDirToTar(tw, layoutDir, "/", 0, 0, 0755, true, false, nil)
+	return nil
 }
 
 func newLayoutImage(imageOS string) (*layoutImage, error) {
@@ -18,6 +18,7 @@ import (
 	"github.com/buildpacks/imgutil/layer"
 	"github.com/buildpacks/lifecycle/api"
 	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
 	"github.com/google/go-containerregistry/pkg/v1/stream"
 	"github.com/heroku/color"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
@@ -1100,6 +1101,42 @@ func testPackageBuilder(t *testing.T, when spec.G, it spec.S) {
 			)
 		})
 	})
+
+	when("#SaveAsLayout", func() {
+		it("writes an OCI image layout", func() {
+			buildpack1, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+				WithAPI:    api.MustParse("0.2"),
+				WithInfo:   dist.ModuleInfo{ID: "bp.1.id", Version: "bp.1.version"},
+				WithStacks: []dist.Stack{{ID: "stack.id.1"}},
+			}, 0644)
+			h.AssertNil(t, err)
+
+			builder := buildpack.NewBuilder(mockImageFactory(""))
+			builder.SetBuildpack(buildpack1)
+
+			layoutDir := filepath.Join(tmpDir, "package-layout")
+			h.AssertNil(t, builder.SaveAsLayout(layoutDir, "linux", map[string]string{"test.label.one": "1"}))
+
+			layoutPath, err := layout.FromPath(layoutDir)
+			h.AssertNil(t, err)
+			index, err := layoutPath.ImageIndex()
+			h.AssertNil(t, err)
+			manifest, err := index.IndexManifest()
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(manifest.Manifests), 1)
+
+			img, err := layoutPath.Image(manifest.Manifests[0].Digest)
+			h.AssertNil(t, err)
+			configFile, err := img.ConfigFile()
+			h.AssertNil(t, err)
+			h.AssertEq(t, configFile.OS, "linux")
+			h.AssertEq(t, configFile.Config.Labels["test.label.one"], "1")
+			h.AssertContains(t, configFile.Config.Labels["io.buildpacks.buildpackage.metadata"], `"id":"bp.1.id"`)
+			layers, err := img.Layers()
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(layers), 1)
+		})
+	})
 }
 
 func computeLayerSHA(reader io.ReadCloser) (string, error) {
@@ -119,6 +119,10 @@ func testGetLocatorType(t *testing.T, when spec.G, it spec.S) {
 			locator:      "docker://registry.com/cnbs/some-bp:some-tag@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
 			expectedType: buildpack.PackageLocator,
 		},
+		{
+			locator:      "docker://localhost:5000/cnbs/some-bp@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			expectedType: buildpack.PackageLocator,
+		},
 		{
 			locator:      "cnbs/some-bp@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
 			expectedType: buildpack.PackageLocator,
@@ -155,6 +159,14 @@ func testGetLocatorType(t *testing.T, when spec.G, it spec.S) {
 			locator:      "example/registry-cnb",
 			expectedType: buildpack.RegistryLocator,
 		},
+		{
+			locator:      "example/foo@^1.2",
+			expectedType: buildpack.RegistryLocator,
+		},
+		{
+			locator:      "example/foo@>=1.0 <2.0",
+			expectedType: buildpack.RegistryLocator,
+		},
 		{
 			locator:      "cnbs/sample-package@hello-universe",
 			expectedType: buildpack.InvalidLocator,
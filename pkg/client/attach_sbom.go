@@ -0,0 +1,178 @@
+package client
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/sbom"
+)
+
+// AttachSBOMOptions configures how the SBOM of a published image is attached to it.
+type AttachSBOMOptions struct {
+	// Name of the published app image.
+	Image string
+
+	// Format of the attached document. Defaults to CycloneDX.
+	Format sbom.Format
+
+	// Additional directories of SBOMs to merge into the attached document.
+	Dirs []string
+}
+
+// AttachSBOM merges the SBOMs of a published image and pushes the result as an OCI artifact that refers to the image,
+// so that it can be discovered through the referrers API. For registries that do not support the referrers API,
+// the referrers tag scheme is used instead. It returns the digest reference of the pushed artifact.
+func (c *Client) AttachSBOM(ctx context.Context, opts AttachSBOMOptions) (string, error) {
+	format := opts.Format
+	if format == "" {
+		format = sbom.FormatCycloneDX
+	}
+
+	ref, err := name.ParseReference(opts.Image, name.WeakValidation)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid image name %s", style.Symbol(opts.Image))
+	}
+
+	subject, err := remote.Head(ref, c.remoteOptions(ctx)...)
+	if err != nil {
+		return "", errors.Wrapf(err, "reading published image %s", style.Symbol(opts.Image))
+	}
+	subjectRef := ref.Context().Digest(subject.Digest.String())
+
+	doc, err := c.MergeSBOM(ctx, MergeSBOMOptions{Image: subjectRef.String(), Dirs: opts.Dirs})
+	if err != nil {
+		return "", err
+	}
+	doc.Name = opts.Image
+
+	data, err := sbom.Encode(doc, format)
+	if err != nil {
+		return "", err
+	}
+
+	artifact, err := sbomArtifact(data, format, *subject)
+	if err != nil {
+		return "", errors.Wrap(err, "creating SBOM artifact")
+	}
+	digest, err := artifact.Digest()
+	if err != nil {
+		return "", err
+	}
+
+	artifactRef := ref.Context().Digest(digest.String())
+	if err := remote.Write(artifactRef, artifact, c.remoteOptions(ctx)...); err != nil {
+		return "", errors.Wrapf(err, "pushing SBOM to %s", style.Symbol(ref.Context().Name()))
+	}
+
+	c.logger.Infof("Attached %s SBOM with %d package(s) to %s", format, len(doc.Packages), style.Symbol(subjectRef.String()))
+	return artifactRef.String(), nil
+}
+
+// sbomArtifact creates an OCI artifact holding an SBOM document that refers to subject.
+// The artifact type is recorded as the config media type so that registries without support for
+// the artifactType field, and the referrers tag scheme, can still filter referrers by it.
+func sbomArtifact(data []byte, format sbom.Format, subject v1.Descriptor) (v1.Image, error) {
+	mediaType := types.MediaType(format.MediaType())
+
+	artifact, err := mutate.Append(mutate.MediaType(empty.Image, types.OCIManifestSchema1), mutate.Addendum{
+		Layer: static.NewLayer(data, mediaType),
+		Annotations: map[string]string{
+			"org.opencontainers.image.title": format.Filename(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	artifact = mutate.ConfigMediaType(artifact, mediaType)
+
+	return mutate.Subject(artifact, subject).(v1.Image), nil
+}
+
+// downloadReferrerSBOM writes the SBOMs attached to an image by AttachSBOM to destinationDir.
+func (c *Client) downloadReferrerSBOM(ctx context.Context, imageName, destinationDir string) error {
+	ref, err := name.ParseReference(imageName, name.WeakValidation)
+	if err != nil {
+		return errors.Wrapf(err, "invalid image name %s", style.Symbol(imageName))
+	}
+
+	subject, err := remote.Head(ref, c.remoteOptions(ctx)...)
+	if err != nil {
+		return errors.Wrapf(err, "reading image %s", style.Symbol(imageName))
+	}
+
+	referrers, err := remote.Referrers(ref.Context().Digest(subject.Digest.String()), c.remoteOptions(ctx)...)
+	if err != nil {
+		return errors.Wrapf(err, "listing referrers of %s", style.Symbol(imageName))
+	}
+	manifest, err := referrers.IndexManifest()
+	if err != nil {
+		return err
+	}
+
+	// one document is written per attached format
+	written := map[sbom.Format]bool{}
+	for _, desc := range manifest.Manifests {
+		format, ok := sbom.FormatFromMediaType(desc.ArtifactType)
+		if !ok || written[format] {
+			continue
+		}
+
+		artifact, err := remote.Image(ref.Context().Digest(desc.Digest.String()), c.remoteOptions(ctx)...)
+		if err != nil {
+			return errors.Wrapf(err, "fetching SBOM artifact %s", style.Symbol(desc.Digest.String()))
+		}
+		if err := writeSBOMArtifact(artifact, format, destinationDir); err != nil {
+			return err
+		}
+		written[format] = true
+	}
+
+	if len(written) == 0 {
+		return errors.Errorf("could not find an SBoM attached to '%s'", imageName)
+	}
+	return nil
+}
+
+func writeSBOMArtifact(artifact v1.Image, format sbom.Format, destinationDir string) error {
+	layers, err := artifact.Layers()
+	if err != nil {
+		return err
+	}
+	if len(layers) != 1 {
+		return errors.Errorf("expected SBOM artifact to have a single layer, found %d", len(layers))
+	}
+
+	rc, err := layers[0].Uncompressed()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	if err := os.MkdirAll(destinationDir, 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(filepath.Join(destinationDir, format.Filename()))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, rc)
+	return err
+}
+
+func (c *Client) remoteOptions(ctx context.Context) []remote.Option {
+	return []remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(c.keychain)}
+}
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/sbom"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestAttachSBOM(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "AttachSBOM", testAttachSBOM, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testAttachSBOM(t *testing.T, when spec.G, it spec.S) {
+	for _, referrersAPI := range []bool{true, false} {
+		referrersAPI := referrersAPI
+
+		when(fmt.Sprintf("the registry supports the referrers API: %t", referrersAPI), func() {
+			var (
+				subject          *Client
+				fakeImageFetcher *ifakes.FakeImageFetcher
+				server           *httptest.Server
+				tmpDir           string
+				imageName        string
+				out              bytes.Buffer
+			)
+
+			it.Before(func() {
+				server = httptest.NewServer(registry.New(
+					registry.Logger(log.New(io.Discard, "", 0)),
+					registry.WithReferrersSupport(referrersAPI),
+				))
+
+				var err error
+				tmpDir, err = os.MkdirTemp("", "pack.attach.sbom.test.")
+				h.AssertNil(t, err)
+
+				fakeImageFetcher = ifakes.NewFakeImageFetcher()
+				subject = &Client{
+					logger:       logging.NewLogWithWriters(&out, &out),
+					imageFetcher: fakeImageFetcher,
+					keychain:     authn.DefaultKeychain,
+				}
+
+				// publish the app image and make its SBOM layer available through the fetcher
+				imageName = strings.TrimPrefix(server.URL, "http://") + "/some/app:latest"
+				ref, err := name.ParseReference(imageName)
+				h.AssertNil(t, err)
+				img, err := random.Image(1, 1)
+				h.AssertNil(t, err)
+				h.AssertNil(t, remote.Write(ref, img))
+				digest, err := img.Digest()
+				h.AssertNil(t, err)
+
+				layerTar := filepath.Join(tmpDir, "sbom.tar")
+				h.AssertNil(t, archive.CreateSingleFileTar(layerTar, "layers/sbom/launch/some_bp/sbom.cdx.json",
+					`{"bomFormat": "CycloneDX", "components": [{"type": "library", "name": "openssl", "version": "3.0.2"}]}`))
+				data, err := os.ReadFile(layerTar)
+				h.AssertNil(t, err)
+				hsh := sha256.Sum256(data)
+				shasum := hex.EncodeToString(hsh[:])
+
+				fakeImage := fakes.NewImage(imageName, "", nil)
+				h.AssertNil(t, fakeImage.AddLayerWithDiffID(layerTar, "sha256:"+shasum))
+				h.AssertNil(t, fakeImage.SetLabel("io.buildpacks.lifecycle.metadata", fmt.Sprintf(`{"sbom": {"sha": "sha256:%s"}, "buildpacks": [{"key": "some/bp", "version": "1.0.0"}]}`, shasum)))
+				fakeImageFetcher.RemoteImages[ref.Context().Digest(digest.String()).String()] = fakeImage
+			})
+
+			it.After(func() {
+				server.Close()
+				h.AssertNil(t, os.RemoveAll(tmpDir))
+			})
+
+			it("attaches the SBOM as a referrer that can be downloaded", func() {
+				artifact, err := subject.AttachSBOM(context.TODO(), AttachSBOMOptions{Image: imageName, Format: sbom.FormatSPDX})
+				h.AssertNil(t, err)
+				h.AssertContains(t, artifact, "/some/app@sha256:")
+				h.AssertContains(t, out.String(), "Attached spdx SBOM with 1 package(s)")
+
+				destDir := filepath.Join(tmpDir, "download")
+				h.AssertNil(t, subject.DownloadSBOM(imageName, DownloadSBOMOptions{Referrer: true, DestinationDir: destDir}))
+
+				doc, err := sbom.ReadFile(filepath.Join(destDir, "sbom.spdx.json"))
+				h.AssertNil(t, err)
+				h.AssertEq(t, doc.Name, imageName)
+				h.AssertEq(t, doc.Packages[0].Name, "openssl")
+				h.AssertEq(t, doc.Packages[0].Buildpack, "some/bp")
+			})
+
+			it("fails to download when no SBOM is attached", func() {
+				err := subject.DownloadSBOM(imageName, DownloadSBOMOptions{Referrer: true, DestinationDir: tmpDir})
+				h.AssertError(t, err, fmt.Sprintf("could not find an SBoM attached to '%s'", imageName))
+			})
+		})
+	}
+
+	when("the image has not been published", func() {
+		it("returns an error", func() {
+			subject := &Client{logger: logging.NewSimpleLogger(&bytes.Buffer{}), keychain: authn.DefaultKeychain}
+
+			_, err := subject.AttachSBOM(context.TODO(), AttachSBOMOptions{Image: "localhost:1/some/app"})
+			h.AssertError(t, err, "reading published image 'localhost:1/some/app'")
+		})
+	})
+}
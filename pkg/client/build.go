@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -24,15 +25,20 @@ import (
 	"github.com/buildpacks/lifecycle/platform/files"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/volume/mounts"
+	"github.com/docker/go-units"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/pkg/errors"
 	ignore "github.com/sabhiram/go-gitignore"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/net/http/httpproxy"
 
 	"github.com/buildpacks/pack/buildpackage"
 	"github.com/buildpacks/pack/internal/build"
 	"github.com/buildpacks/pack/internal/builder"
 	internalConfig "github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/egress"
 	pname "github.com/buildpacks/pack/internal/name"
+	"github.com/buildpacks/pack/internal/parallel"
 	"github.com/buildpacks/pack/internal/paths"
 	"github.com/buildpacks/pack/internal/stack"
 	"github.com/buildpacks/pack/internal/stringset"
@@ -44,8 +50,11 @@ import (
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/project"
 	projectTypes "github.com/buildpacks/pack/pkg/project/types"
 	v02 "github.com/buildpacks/pack/pkg/project/v02"
+	"github.com/buildpacks/pack/pkg/sbom"
+	"github.com/buildpacks/pack/pkg/tracing"
 )
 
 const (
@@ -93,8 +102,15 @@ type BuildOptions struct {
 
 	// AppPath is the path to application bits.
 	// If unset it defaults to current working directory.
+	// It may be a directory, a zip file, or a tar or gzipped tar file. It may also be the URL of a git repository, in
+	// the form <url>[#<ref>[:<subdir>]], which is fetched using the local git credentials, or a reference to an OCI
+	// artifact, in the form oci://<reference>, whose layers contain the application.
 	AppPath string
 
+	// GitCheckout is the git repository given as AppPath, already checked out with FetchGitSource.
+	// If unset, the repository is fetched during the build.
+	GitCheckout *GitCheckout
+
 	// Specify the run image the Image will be
 	// built atop.
 	RunImage string
@@ -103,6 +119,13 @@ type BuildOptions struct {
 	// e.g. tcp://example.com:1234, unix:///run/user/1000/podman/podman.sock
 	DockerHost string
 
+	// Daemon to run the build on, rather than the daemon of the client.
+	Daemon *DaemonProfile
+
+	// Daemons to choose from when Daemon is not set and the builder is not available for the platform of the daemon of
+	// the client. The build runs on the first one whose platform the builder is available for.
+	DaemonCandidates []DaemonProfile
+
 	// Used to determine a run-image mirror if Run Image is empty.
 	// Used in combination with Builder metadata to determine to the 'best' mirror.
 	// 'best' is defined as:
@@ -198,9 +221,28 @@ type BuildOptions struct {
 	// Only trust builders from reputable sources.
 	TrustBuilder IsTrustedBuilder
 
+	// Decide whether the builder is trusted using a policy instead of TrustBuilder. The decision is logged
+	// and recorded in trust.toml in ReportDestinationDir. If the decision depends on the digest of the builder,
+	// the builder is pinned to that digest for the build.
+	TrustPolicy *TrustPolicy
+
 	// Directory to output any SBOM artifacts
 	SBOMDestinationDir string
 
+	// Format of an SBOM to attach to the published image as an OCI referrer.
+	// Leave empty to not attach an SBOM. Only valid if Publish is true.
+	AttachSBOM sbom.Format
+
+	// Secrets to make available to the detect and build phases only, as read-only files in /run/secrets.
+	// Secrets are never set as environment variables, cached or exported, and are redacted from build output.
+	// They are bound into the containers from a tmpfs on the local machine, so they require the Docker daemon to run on it.
+	// Providing secrets disables running all lifecycle phases in a single container.
+	Secrets []BuildSecret
+
+	// Restrict the hosts the build containers may contact, recording those contacted in egress.toml
+	// in ReportDestinationDir. Leave nil to use the policy in the project descriptor, if any.
+	EgressPolicy *EgressPolicy
+
 	// Directory to output the report.toml metadata artifact
 	ReportDestinationDir string
 
@@ -209,6 +251,21 @@ type BuildOptions struct {
 
 	// Configuration to export to OCI layout format
 	LayoutConfig *LayoutConfig
+
+	// Pin the builder, run image, buildpacks and extensions to the digests and checksums in the lock.
+	// Inputs that are not in the lock are resolved as usual, with a warning.
+	Lock *project.Lock
+
+	// Also exclude the paths listed in .gitignore files from the app source. Paths listed in .packignore
+	// files are always excluded.
+	UseGitignore bool
+
+	// Log the files of the app source sent to the build, and their total size.
+	PrintContext bool
+
+	// Keep the app source in a volume between builds, so that only the files that changed since the previous build
+	// of the same app directory are copied. Ignored for git repository and OCI artifact sources.
+	KeepAppVolume bool
 }
 
 func (b *BuildOptions) Layout() bool {
@@ -245,6 +302,30 @@ type ContainerConfig struct {
 	// - /layers
 	// - anything below /cnb/**
 	Volumes []string
+
+	// Memory limit, in bytes, of the containers running buildpacks. Zero means no limit.
+	Memory int64
+
+	// Number of CPUs available to the containers running buildpacks, e.g. 1.5. Zero means no limit.
+	CPUs float64
+
+	// Maximum number of processes in the containers running buildpacks. Zero means no limit.
+	PidsLimit int64
+
+	// Ulimits of the containers running buildpacks, in the form <type>=<soft limit>[:<hard limit>].
+	Ulimits []string
+
+	// Additional /etc/hosts entries of the build containers, in the form <host>:<ip>.
+	ExtraHosts []string
+
+	// DNS servers of the build containers.
+	DNS []string
+
+	// Security options of the containers running buildpacks, e.g. no-new-privileges.
+	SecurityOpt []string
+
+	// Kernel capabilities to drop from the containers running buildpacks, e.g. ALL.
+	CapDrop []string
 }
 
 type LayoutConfig struct {
@@ -287,9 +368,48 @@ var IsTrustedBuilderFunc = func(b string) bool {
 // It then invokes the lifecycle to build an app image.
 // If any configuration is deemed invalid, or if any lifecycle phases fail,
 // an error will be returned and no image produced.
-func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
+func (c *Client) Build(ctx context.Context, opts BuildOptions) (err error) {
+	ctx, span := c.startSpan(ctx, "pack.build",
+		attribute.String("pack.image", opts.Image),
+		attribute.String("pack.builder", opts.Builder),
+		attribute.Bool("pack.publish", opts.Publish),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	daemon, err := c.selectDaemon(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if daemon != nil {
+		daemonClient, daemonConn, err := c.onDaemon(*daemon)
+		if err != nil {
+			return err
+		}
+		defer daemonConn.Close()
+		c.logger.Infof("Building on %s", style.Symbol(daemon.Name))
+		opts.Daemon, opts.DaemonCandidates = nil, nil
+		if daemon.LifecycleImage != "" {
+			opts.LifecycleImage = daemon.LifecycleImage
+		}
+		return daemonClient.Build(ctx, opts)
+	}
+
 	var pathsConfig layoutPathConfig
 
+	if opts.AttachSBOM != "" && !opts.Publish {
+		return errors.New("attaching an SBOM requires publishing the image")
+	}
+
+	secrets, err := resolveSecrets(opts.Secrets)
+	if err != nil {
+		return err
+	}
+
+	egressAllow, err := egressAllowlist(opts.EgressPolicy, opts.ProjectDescriptor.Build.Egress)
+	if err != nil {
+		return err
+	}
+
 	imageRef, err := c.parseReference(opts)
 	if err != nil {
 		return errors.Wrapf(err, "invalid image name '%s'", opts.Image)
@@ -308,21 +428,85 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		}
 	}
 
-	appPath, err := c.processAppPath(opts.AppPath)
+	var gitProjectSource *files.ProjectSource
+	localAppPath := opts.AppPath
+	if IsGitSource(opts.AppPath) {
+		checkout := opts.GitCheckout
+		if checkout == nil {
+			if checkout, err = c.FetchGitSource(ctx, opts.AppPath); err != nil {
+				return err
+			}
+			defer checkout.Close()
+		}
+		localAppPath, gitProjectSource = checkout.AppPath, checkout.projectSource
+	} else if IsOCISource(opts.AppPath) {
+		ociDir, err := os.MkdirTemp("", "pack.oci-source.")
+		if err != nil {
+			return errors.Wrap(err, "creating temp directory")
+		}
+		defer os.RemoveAll(ociDir)
+
+		if localAppPath, err = c.fetchOCISource(ctx, opts.AppPath, ociDir); err != nil {
+			return errors.Wrapf(err, "fetching OCI source %s", style.Symbol(opts.AppPath))
+		}
+	}
+
+	appPath, err := c.processAppPath(localAppPath)
 	if err != nil {
 		return errors.Wrapf(err, "invalid app path '%s'", opts.AppPath)
 	}
 
 	proxyConfig := c.processProxyConfig(opts.ProxyConfig)
 
+	builderPullPolicy := opts.PullPolicy
+	if layoutBuilder := ParseInputImageReference(opts.Builder); layoutBuilder.Layout() {
+		layoutDir, err := layoutBuilder.FullName()
+		if err != nil {
+			return errors.Wrapf(err, "invalid builder '%s'", opts.Builder)
+		}
+		if opts.Builder, err = c.loadLayoutBuilder(ctx, layoutDir); err != nil {
+			return err
+		}
+		// the builder only exists in the daemon
+		builderPullPolicy = image.PullNever
+	}
+
 	builderRef, err := c.processBuilderName(opts.Builder)
 	if err != nil {
 		return errors.Wrapf(err, "invalid builder '%s'", opts.Builder)
 	}
 
-	rawBuilderImage, err := c.imageFetcher.Fetch(ctx, builderRef.Name(), image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy})
+	builderName := builderRef.Name()
+	var lockedDigest string
+	if opts.Lock != nil {
+		builderName = c.lockedImageName(ctx, opts.Lock.Builder, opts.Builder, "builder")
+		_, lockedDigest, _ = strings.Cut(builderName, "@")
+	}
+
+	// Default mode: if the TrustBuilder option is not set, trust the suggested builders.
+	if opts.TrustBuilder == nil {
+		opts.TrustBuilder = IsTrustedBuilderFunc
+	}
+
+	trustBuilder := opts.TrustBuilder(opts.Builder)
+	if opts.TrustPolicy != nil {
+		// trust rules name the builder as requested, the locked digest is what gets fetched
+		decision, err := c.EvaluateTrust(ctx, opts.Builder, lockedDigest, *opts.TrustPolicy)
+		if err != nil {
+			return errors.Wrapf(err, "evaluating trust of builder %s", style.Symbol(opts.Builder))
+		}
+		if err := c.recordTrustDecision(decision, opts.ReportDestinationDir); err != nil {
+			return err
+		}
+		if decision.Digest != "" {
+			builderName = builderRef.Context().Name() + "@" + decision.Digest
+		}
+		trustBuilder = decision.TrustsLifecycle()
+	}
+
+	rawBuilderImage, err := c.imageFetcher.Fetch(ctx, builderName, image.FetchOptions{Daemon: true, PullPolicy: builderPullPolicy})
 	if err != nil {
-		return errors.Wrapf(err, "failed to fetch builder image '%s'", builderRef.Name())
+		return errors.Wrapf(err, "failed to fetch builder image '%s'", builderName)
 	}
 
 	builderOS, err := rawBuilderImage.OS()
@@ -341,6 +525,9 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 	}
 
 	runImageName := c.resolveRunImage(opts.RunImage, imgRegistry, builderRef.Context().RegistryStr(), bldr.DefaultRunImage(), opts.AdditionalMirrors, opts.Publish, c.accessChecker)
+	if opts.Lock != nil {
+		runImageName = c.lockedImageName(ctx, opts.Lock.RunImage, runImageName, "run image")
+	}
 
 	fetchOptions := image.FetchOptions{
 		Daemon:     !opts.Publish,
@@ -362,30 +549,6 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		pathsConfig.targetRunImagePath = targetRunImagePath
 		pathsConfig.hostRunImagePath = hostRunImagePath
 	}
-	runImage, err := c.validateRunImage(ctx, runImageName, fetchOptions, bldr.StackID)
-	if err != nil {
-		return errors.Wrapf(err, "invalid run-image '%s'", runImageName)
-	}
-
-	var runMixins []string
-	if _, err := dist.GetLabel(runImage, stack.MixinsLabel, &runMixins); err != nil {
-		return err
-	}
-
-	fetchedBPs, order, err := c.processBuildpacks(ctx, bldr.Image(), bldr.Buildpacks(), bldr.Order(), bldr.StackID, opts)
-	if err != nil {
-		return err
-	}
-
-	fetchedExs, orderExtensions, err := c.processExtensions(ctx, bldr.Image(), bldr.Extensions(), bldr.OrderExtensions(), bldr.StackID, opts)
-	if err != nil {
-		return err
-	}
-
-	// Default mode: if the TrustBuilder option is not set, trust the suggested builders.
-	if opts.TrustBuilder == nil {
-		opts.TrustBuilder = IsTrustedBuilderFunc
-	}
 
 	// Ensure the builder's platform APIs are supported
 	var builderPlatformAPIs builder.APISet
@@ -399,42 +562,92 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 
 	// Get the platform API version to use
 	lifecycleVersion := bldr.LifecycleDescriptor().Info.Version
-	useCreator := supportsCreator(lifecycleVersion) && opts.TrustBuilder(opts.Builder)
+	useCreator := supportsCreator(lifecycleVersion) && trustBuilder
+	if useCreator && len(secrets) > 0 {
+		c.logger.Debug("Running each lifecycle phase in a separate container as build secrets were provided")
+		useCreator = false
+	}
+	if useCreator && egressAllow != nil {
+		c.logger.Debug("Running each lifecycle phase in a separate container as egress is restricted")
+		useCreator = false
+	}
+
+	// The run image, lifecycle image and additional modules only depend on the builder,
+	// so they are fetched concurrently.
 	var (
-		lifecycleOptsLifecycleImage string
-		lifecycleAPIs               []string
+		runImage        imgutil.Image
+		lifecycleImage  imgutil.Image
+		fetchedBPs      []buildpack.BuildModule
+		order           dist.Order
+		fetchedExs      []buildpack.BuildModule
+		orderExtensions dist.Order
 	)
-	if !(useCreator) {
-		// fetch the lifecycle image
-		if supportsLifecycleImage(lifecycleVersion) {
-			lifecycleImageName := opts.LifecycleImage
-			if lifecycleImageName == "" {
-				lifecycleImageName = fmt.Sprintf("%s:%s", internalConfig.DefaultLifecycleImageRepo, lifecycleVersion.String())
-			}
+	setupTasks := []func() error{
+		func() error {
+			return c.fetchLimiter.Do(func() (err error) {
+				if runImage, err = c.validateRunImage(ctx, runImageName, fetchOptions, bldr.StackID); err != nil {
+					return errors.Wrapf(err, "invalid run-image '%s'", runImageName)
+				}
+				return nil
+			})
+		},
+		func() (err error) {
+			fetchedBPs, order, err = c.processBuildpacks(ctx, bldr.Image(), bldr.Buildpacks(), bldr.Order(), bldr.StackID, opts)
+			return err
+		},
+		func() (err error) {
+			fetchedExs, orderExtensions, err = c.processExtensions(ctx, bldr.Image(), bldr.Extensions(), bldr.OrderExtensions(), bldr.StackID, opts)
+			return err
+		},
+	}
+	if !useCreator && supportsLifecycleImage(lifecycleVersion) {
+		lifecycleImageName := opts.LifecycleImage
+		if lifecycleImageName == "" {
+			lifecycleImageName = fmt.Sprintf("%s:%s", internalConfig.DefaultLifecycleImageRepo, lifecycleVersion.String())
+		}
 
-			lifecycleImage, err := c.imageFetcher.Fetch(
-				ctx,
-				lifecycleImageName,
-				image.FetchOptions{
-					Daemon:     true,
-					PullPolicy: opts.PullPolicy,
-					Platform:   fmt.Sprintf("%s/%s", builderOS, builderArch),
-				},
-			)
-			if err != nil {
-				return fmt.Errorf("fetching lifecycle image: %w", err)
-			}
+		setupTasks = append(setupTasks, func() error {
+			return c.fetchLimiter.Do(func() (err error) {
+				lifecycleImage, err = c.imageFetcher.Fetch(
+					ctx,
+					lifecycleImageName,
+					image.FetchOptions{
+						Daemon:     true,
+						PullPolicy: opts.PullPolicy,
+						Platform:   fmt.Sprintf("%s/%s", builderOS, builderArch),
+					},
+				)
+				if err != nil {
+					return fmt.Errorf("fetching lifecycle image: %w", err)
+				}
+				return nil
+			})
+		})
+	}
+	// the tasks only wait on fetches, which share the client's fetch limiter, so they aren't limited themselves
+	if err = parallel.Run(len(setupTasks), len(setupTasks), func(i int) error { return setupTasks[i]() }); err != nil {
+		return err
+	}
 
-			lifecycleOptsLifecycleImage = lifecycleImage.Name()
-			labels, err := lifecycleImage.Labels()
-			if err != nil {
-				return fmt.Errorf("reading labels of lifecycle image: %w", err)
-			}
+	var runMixins []string
+	if _, err := dist.GetLabel(runImage, stack.MixinsLabel, &runMixins); err != nil {
+		return err
+	}
 
-			lifecycleAPIs, err = extractSupportedLifecycleApis(labels)
-			if err != nil {
-				return fmt.Errorf("reading api versions of lifecycle image: %w", err)
-			}
+	var (
+		lifecycleOptsLifecycleImage string
+		lifecycleAPIs               []string
+	)
+	if lifecycleImage != nil {
+		lifecycleOptsLifecycleImage = lifecycleImage.Name()
+		labels, err := lifecycleImage.Labels()
+		if err != nil {
+			return fmt.Errorf("reading labels of lifecycle image: %w", err)
+		}
+
+		lifecycleAPIs, err = extractSupportedLifecycleApis(labels)
+		if err != nil {
+			return fmt.Errorf("reading api versions of lifecycle image: %w", err)
 		}
 	}
 
@@ -478,6 +691,16 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		}
 	}
 
+	var secretsDir string
+	if len(secrets) > 0 {
+		if builderOS == "windows" {
+			return fmt.Errorf("build secrets are not supported for Windows builds")
+		}
+		if secretsDir, err = secretsTmpfs(c.docker.DaemonHost()); err != nil {
+			return err
+		}
+	}
+
 	if opts.Layout() {
 		opts.ContainerConfig.Volumes = appendLayoutVolumes(opts.ContainerConfig.Volumes, pathsConfig)
 	}
@@ -487,6 +710,15 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return err
 	}
 
+	containerConfig, err := mergeContainerConfig(opts.ContainerConfig, opts.ProjectDescriptor.Build.Container)
+	if err != nil {
+		return err
+	}
+	resources, err := processContainerResources(containerConfig)
+	if err != nil {
+		return err
+	}
+
 	for _, warning := range warnings {
 		c.logger.Warn(warning)
 	}
@@ -495,14 +727,64 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 	if err != nil {
 		return err
 	}
+	ignoreFilter, err := ignoreFileFilter(appPath, opts.UseGitignore)
+	if err != nil {
+		return err
+	}
+	fileFilter = combineFileFilters(fileFilter, ignoreFilter)
+
+	if opts.PrintContext {
+		if err := c.printBuildContext(appPath, fileFilter); err != nil {
+			return err
+		}
+	}
+
+	var appSourceVolume, appSourceIndex string
+	if opts.KeepAppVolume && !IsGitSource(opts.AppPath) && !IsOCISource(opts.AppPath) {
+		if appSourceVolume, appSourceIndex, err = keptAppSource(appPath, c.docker.DaemonHost()); err != nil {
+			return err
+		}
+		c.logger.Debugf("Keeping app source in volume %s", style.Symbol(appSourceVolume))
+	}
 
 	runImageName, err = pname.TranslateRegistry(runImageName, c.registryMirrors, c.logger)
 	if err != nil {
 		return err
 	}
 
+	var (
+		egressProxy              *egress.Proxy
+		egressNetwork, egressURL string
+	)
+	if egressAllow != nil {
+		if builderOS == "windows" {
+			return fmt.Errorf("egress policies are not supported for Windows builds")
+		}
+
+		var gateway string
+		if egressNetwork, gateway, err = c.createEgressNetwork(ctx); err != nil {
+			return err
+		}
+		defer c.removeEgressNetwork(egressNetwork)
+
+		upstream := &httpproxy.Config{HTTPProxy: proxyConfig.HTTPProxy, HTTPSProxy: proxyConfig.HTTPSProxy, NoProxy: proxyConfig.NoProxy}
+		egressProxy, err = egress.NewProxy(egressAllow, upstream.ProxyFunc())
+		if err != nil {
+			return err
+		}
+		if err = egressProxy.Start(net.JoinHostPort(gateway, "0")); err != nil {
+			return errors.Wrap(err, "the egress proxy must listen on the gateway of the egress network, on the local machine")
+		}
+		defer egressProxy.Close()
+
+		egressURL = egressProxy.URL(gateway)
+		c.logger.Debugf("Restricting build egress to %s", strings.Join(egressAllow, ", "))
+	}
+
 	projectMetadata := files.ProjectMetadata{}
-	if c.experimental {
+	if gitProjectSource != nil {
+		projectMetadata.Source = gitProjectSource
+	} else if c.experimental {
 		version := opts.ProjectDescriptor.Project.Version
 		sourceURL := opts.ProjectDescriptor.Project.SourceURL
 		if version != "" || sourceURL != "" {
@@ -526,7 +808,7 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		ProjectMetadata:          projectMetadata,
 		ClearCache:               opts.ClearCache,
 		Publish:                  opts.Publish,
-		TrustBuilder:             opts.TrustBuilder(opts.Builder),
+		TrustBuilder:             trustBuilder,
 		UseCreator:               useCreator,
 		UseCreatorWithExtensions: supportsCreatorWithExtensions(lifecycleVersion),
 		DockerHost:               opts.DockerHost,
@@ -535,11 +817,15 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		HTTPProxy:                proxyConfig.HTTPProxy,
 		HTTPSProxy:               proxyConfig.HTTPSProxy,
 		NoProxy:                  proxyConfig.NoProxy,
+		EgressProxy:              egressURL,
+		EgressNetwork:            egressNetwork,
 		Network:                  opts.ContainerConfig.Network,
 		AdditionalTags:           opts.AdditionalTags,
 		Volumes:                  processedVolumes,
 		DefaultProcessType:       opts.DefaultProcessType,
 		FileFilter:               fileFilter,
+		AppSourceVolume:          appSourceVolume,
+		AppSourceIndex:           appSourceIndex,
 		Workspace:                opts.Workspace,
 		GID:                      opts.GroupID,
 		UID:                      opts.UserID,
@@ -551,6 +837,11 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		CreationTime:             opts.CreationTime,
 		Layout:                   opts.Layout(),
 		Keychain:                 c.keychain,
+		Secrets:                  secrets,
+		SecretsHostDir:           secretsDir,
+		Resources:                resources,
+		ExtraHosts:               containerConfig.ExtraHosts,
+		DNS:                      containerConfig.DNS,
 	}
 
 	switch {
@@ -559,7 +850,7 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 	case supportsLifecycleImage(lifecycleVersion):
 		lifecycleOpts.LifecycleImage = lifecycleOptsLifecycleImage
 		lifecycleOpts.LifecycleApis = lifecycleAPIs
-	case !opts.TrustBuilder(opts.Builder):
+	case !trustBuilder:
 		return errors.Errorf("Lifecycle %s does not have an associated lifecycle image. Builder must be trusted.", lifecycleVersion.String())
 	}
 
@@ -697,9 +988,21 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return ephemeralRunImageName, nil
 	}
 
-	if err = c.lifecycleExecutor.Execute(ctx, lifecycleOpts); err != nil {
+	err = c.lifecycleExecutor.Execute(ctx, lifecycleOpts)
+	if egressProxy != nil {
+		if egressErr := c.processEgressReport(egressProxy.Report(), opts.ReportDestinationDir); egressErr != nil {
+			return egressErr
+		}
+	}
+	if err != nil {
 		return fmt.Errorf("executing lifecycle: %w", err)
 	}
+
+	if opts.AttachSBOM != "" {
+		if _, err = c.AttachSBOM(ctx, AttachSBOMOptions{Image: imageRef.Name(), Format: opts.AttachSBOM}); err != nil {
+			return errors.Wrap(err, "attaching SBOM")
+		}
+	}
 	return c.logImageNameAndSha(ctx, opts.Publish, imageRef)
 }
 
@@ -933,7 +1236,14 @@ func (c *Client) processAppPath(appPath string) (string, error) {
 		}
 
 		if !isZip {
-			return "", errors.New("app path must be a directory or zip")
+			isTar, err := archive.IsTar(resolvedAppPath)
+			if err != nil {
+				return "", errors.Wrap(err, "check tar")
+			}
+
+			if !isTar {
+				return "", errors.New("app path must be a directory, zip or tar")
+			}
 		}
 	}
 
@@ -1072,14 +1382,14 @@ func (c *Client) processBuildpacks(ctx context.Context, builderImage imgutil.Ima
 		}
 	}
 
-	order = dist.Order{{Group: []dist.ModuleRef{}}}
-	for _, bp := range declaredBPs {
-		locatorType, err := buildpack.GetLocatorType(bp, relativeBaseDir, builderBPs)
-		if err != nil {
-			return nil, nil, err
-		}
+	fetched, err := c.fetchBuildpacks(ctx, declaredBPs, relativeBaseDir, builderImage, builderBPs, opts, buildpack.KindBuildpack)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		switch locatorType {
+	order = dist.Order{{Group: []dist.ModuleRef{}}}
+	for _, f := range fetched {
+		switch f.locatorType {
 		case buildpack.FromBuilderLocator:
 			switch {
 			case len(order) == 0 || len(order[0].Group) == 0:
@@ -1098,12 +1408,8 @@ func (c *Client) processBuildpacks(ctx context.Context, builderImage imgutil.Ima
 				order = newOrder
 			}
 		default:
-			newFetchedBPs, moduleInfo, err := c.fetchBuildpack(ctx, bp, relativeBaseDir, builderImage, builderBPs, opts, buildpack.KindBuildpack)
-			if err != nil {
-				return fetchedBPs, order, err
-			}
-			fetchedBPs = append(fetchedBPs, newFetchedBPs...)
-			order = appendBuildpackToOrder(order, *moduleInfo)
+			fetchedBPs = append(fetchedBPs, f.modules...)
+			order = appendBuildpackToOrder(order, *f.info)
 		}
 	}
 
@@ -1131,22 +1437,19 @@ func (c *Client) processBuildpacks(ctx context.Context, builderImage imgutil.Ima
 
 		if len(preBuildpacks) > 0 || len(postBuildpacks) > 0 {
 			order = builderOrder
-			for _, bp := range preBuildpacks {
-				newFetchedBPs, moduleInfo, err := c.fetchBuildpack(ctx, bp, relativeBaseDir, builderImage, builderBPs, opts, buildpack.KindBuildpack)
-				if err != nil {
-					return fetchedBPs, order, err
-				}
-				fetchedBPs = append(fetchedBPs, newFetchedBPs...)
-				order = prependBuildpackToOrder(order, *moduleInfo)
+			fetched, err := c.fetchBuildpacks(ctx, append(append([]string{}, preBuildpacks...), postBuildpacks...), relativeBaseDir, builderImage, builderBPs, opts, buildpack.KindBuildpack)
+			if err != nil {
+				return fetchedBPs, order, err
 			}
 
-			for _, bp := range postBuildpacks {
-				newFetchedBPs, moduleInfo, err := c.fetchBuildpack(ctx, bp, relativeBaseDir, builderImage, builderBPs, opts, buildpack.KindBuildpack)
-				if err != nil {
-					return fetchedBPs, order, err
-				}
-				fetchedBPs = append(fetchedBPs, newFetchedBPs...)
-				order = appendBuildpackToOrder(order, *moduleInfo)
+			for _, f := range fetched[:len(preBuildpacks)] {
+				fetchedBPs = append(fetchedBPs, f.modules...)
+				order = prependBuildpackToOrder(order, *f.info)
+			}
+
+			for _, f := range fetched[len(preBuildpacks):] {
+				fetchedBPs = append(fetchedBPs, f.modules...)
+				order = appendBuildpackToOrder(order, *f.info)
 			}
 		}
 	}
@@ -1154,6 +1457,45 @@ func (c *Client) processBuildpacks(ctx context.Context, builderImage imgutil.Ima
 	return fetchedBPs, order, nil
 }
 
+// fetchedModule is the result of resolving a single declared buildpack or extension locator.
+type fetchedModule struct {
+	locatorType buildpack.LocatorType
+	modules     []buildpack.BuildModule
+	info        *dist.ModuleInfo
+}
+
+// fetchBuildpacks resolves the given locators concurrently. Results are returned in the order of locators,
+// and locators referencing the builder's buildpacks are not fetched.
+func (c *Client) fetchBuildpacks(ctx context.Context, locators []string, relativeBaseDir string, builderImage imgutil.Image, builderBPs []dist.ModuleInfo, opts BuildOptions, kind string) ([]fetchedModule, error) {
+	results := make([]fetchedModule, len(locators))
+	for i, bp := range locators {
+		locatorType, err := buildpack.GetLocatorType(bp, relativeBaseDir, builderBPs)
+		if err != nil {
+			return nil, err
+		}
+		results[i].locatorType = locatorType
+	}
+
+	err := parallel.Run(len(locators), len(locators), func(i int) error {
+		if results[i].locatorType == buildpack.FromBuilderLocator {
+			return nil
+		}
+
+		return c.fetchLimiter.Do(func() error {
+			locator, err := c.lockedLocator(ctx, opts.Lock, locators[i], results[i].locatorType, relativeBaseDir)
+			if err != nil {
+				return err
+			}
+			results[i].modules, results[i].info, err = c.fetchBuildpack(ctx, locator, relativeBaseDir, builderImage, builderBPs, opts, kind)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
 func (c *Client) fetchBuildpack(ctx context.Context, bp string, relativeBaseDir string, builderImage imgutil.Image, builderBPs []dist.ModuleInfo, opts BuildOptions, kind string) ([]buildpack.BuildModule, *dist.ModuleInfo, error) {
 	pullPolicy := opts.PullPolicy
 	publish := opts.Publish
@@ -1295,7 +1637,6 @@ func (c *Client) processExtensions(ctx context.Context, builderImage imgutil.Ima
 	relativeBaseDir := opts.RelativeBaseDir
 	declaredExs := opts.Extensions
 
-	orderExtensions = dist.Order{{Group: []dist.ModuleRef{}}}
 	for _, ex := range declaredExs {
 		locatorType, err := buildpack.GetLocatorType(ex, relativeBaseDir, builderExs)
 		if err != nil {
@@ -1307,16 +1648,20 @@ func (c *Client) processExtensions(ctx context.Context, builderImage imgutil.Ima
 			return nil, nil, errors.New("RegistryLocator type is not valid for extensions")
 		case buildpack.FromBuilderLocator:
 			return nil, nil, errors.New("from builder is not supported for extensions")
-		default:
-			newFetchedExs, moduleInfo, err := c.fetchBuildpack(ctx, ex, relativeBaseDir, builderImage, builderExs, opts, buildpack.KindExtension)
-			if err != nil {
-				return fetchedExs, orderExtensions, err
-			}
-			fetchedExs = append(fetchedExs, newFetchedExs...)
-			orderExtensions = prependBuildpackToOrder(orderExtensions, *moduleInfo)
 		}
 	}
 
+	fetched, err := c.fetchBuildpacks(ctx, declaredExs, relativeBaseDir, builderImage, builderExs, opts, buildpack.KindExtension)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orderExtensions = dist.Order{{Group: []dist.ModuleRef{}}}
+	for _, f := range fetched {
+		fetchedExs = append(fetchedExs, f.modules...)
+		orderExtensions = prependBuildpackToOrder(orderExtensions, *f.info)
+	}
+
 	return fetchedExs, orderExtensions, nil
 }
 
@@ -1519,6 +1864,67 @@ func fullImagePath(inputImageRef InputImageReference, create bool) (string, erro
 	return imagePath, nil
 }
 
+// mergeContainerConfig fills any container settings not provided in config from the project descriptor.
+func mergeContainerConfig(config ContainerConfig, descriptor projectTypes.Container) (ContainerConfig, error) {
+	if config.Memory == 0 && descriptor.Memory != "" {
+		memory, err := units.RAMInBytes(descriptor.Memory)
+		if err != nil {
+			return ContainerConfig{}, errors.Wrapf(err, "parsing memory limit %s in project descriptor", style.Symbol(descriptor.Memory))
+		}
+		config.Memory = memory
+	}
+	if config.CPUs == 0 {
+		config.CPUs = descriptor.CPUs
+	}
+	if config.PidsLimit == 0 {
+		config.PidsLimit = descriptor.PidsLimit
+	}
+	if len(config.Ulimits) == 0 {
+		config.Ulimits = descriptor.Ulimits
+	}
+	if len(config.ExtraHosts) == 0 {
+		config.ExtraHosts = descriptor.ExtraHosts
+	}
+	if len(config.DNS) == 0 {
+		config.DNS = descriptor.DNS
+	}
+	if len(config.SecurityOpt) == 0 {
+		config.SecurityOpt = descriptor.SecurityOpt
+	}
+	if len(config.CapDrop) == 0 {
+		config.CapDrop = descriptor.CapDrop
+	}
+	return config, nil
+}
+
+func processContainerResources(config ContainerConfig) (build.ContainerResources, error) {
+	if config.Memory < 0 {
+		return build.ContainerResources{}, errors.New("memory limit must not be negative")
+	}
+	if config.CPUs < 0 {
+		return build.ContainerResources{}, errors.New("cpus must not be negative")
+	}
+	if config.PidsLimit < 0 {
+		return build.ContainerResources{}, errors.New("pids limit must not be negative")
+	}
+
+	resources := build.ContainerResources{
+		Memory:      config.Memory,
+		NanoCPUs:    int64(config.CPUs * 1e9),
+		PidsLimit:   config.PidsLimit,
+		SecurityOpt: config.SecurityOpt,
+		CapDrop:     config.CapDrop,
+	}
+	for _, ulimit := range config.Ulimits {
+		parsed, err := units.ParseUlimit(ulimit)
+		if err != nil {
+			return build.ContainerResources{}, errors.Wrapf(err, "invalid ulimit %s", style.Symbol(ulimit))
+		}
+		resources.Ulimits = append(resources.Ulimits, parsed)
+	}
+	return resources, nil
+}
+
 // appendLayoutVolumes mount host volume into the build container, in the form '<host path>:<target path>[:<options>]'
 // the volumes mounted are:
 // - The path where the user wants the image to be exported in OCI layout format
@@ -0,0 +1,237 @@
+package client
+
+import (
+	"archive/tar"
+	"bufio"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
+)
+
+const (
+	// PackIgnoreFile is the name of the files listing paths to exclude from the app source, using .gitignore syntax
+	PackIgnoreFile = ".packignore"
+
+	gitIgnoreFile = ".gitignore"
+)
+
+// ignoreFileFilter returns a filter excluding the paths matched by the ignore files in the app directory and its
+// subdirectories, or nil if there are no patterns. Patterns are relative to the directory of their ignore file, and
+// take precedence over those of ignore files above it. Ignore files are not read from zip or tar app sources.
+func ignoreFileFilter(appPath string, useGitignore bool) (func(string) bool, error) {
+	fi, err := os.Stat(appPath)
+	if err != nil || !fi.IsDir() {
+		return nil, nil
+	}
+
+	ignoreFiles := []string{PackIgnoreFile}
+	if useGitignore {
+		ignoreFiles = []string{gitIgnoreFile, PackIgnoreFile}
+	}
+
+	patterns, err := readIgnorePatterns(appPath, ignoreFiles)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading ignore files")
+	}
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	matcher := gitignore.NewMatcher(patterns)
+	return func(relPath string) bool {
+		parts := splitRelPath(relPath)
+		if len(parts) == 0 {
+			return true
+		}
+		fi, err := os.Lstat(filepath.Join(appPath, relPath))
+		return !matcher.Match(parts, err == nil && fi.IsDir())
+	}, nil
+}
+
+// readIgnorePatterns reads the patterns of the ignore files in appDir and its subdirectories, in ascending order of
+// precedence. Directories that are ignored are not searched for ignore files.
+func readIgnorePatterns(appDir string, ignoreFiles []string) ([]gitignore.Pattern, error) {
+	var patterns []gitignore.Pattern
+	err := filepath.WalkDir(appDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(appDir, path)
+		if err != nil {
+			return err
+		}
+		domain := splitRelPath(relPath)
+		if len(domain) > 0 && gitignore.NewMatcher(patterns).Match(domain, true) {
+			return filepath.SkipDir
+		}
+
+		for _, ignoreFile := range ignoreFiles {
+			filePatterns, err := readIgnoreFile(filepath.Join(path, ignoreFile), domain)
+			if err != nil {
+				return err
+			}
+			patterns = append(patterns, filePatterns...)
+		}
+		return nil
+	})
+	return patterns, err
+}
+
+func readIgnoreFile(path string, domain []string) ([]gitignore.Pattern, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var patterns []gitignore.Pattern
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+			continue
+		}
+		patterns = append(patterns, gitignore.ParsePattern(line, domain))
+	}
+	return patterns, errors.Wrapf(scanner.Err(), "reading %s", style.Symbol(path))
+}
+
+func splitRelPath(relPath string) []string {
+	relPath = filepath.ToSlash(filepath.Clean(relPath))
+	if relPath == "." {
+		return nil
+	}
+	return strings.Split(relPath, "/")
+}
+
+// combineFileFilters returns a filter accepting the paths accepted by all filters, or nil if there are no filters
+func combineFileFilters(filters ...func(string) bool) func(string) bool {
+	var combined []func(string) bool
+	for _, filter := range filters {
+		if filter != nil {
+			combined = append(combined, filter)
+		}
+	}
+	if len(combined) == 0 {
+		return nil
+	}
+
+	return func(path string) bool {
+		for _, filter := range combined {
+			if !filter(path) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// keptAppSource returns the name of the volume the app source at appPath is kept in between builds on the daemon at
+// daemonHost, and the path of the index of the files copied into it
+func keptAppSource(appPath, daemonHost string) (string, string, error) {
+	home, err := config.PackHome()
+	if err != nil {
+		return "", "", err
+	}
+
+	sum := sha256.Sum256([]byte(appPath + "\x00" + daemonHost))
+	volumeName := paths.FilterReservedNames(fmt.Sprintf("pack-app-source-%x", sum[:6]))
+	return volumeName, filepath.Join(home, "app-volumes", volumeName+".json"), nil
+}
+
+// printBuildContext logs the files of the app source that are sent to the build, and their total size
+func (c *Client) printBuildContext(appPath string, fileFilter func(string) bool) error {
+	c.logger.Info("Build context:")
+	var (
+		files int
+		total int64
+	)
+	err := walkBuildContext(appPath, fileFilter, func(name string, size int64) {
+		files++
+		total += size
+		c.logger.Infof("  %10s  %s", humanize.Bytes(uint64(size)), name)
+	})
+	if err != nil {
+		return err
+	}
+	c.logger.Infof("Sending %d files, %s in total", files, humanize.Bytes(uint64(total)))
+	return nil
+}
+
+// walkBuildContext calls fn with the path and size of every file of the app source accepted by fileFilter. App
+// directories are walked without reading the files, like archive.ReadDirAsTar would see them.
+func walkBuildContext(appPath string, fileFilter func(string) bool, fn func(name string, size int64)) error {
+	fi, err := os.Stat(appPath)
+	if err != nil {
+		return err
+	}
+	if fi.IsDir() {
+		return filepath.Walk(appPath, func(file string, fi os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			relPath, err := filepath.Rel(appPath, file)
+			if err != nil {
+				return err
+			}
+			if relPath == "." || fi.IsDir() || fi.Mode()&os.ModeSocket != 0 {
+				return nil
+			}
+			if fileFilter != nil && !fileFilter(relPath) {
+				return nil
+			}
+
+			var size int64
+			if fi.Mode().IsRegular() {
+				size = fi.Size()
+			}
+			fn(filepath.ToSlash(relPath), size)
+			return nil
+		})
+	}
+
+	var rc io.ReadCloser
+	if isZip, err := archive.IsZip(appPath); err != nil {
+		return err
+	} else if isZip {
+		rc = archive.ReadZipAsTar(appPath, "/", 0, 0, -1, false, fileFilter)
+	} else {
+		rc = archive.ReadTarAsTar(appPath, "/", 0, 0, -1, false, fileFilter)
+	}
+	defer rc.Close()
+
+	tr := tar.NewReader(rc)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "reading build context")
+		}
+		if header.Typeflag == tar.TypeDir {
+			continue
+		}
+		fn(strings.TrimPrefix(header.Name, "/"), header.Size)
+	}
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildContext(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuildContext", testBuildContext, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildContext(t *testing.T, when spec.G, it spec.S) {
+	var appDir string
+
+	writeFile := func(path, contents string) {
+		path = filepath.Join(appDir, filepath.FromSlash(path))
+		h.AssertNil(t, os.MkdirAll(filepath.Dir(path), 0755))
+		h.AssertNil(t, os.WriteFile(path, []byte(contents), 0600))
+	}
+
+	it.Before(func() {
+		var err error
+		appDir, err = os.MkdirTemp("", "build-context")
+		h.AssertNil(t, err)
+
+		writeFile("main.go", "package main")
+		writeFile("build/out.bin", "binary")
+		writeFile("node_modules/left-pad/index.js", "module.exports = {}")
+		writeFile("test/fixtures/big.json", "{}")
+		writeFile("test/fixtures/keep.json", "{}")
+		writeFile("docs/build/index.md", "# docs")
+		writeFile("debug.log", "log")
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, os.RemoveAll(appDir))
+	})
+
+	when("#ignoreFileFilter", func() {
+		it("excludes the paths matched by .packignore files", func() {
+			writeFile(PackIgnoreFile, "node_modules/\n/build\n# comment\n*.log\ntest/fixtures/*\n!test/fixtures/keep.json\n")
+
+			filter, err := ignoreFileFilter(appDir, false)
+			h.AssertNil(t, err)
+
+			for path, included := range map[string]bool{
+				".":                              true,
+				"main.go":                        true,
+				"node_modules":                   false,
+				"node_modules/left-pad/index.js": false,
+				"build":                          false,
+				"build/out.bin":                  false,
+				"docs/build/index.md":            true,
+				"debug.log":                      false,
+				"test/fixtures/big.json":         false,
+				"test/fixtures/keep.json":        true,
+			} {
+				h.AssertEq(t, filter(filepath.FromSlash(path)), included)
+			}
+		})
+
+		it("applies nested .packignore files relative to their directory", func() {
+			writeFile(PackIgnoreFile, "*.json\n")
+			writeFile("test/"+PackIgnoreFile, "!fixtures/keep.json\n")
+
+			filter, err := ignoreFileFilter(appDir, false)
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, filter(filepath.FromSlash("test/fixtures/big.json")), false)
+			h.AssertEq(t, filter(filepath.FromSlash("test/fixtures/keep.json")), true)
+		})
+
+		it("only reads .gitignore files when asked to", func() {
+			writeFile(".gitignore", "*.log\n")
+
+			filter, err := ignoreFileFilter(appDir, false)
+			h.AssertNil(t, err)
+			h.AssertEq(t, filter == nil, true)
+
+			filter, err = ignoreFileFilter(appDir, true)
+			h.AssertNil(t, err)
+			h.AssertEq(t, filter("debug.log"), false)
+			h.AssertEq(t, filter("main.go"), true)
+		})
+
+		it("returns no filter for archives", func() {
+			tarPath := filepath.Join(appDir, "app.tar")
+			h.AssertNil(t, archive.CreateSingleFileTar(tarPath, PackIgnoreFile, "*"))
+
+			filter, err := ignoreFileFilter(tarPath, false)
+			h.AssertNil(t, err)
+			h.AssertEq(t, filter == nil, true)
+		})
+	})
+
+	when("#combineFileFilters", func() {
+		it("accepts paths accepted by all filters", func() {
+			filter := combineFileFilters(
+				func(path string) bool { return path != "a" },
+				nil,
+				func(path string) bool { return path != "b" },
+			)
+			h.AssertEq(t, filter("a"), false)
+			h.AssertEq(t, filter("b"), false)
+			h.AssertEq(t, filter("c"), true)
+		})
+
+		it("returns nil without filters", func() {
+			h.AssertEq(t, combineFileFilters(nil, nil) == nil, true)
+		})
+	})
+
+	when("#printBuildContext", func() {
+		it("lists the files sent and their total size", func() {
+			writeFile(PackIgnoreFile, "node_modules/\nbuild/\ntest/\ndocs/\n*.log\n")
+			filter, err := ignoreFileFilter(appDir, false)
+			h.AssertNil(t, err)
+
+			var out bytes.Buffer
+			subject := &Client{logger: logging.NewLogWithWriters(&out, &out)}
+			h.AssertNil(t, subject.printBuildContext(appDir, filter))
+
+			h.AssertContains(t, out.String(), "main.go")
+			h.AssertNotContains(t, out.String(), "index.js")
+			h.AssertContains(t, out.String(), "Sending 2 files, 51 B in total")
+		})
+
+		it("lists the files of archives", func() {
+			tarPath := filepath.Join(t.TempDir(), "app.tar")
+			h.AssertNil(t, archive.CreateSingleFileTar(tarPath, "main.go", "package main"))
+
+			var out bytes.Buffer
+			subject := &Client{logger: logging.NewLogWithWriters(&out, &out)}
+			h.AssertNil(t, subject.printBuildContext(tarPath, nil))
+
+			h.AssertContains(t, out.String(), "main.go")
+			h.AssertContains(t, out.String(), "Sending 1 files, 12 B in total")
+		})
+	})
+
+	when("#keptAppSource", func() {
+		it("keeps the app source separately for each daemon", func() {
+			localVolume, localIndex, err := keptAppSource(appDir, "unix:///var/run/docker.sock")
+			h.AssertNil(t, err)
+			remoteVolume, remoteIndex, err := keptAppSource(appDir, "tcp://remote:2376")
+			h.AssertNil(t, err)
+
+			h.AssertNotEq(t, localVolume, remoteVolume)
+			h.AssertNotEq(t, localIndex, remoteIndex)
+
+			sameVolume, sameIndex, err := keptAppSource(appDir, "unix:///var/run/docker.sock")
+			h.AssertNil(t, err)
+			h.AssertEq(t, sameVolume, localVolume)
+			h.AssertEq(t, sameIndex, localIndex)
+		})
+	})
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+var (
+	secretIDRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+	// secretsHostDir is the tmpfs build secrets are written to before being mounted into build containers
+	secretsHostDir = filepath.Join("/", "dev", "shm")
+)
+
+// BuildSecret is a value made available to buildpacks during a build without being written to the image.
+// Exactly one of Src or Env must be set.
+type BuildSecret struct {
+	// ID of the secret, used as its file name within the secrets directory.
+	ID string
+
+	// Path of a file on the host containing the secret.
+	Src string
+
+	// Name of an environment variable on the host containing the secret.
+	Env string
+}
+
+func resolveSecrets(secrets []BuildSecret) ([]build.Secret, error) {
+	var (
+		resolved []build.Secret
+		seen     = map[string]bool{}
+	)
+	for _, secret := range secrets {
+		if !secretIDRegex.MatchString(secret.ID) {
+			return nil, errors.Errorf("invalid secret id %s, must contain only letters, digits, '.', '_' or '-'", style.Symbol(secret.ID))
+		}
+		if seen[secret.ID] {
+			return nil, errors.Errorf("secret %s provided more than once", style.Symbol(secret.ID))
+		}
+		seen[secret.ID] = true
+
+		var (
+			data []byte
+			err  error
+		)
+		switch {
+		case secret.Src != "" && secret.Env != "":
+			return nil, errors.Errorf("secret %s must have either a src or an env source, not both", style.Symbol(secret.ID))
+		case secret.Src != "":
+			if data, err = os.ReadFile(secret.Src); err != nil {
+				return nil, errors.Wrapf(err, "reading secret %s", style.Symbol(secret.ID))
+			}
+		case secret.Env != "":
+			value, ok := os.LookupEnv(secret.Env)
+			if !ok {
+				return nil, errors.Errorf("environment variable %s for secret %s is not set", style.Symbol(secret.Env), style.Symbol(secret.ID))
+			}
+			data = []byte(value)
+		default:
+			return nil, errors.Errorf("secret %s must have a src or an env source", style.Symbol(secret.ID))
+		}
+
+		resolved = append(resolved, build.Secret{ID: secret.ID, Data: data})
+	}
+	return resolved, nil
+}
+
+// secretsTmpfs returns the tmpfs on the Docker host build secrets can be written to. Secrets are only supported by
+// daemons on the local machine, as the tmpfs is bound into the build containers.
+func secretsTmpfs(daemonHost string) (string, error) {
+	if !strings.HasPrefix(daemonHost, "unix://") {
+		return "", errors.Errorf("build secrets require a Docker daemon on the local machine, %s is not local", style.Symbol(daemonHost))
+	}
+	if fi, err := os.Stat(secretsHostDir); err != nil || !fi.IsDir() {
+		return "", errors.Errorf("build secrets require a tmpfs at %s", style.Symbol(secretsHostDir))
+	}
+	return secretsHostDir, nil
+}
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -22,7 +24,10 @@ import (
 	"github.com/buildpacks/imgutil/remote"
 	"github.com/buildpacks/lifecycle/api"
 	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/docker/docker/api/types"
+	dockerNetwork "github.com/docker/docker/api/types/network"
 	dockerclient "github.com/docker/docker/client"
+	"github.com/docker/go-units"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/heroku/color"
 	"github.com/onsi/gomega/ghttp"
@@ -34,6 +39,7 @@ import (
 	"github.com/buildpacks/pack/internal/builder"
 	cfg "github.com/buildpacks/pack/internal/config"
 	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/internal/paths"
 	rg "github.com/buildpacks/pack/internal/registry"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/blob"
@@ -41,7 +47,9 @@ import (
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/project"
 	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+	"github.com/buildpacks/pack/pkg/sbom"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -301,6 +309,8 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 			for fileDesc, appPath := range map[string]string{
 				"zip": filepath.Join("testdata", "zip-file.zip"),
 				"jar": filepath.Join("testdata", "jar-file.jar"),
+				"tar": filepath.Join("testdata", "tar-file.tar"),
+				"tgz": filepath.Join("testdata", "tgz-file.tgz"),
 			} {
 				fileDesc := fileDesc
 				appPath := appPath
@@ -317,8 +327,8 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 
 			for fileDesc, testData := range map[string][]string{
 				"non-existent": {"not/exist/path", "does not exist"},
-				"empty":        {filepath.Join("testdata", "empty-file"), "app path must be a directory or zip"},
-				"non-zip":      {filepath.Join("testdata", "non-zip-file"), "app path must be a directory or zip"},
+				"empty":        {filepath.Join("testdata", "empty-file"), "app path must be a directory, zip or tar"},
+				"non-zip":      {filepath.Join("testdata", "non-zip-file"), "app path must be a directory, zip or tar"},
 			} {
 				fileDesc := fileDesc
 				appPath := testData[0]
@@ -1289,6 +1299,18 @@ api = "0.2"
 				)
 			})
 
+			it("reports every buildpack that could not be fetched", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:      "some/app",
+					Builder:    defaultBuilderName,
+					ClearCache: true,
+					Buildpacks: []string{"missing.bp@version", "other-missing.bp@version"},
+				})
+				h.AssertError(t, err, "error reading missing.bp@version")
+				h.AssertError(t, err, "error reading other-missing.bp@version")
+				h.AssertTrue(t, strings.Index(err.Error(), "missing.bp@version") < strings.Index(err.Error(), "other-missing.bp@version"))
+			})
+
 			when("from project descriptor", func() {
 				when("id - no version is provided", func() {
 					it("resolves version", func() {
@@ -2054,6 +2076,119 @@ api = "0.2"
 			})
 		})
 
+		when("Secrets option", func() {
+			var secretFile string
+
+			it.Before(func() {
+				secretFile = filepath.Join(tmpDir, "some-secret-file")
+				h.AssertNil(t, os.WriteFile(secretFile, []byte("some-file-value"), 0600))
+				h.AssertNil(t, os.Setenv("PACK_TEST_SECRET", "some-env-value"))
+			})
+
+			it.After(func() {
+				h.AssertNil(t, os.Unsetenv("PACK_TEST_SECRET"))
+			})
+
+			it("passes the secret values to the lifecycle", func() {
+				h.SkipIf(t, runtime.GOOS != "linux", "build secrets require a tmpfs at /dev/shm")
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					Secrets: []BuildSecret{
+						{ID: "from-file", Src: secretFile},
+						{ID: "from-env", Env: "PACK_TEST_SECRET"},
+					},
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.Secrets, []build.Secret{
+					{ID: "from-file", Data: []byte("some-file-value")},
+					{ID: "from-env", Data: []byte("some-env-value")},
+				})
+			})
+
+			it("does not set the secrets on the ephemeral builder", func() {
+				h.SkipIf(t, runtime.GOOS != "linux", "build secrets require a tmpfs at /dev/shm")
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					Secrets: []BuildSecret{{ID: "from-env", Env: "PACK_TEST_SECRET"}},
+				}))
+				_, err := defaultBuilderImage.FindLayerWithPath("/platform/env/from-env")
+				h.AssertNotNil(t, err)
+			})
+
+			it("does not use the creator for trusted builders", func() {
+				h.SkipIf(t, runtime.GOOS != "linux", "build secrets require a tmpfs at /dev/shm")
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      defaultBuilderName,
+					TrustBuilder: func(string) bool { return true },
+					Secrets:      []BuildSecret{{ID: "from-file", Src: secretFile}},
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.UseCreator, false)
+				h.AssertEq(t, fakeLifecycle.Opts.LifecycleImage, fakeLifecycleImage.Name())
+			})
+
+			when("the secret is invalid", func() {
+				for _, tc := range []struct {
+					name   string
+					secret BuildSecret
+					err    string
+				}{
+					{"has an invalid id", BuildSecret{ID: "../some-id", Env: "PACK_TEST_SECRET"}, "invalid secret id '../some-id'"},
+					{"has no source", BuildSecret{ID: "some-id"}, "secret 'some-id' must have a src or an env source"},
+					{"has both sources", BuildSecret{ID: "some-id", Src: "some-file", Env: "PACK_TEST_SECRET"}, "secret 'some-id' must have either a src or an env source, not both"},
+					{"references an unset env var", BuildSecret{ID: "some-id", Env: "PACK_TEST_UNSET_SECRET"}, "environment variable 'PACK_TEST_UNSET_SECRET' for secret 'some-id' is not set"},
+					{"references a missing file", BuildSecret{ID: "some-id", Src: "missing-file"}, "reading secret 'some-id'"},
+				} {
+					tc := tc
+					it("errors when it "+tc.name, func() {
+						err := subject.Build(context.TODO(), BuildOptions{
+							Image:   "some/app",
+							Builder: defaultBuilderName,
+							Secrets: []BuildSecret{tc.secret},
+						})
+						h.AssertError(t, err, tc.err)
+					})
+				}
+
+				it("errors when it is provided more than once", func() {
+					err := subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: defaultBuilderName,
+						Secrets: []BuildSecret{{ID: "some-id", Src: secretFile}, {ID: "some-id", Env: "PACK_TEST_SECRET"}},
+					})
+					h.AssertError(t, err, "secret 'some-id' provided more than once")
+				})
+			})
+
+			it("writes the secrets to the tmpfs of the local machine", func() {
+				h.SkipIf(t, runtime.GOOS != "linux", "build secrets require a tmpfs at /dev/shm")
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					Secrets: []BuildSecret{{ID: "from-file", Src: secretFile}},
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.SecretsHostDir, "/dev/shm")
+			})
+
+			it("errors for a remote daemon", func() {
+				remoteDocker, err := dockerclient.NewClientWithOpts(dockerclient.WithHost("tcp://docker.example.com:2376"), dockerclient.WithVersion("1.38"))
+				h.AssertNil(t, err)
+				subject.docker = remoteDocker
+
+				err = subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					Secrets: []BuildSecret{{ID: "from-file", Src: secretFile}},
+				})
+				h.AssertError(t, err, "build secrets require a Docker daemon on the local machine, 'tcp://docker.example.com:2376' is not local")
+			})
+		})
+
 		when("Publish option", func() {
 			var remoteRunImage, builderWithoutLifecycleImageOrCreator *fakes.Image
 
@@ -2081,6 +2216,17 @@ api = "0.2"
 				builderWithoutLifecycleImageOrCreator.Cleanup()
 			})
 
+			when("false", func() {
+				it("does not allow attaching an SBOM", func() {
+					err := subject.Build(context.TODO(), BuildOptions{
+						Image:      "some/app",
+						Builder:    defaultBuilderName,
+						AttachSBOM: sbom.FormatCycloneDX,
+					})
+					h.AssertError(t, err, "attaching an SBOM requires publishing the image")
+				})
+			})
+
 			when("true", func() {
 				it("uses a remote run image", func() {
 					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
@@ -2380,6 +2526,431 @@ api = "0.2"
 			})
 		})
 
+		when("container resource options", func() {
+			it("passes the values through", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					ContainerConfig: ContainerConfig{
+						Memory:      1024,
+						CPUs:        1.5,
+						PidsLimit:   512,
+						Ulimits:     []string{"nofile=1024:2048"},
+						ExtraHosts:  []string{"some-host:127.0.0.1"},
+						DNS:         []string{"8.8.8.8"},
+						SecurityOpt: []string{"no-new-privileges"},
+						CapDrop:     []string{"ALL"},
+					},
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.Resources, build.ContainerResources{
+					Memory:      1024,
+					NanoCPUs:    1500000000,
+					PidsLimit:   512,
+					Ulimits:     []*units.Ulimit{{Name: "nofile", Soft: 1024, Hard: 2048}},
+					SecurityOpt: []string{"no-new-privileges"},
+					CapDrop:     []string{"ALL"},
+				})
+				h.AssertEq(t, fakeLifecycle.Opts.ExtraHosts, []string{"some-host:127.0.0.1"})
+				h.AssertEq(t, fakeLifecycle.Opts.DNS, []string{"8.8.8.8"})
+			})
+
+			it("uses the project descriptor for values that are not provided", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					ContainerConfig: ContainerConfig{
+						CPUs: 2,
+					},
+					ProjectDescriptor: projectTypes.Descriptor{
+						Build: projectTypes.Build{
+							Container: projectTypes.Container{
+								Memory:  "2g",
+								CPUs:    1,
+								CapDrop: []string{"ALL"},
+								DNS:     []string{"1.1.1.1"},
+							},
+						},
+					},
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.Resources.Memory, int64(2*1024*1024*1024))
+				h.AssertEq(t, fakeLifecycle.Opts.Resources.NanoCPUs, int64(2000000000))
+				h.AssertEq(t, fakeLifecycle.Opts.Resources.CapDrop, []string{"ALL"})
+				h.AssertEq(t, fakeLifecycle.Opts.DNS, []string{"1.1.1.1"})
+			})
+
+			it("errors for an invalid ulimit", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					ContainerConfig: ContainerConfig{
+						Ulimits: []string{"nofile"},
+					},
+				})
+				h.AssertError(t, err, "invalid ulimit 'nofile'")
+			})
+
+			it("errors for an invalid memory limit in the project descriptor", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					ProjectDescriptor: projectTypes.Descriptor{
+						Build: projectTypes.Build{
+							Container: projectTypes.Container{Memory: "lots"},
+						},
+					},
+				})
+				h.AssertError(t, err, "parsing memory limit 'lots' in project descriptor")
+			})
+		})
+
+		when("egress policy", func() {
+			var egressDocker *egressDockerClient
+
+			it.Before(func() {
+				egressDocker = &egressDockerClient{DockerClient: subject.docker, gateway: "127.0.0.1", networks: map[string]types.NetworkCreate{}}
+				subject.docker = egressDocker
+			})
+
+			it("does not restrict egress by default", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.EgressProxy, "")
+				h.AssertEq(t, fakeLifecycle.Opts.EgressNetwork, "")
+				h.AssertEq(t, len(egressDocker.created), 0)
+			})
+
+			it("routes the build containers through the egress proxy on an internal network", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      defaultBuilderName,
+					TrustBuilder: func(string) bool { return true },
+					ProxyConfig:  &ProxyConfig{HTTPProxy: "http://some-proxy", NoProxy: "some-host"},
+					EgressPolicy: &EgressPolicy{Restrict: true, Allow: []string{"example.com"}},
+				}))
+				h.AssertContains(t, fakeLifecycle.Opts.EgressProxy, "@127.0.0.1:")
+				h.AssertEq(t, fakeLifecycle.Opts.HTTPProxy, "http://some-proxy")
+				h.AssertEq(t, fakeLifecycle.Opts.NoProxy, "some-host")
+				h.AssertEq(t, fakeLifecycle.Opts.UseCreator, false)
+				h.AssertEq(t, len(fakeLifecycle.Opts.ExtraHosts), 0)
+
+				h.AssertEq(t, len(egressDocker.created), 1)
+				network := egressDocker.created[0]
+				h.AssertEq(t, fakeLifecycle.Opts.EgressNetwork, network)
+				h.AssertEq(t, egressDocker.networks[network].Internal, true)
+				h.AssertEq(t, egressDocker.removed, []string{network})
+			})
+
+			it("errors when the daemon is not local", func() {
+				egressDocker.host = "tcp://docker.example.com:2376"
+
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      defaultBuilderName,
+					EgressPolicy: &EgressPolicy{Restrict: true, Allow: []string{"example.com"}},
+				})
+				h.AssertError(t, err, "egress policies require a Docker daemon on the local machine, 'tcp://docker.example.com:2376' is not local")
+				h.AssertEq(t, len(egressDocker.created), 0)
+			})
+
+			it("uses the policy in the project descriptor", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					ProjectDescriptor: projectTypes.Descriptor{
+						Build: projectTypes.Build{
+							Egress: projectTypes.Egress{Policy: "allowlist", Allow: []string{"example.com"}},
+						},
+					},
+				}))
+				h.AssertContains(t, fakeLifecycle.Opts.EgressProxy, "@127.0.0.1:")
+			})
+
+			it("lets the options override the policy in the project descriptor", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      defaultBuilderName,
+					EgressPolicy: &EgressPolicy{Restrict: false},
+					ProjectDescriptor: projectTypes.Descriptor{
+						Build: projectTypes.Build{
+							Egress: projectTypes.Egress{Policy: "allowlist"},
+						},
+					},
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.EgressProxy, "")
+			})
+
+			it("errors for an invalid policy in the project descriptor", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					ProjectDescriptor: projectTypes.Descriptor{
+						Build: projectTypes.Build{
+							Egress: projectTypes.Egress{Policy: "deny"},
+						},
+					},
+				})
+				h.AssertError(t, err, "invalid egress policy 'deny' in project descriptor")
+			})
+
+			when("a build container contacts hosts", func() {
+				var reportDir string
+
+				it.Before(func() {
+					var err error
+					reportDir, err = os.MkdirTemp(tmpDir, "report")
+					h.AssertNil(t, err)
+				})
+
+				it("records the contacted hosts", func() {
+					subject.lifecycleExecutor = &egressLifecycle{targets: []string{"http://127.0.0.1:1/"}}
+
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:                "some/app",
+						Builder:              defaultBuilderName,
+						ReportDestinationDir: reportDir,
+						EgressPolicy:         &EgressPolicy{Restrict: true, Allow: []string{"127.0.0.1"}},
+					}))
+					h.AssertContains(t, outBuf.String(), "Hosts contacted during build: 127.0.0.1")
+
+					contents, err := os.ReadFile(filepath.Join(reportDir, "egress.toml"))
+					h.AssertNil(t, err)
+					h.AssertContains(t, string(contents), `contacted = ["127.0.0.1"]`)
+				})
+
+				it("forwards requests through the proxy of the build", func() {
+					var proxied string
+					upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						proxied = r.URL.String()
+					}))
+					defer upstream.Close()
+					subject.lifecycleExecutor = &egressLifecycle{targets: []string{"http://example.com/"}}
+
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:        "some/app",
+						Builder:      defaultBuilderName,
+						ProxyConfig:  &ProxyConfig{HTTPProxy: upstream.URL},
+						EgressPolicy: &EgressPolicy{Restrict: true, Allow: []string{"example.com"}},
+					}))
+					h.AssertEq(t, proxied, "http://example.com/")
+				})
+
+				it("fails the build when a host is blocked", func() {
+					subject.lifecycleExecutor = &egressLifecycle{targets: []string{"http://blocked.example.com/"}}
+
+					err := subject.Build(context.TODO(), BuildOptions{
+						Image:                "some/app",
+						Builder:              defaultBuilderName,
+						ReportDestinationDir: reportDir,
+						EgressPolicy:         &EgressPolicy{Restrict: true, Allow: []string{"example.com"}},
+					})
+					h.AssertError(t, err, "build attempted to contact hosts not allowed by the egress policy: blocked.example.com")
+
+					contents, err := os.ReadFile(filepath.Join(reportDir, "egress.toml"))
+					h.AssertNil(t, err)
+					h.AssertContains(t, string(contents), `blocked = ["blocked.example.com"]`)
+				})
+			})
+		})
+
+		when("TrustPolicy option", func() {
+			it("runs the creator for builders trusted by the policy and records the decision", func() {
+				reportDir := filepath.Join(tmpDir, "trust-report")
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:                "some/app",
+					Builder:              defaultBuilderName,
+					ReportDestinationDir: reportDir,
+					TrustPolicy: &TrustPolicy{Rules: []TrustRule{
+						{Source: "some-rule", Pattern: "example.com/default/*"},
+					}},
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.UseCreator, true)
+				h.AssertEq(t, fakeLifecycle.Opts.TrustBuilder, true)
+				h.AssertContains(t, outBuf.String(), "Builder 'example.com/default/builder:tag' is trusted: matches some-rule")
+
+				contents, err := os.ReadFile(filepath.Join(reportDir, "trust.toml"))
+				h.AssertNil(t, err)
+				h.AssertContains(t, string(contents), `scope = "builder"`)
+				h.AssertContains(t, string(contents), `reason = "matches some-rule"`)
+			})
+
+			it("uses the lifecycle image when only the buildpacks are trusted", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      defaultBuilderName,
+					TrustBuilder: func(string) bool { return true },
+					TrustPolicy: &TrustPolicy{Rules: []TrustRule{
+						{Source: "some-rule", Pattern: defaultBuilderName, Scope: TrustScopeBuildpacks},
+					}},
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.UseCreator, false)
+				h.AssertEq(t, fakeLifecycle.Opts.TrustBuilder, false)
+				h.AssertEq(t, fakeLifecycle.Opts.LifecycleImage, fakeLifecycleImage.Name())
+				h.AssertContains(t, outBuf.String(), "Buildpacks of builder 'example.com/default/builder:tag' are trusted, but its lifecycle is not")
+			})
+
+			it("does not trust builders no rule matches", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      defaultBuilderName,
+					TrustBuilder: func(string) bool { return true },
+					TrustPolicy: &TrustPolicy{Rules: []TrustRule{
+						{Source: "some-rule", Pattern: "example.com/other/*"},
+					}},
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.UseCreator, false)
+				h.AssertContains(t, outBuf.String(), "Builder 'example.com/default/builder:tag' is untrusted: no trust rule matches the builder")
+			})
+
+			it("pins the builder to the trusted digest", func() {
+				digest := "sha256:" + strings.Repeat("b", 64)
+				pinnedBuilder := newFakeBuilderImage(t, tmpDir, "example.com/default/builder@"+digest, defaultBuilderStackID, defaultRunImageName, builder.DefaultLifecycleVersion, newLinuxImage)
+				h.AssertNil(t, pinnedBuilder.SetLabel("io.buildpacks.stack.mixins", `["mixinA", "build:mixinB", "mixinX", "build:mixinY"]`))
+				fakeImageFetcher.LocalImages[pinnedBuilder.Name()] = pinnedBuilder
+				defer pinnedBuilder.Cleanup()
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					Lock: &project.Lock{
+						Builder:  project.LockedImage{Name: defaultBuilderName, Digest: digest},
+						RunImage: project.LockedImage{Name: defaultRunImageName},
+					},
+					TrustPolicy: &TrustPolicy{Rules: []TrustRule{
+						{Source: "some-rule", Pattern: defaultBuilderName, Digests: []string{digest}},
+					}},
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.Builder.Name(), pinnedBuilder.Name())
+				h.AssertEq(t, fakeLifecycle.Opts.UseCreator, true)
+				h.AssertContains(t, outBuf.String(), "is pinned to digest "+digest)
+			})
+		})
+
+		when("Lock option", func() {
+			var (
+				lockedDigest  = "sha256:" + strings.Repeat("a", 64)
+				lockedBuilder *fakes.Image
+				lockedRun     *fakes.Image
+			)
+
+			it.Before(func() {
+				lockedBuilder = newFakeBuilderImage(t, tmpDir, "example.com/default/builder@"+lockedDigest, defaultBuilderStackID, defaultRunImageName, builder.DefaultLifecycleVersion, newLinuxImage)
+				h.AssertNil(t, lockedBuilder.SetLabel("io.buildpacks.stack.mixins", `["mixinA", "build:mixinB", "mixinX", "build:mixinY"]`))
+				fakeImageFetcher.LocalImages[lockedBuilder.Name()] = lockedBuilder
+
+				lockedRun = newLinuxImage("default/run@"+lockedDigest, "", nil)
+				h.AssertNil(t, lockedRun.SetLabel("io.buildpacks.stack.id", defaultBuilderStackID))
+				h.AssertNil(t, lockedRun.SetLabel("io.buildpacks.stack.mixins", `["mixinA", "run:mixinC", "mixinX", "run:mixinZ"]`))
+				fakeImageFetcher.LocalImages[lockedRun.Name()] = lockedRun
+			})
+
+			it.After(func() {
+				h.AssertNilE(t, lockedBuilder.Cleanup())
+				h.AssertNilE(t, lockedRun.Cleanup())
+			})
+
+			it("uses the locked builder and run image", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					Lock: &project.Lock{
+						Builder:  project.LockedImage{Name: defaultBuilderName, Digest: lockedDigest},
+						RunImage: project.LockedImage{Name: defaultRunImageName, Digest: lockedDigest},
+					},
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.Builder.Name(), lockedBuilder.Name())
+				h.AssertEq(t, fakeLifecycle.Opts.RunImage, "default/run@"+lockedDigest)
+			})
+
+			it("evaluates trust rules against the requested builder name", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					Lock: &project.Lock{
+						Builder:  project.LockedImage{Name: defaultBuilderName, Digest: lockedDigest},
+						RunImage: project.LockedImage{Name: defaultRunImageName, Digest: lockedDigest},
+					},
+					TrustPolicy: &TrustPolicy{Rules: []TrustRule{
+						{Source: "some-rule", Pattern: defaultBuilderName},
+					}},
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.Builder.Name(), lockedBuilder.Name())
+				h.AssertEq(t, fakeLifecycle.Opts.TrustBuilder, true)
+			})
+
+			it("warns when the requested images are not locked", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					Lock: &project.Lock{
+						Builder:  project.LockedImage{Name: "some/other-builder", Digest: lockedDigest},
+						RunImage: project.LockedImage{Name: "some/other-run", Digest: lockedDigest},
+					},
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.Builder.Name(), defaultBuilderImage.Name())
+				h.AssertEq(t, fakeLifecycle.Opts.RunImage, defaultRunImageName)
+				h.AssertContains(t, outBuf.String(), "Warning: The builder 'example.com/default/builder:tag' is not locked in project.lock")
+				h.AssertContains(t, outBuf.String(), "Warning: The run image 'default/run' is not locked in project.lock")
+			})
+
+			when("buildpacks are locked", func() {
+				var buildpackTgz string
+
+				it.Before(func() {
+					buildpackTgz = h.CreateTGZ(t, filepath.Join("testdata", "buildpack2"), "./", 0755)
+				})
+
+				it.After(func() {
+					h.AssertNilE(t, os.Remove(buildpackTgz))
+				})
+
+				it("verifies the checksum of buildpacks downloaded from a URI", func() {
+					uri, err := paths.FilePathToURI(buildpackTgz, "")
+					h.AssertNil(t, err)
+					checksum, err := subject.uriChecksum(context.TODO(), uri)
+					h.AssertNil(t, err)
+
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:      "some/app",
+						Builder:    defaultBuilderName,
+						Buildpacks: []string{buildpackTgz},
+						Lock: &project.Lock{
+							Builder:    project.LockedImage{Name: defaultBuilderName, Digest: lockedDigest},
+							RunImage:   project.LockedImage{Name: defaultRunImageName, Digest: lockedDigest},
+							Buildpacks: []project.LockedBuildpack{{Locator: buildpackTgz, URI: uri, SHA256: checksum}},
+						},
+					}))
+
+					err = subject.Build(context.TODO(), BuildOptions{
+						Image:      "some/app",
+						Builder:    defaultBuilderName,
+						Buildpacks: []string{buildpackTgz},
+						Lock: &project.Lock{
+							Builder:    project.LockedImage{Name: defaultBuilderName, Digest: lockedDigest},
+							RunImage:   project.LockedImage{Name: defaultRunImageName, Digest: lockedDigest},
+							Buildpacks: []project.LockedBuildpack{{Locator: buildpackTgz, URI: uri, SHA256: "sha256:" + strings.Repeat("0", 64)}},
+						},
+					})
+					h.AssertError(t, err, "does not match project.lock")
+				})
+
+				it("warns about buildpacks that are not locked", func() {
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:      "some/app",
+						Builder:    defaultBuilderName,
+						Buildpacks: []string{buildpackTgz, filepath.Join("testdata", "buildpack")},
+						Lock: &project.Lock{
+							Builder:  project.LockedImage{Name: defaultBuilderName, Digest: lockedDigest},
+							RunImage: project.LockedImage{Name: defaultRunImageName, Digest: lockedDigest},
+						},
+					}))
+					h.AssertContains(t, outBuf.String(), fmt.Sprintf("Warning: Buildpack '%s' is not locked in project.lock", buildpackTgz))
+					h.AssertNotContains(t, outBuf.String(), "testdata/buildpack' is not locked")
+				})
+			})
+		})
+
 		when("Lifecycle option", func() {
 			when("Platform API", func() {
 				for _, supportedPlatformAPI := range []string{"0.3", "0.4"} {
@@ -3367,6 +3938,62 @@ func setAPIs(t *testing.T, image *fakes.Image, buildpackAPIs []string, platformA
 	h.AssertNil(t, image.SetLabel(builderMDLabelName, string(builderMDLabelBytes)))
 }
 
+// egressLifecycle requests targets through the proxy configured for the build containers.
+type egressLifecycle struct {
+	targets []string
+}
+
+func (f *egressLifecycle) Execute(_ context.Context, opts build.LifecycleOptions) error {
+	proxyURL, err := url.Parse(opts.EgressProxy)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}
+	for _, target := range f.targets {
+		if resp, err := client.Get(target); err == nil {
+			resp.Body.Close()
+		}
+	}
+	return nil
+}
+
+// egressDockerClient records the networks created for egress policies, whose gateway is gateway, and reports host as
+// the daemon host if set.
+type egressDockerClient struct {
+	DockerClient
+	host     string
+	gateway  string
+	networks map[string]types.NetworkCreate
+	created  []string
+	removed  []string
+}
+
+func (c *egressDockerClient) DaemonHost() string {
+	if c.host != "" {
+		return c.host
+	}
+	return "unix:///var/run/docker.sock"
+}
+
+func (c *egressDockerClient) NetworkCreate(_ context.Context, name string, options types.NetworkCreate) (types.NetworkCreateResponse, error) {
+	c.networks[name] = options
+	c.created = append(c.created, name)
+	return types.NetworkCreateResponse{ID: name}, nil
+}
+
+func (c *egressDockerClient) NetworkInspect(_ context.Context, name string, _ types.NetworkInspectOptions) (types.NetworkResource, error) {
+	return types.NetworkResource{
+		Name: name,
+		IPAM: dockerNetwork.IPAM{Config: []dockerNetwork.IPAMConfig{{Subnet: "fd00::/64", Gateway: "fd00::1"}, {Subnet: "127.0.0.0/8", Gateway: c.gateway}}},
+	}, nil
+}
+
+func (c *egressDockerClient) NetworkRemove(_ context.Context, name string) error {
+	c.removed = append(c.removed, name)
+	return nil
+}
+
 type executeFailsLifecycle struct { //nolint
 	Opts build.LifecycleOptions
 }
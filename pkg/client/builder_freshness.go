@@ -0,0 +1,218 @@
+package client
+
+import (
+	"context"
+
+	"github.com/Masterminds/semver"
+	"github.com/google/go-containerregistry/pkg/name"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// FreshnessStatus describes whether a part of a builder is the newest one known
+type FreshnessStatus string
+
+const (
+	// FreshnessCurrent means no newer version is known.
+	FreshnessCurrent FreshnessStatus = "current"
+
+	// FreshnessOutdated means a newer version is available.
+	FreshnessOutdated FreshnessStatus = "outdated"
+
+	// FreshnessYanked means the version was yanked from the buildpack registry and should no longer be used.
+	FreshnessYanked FreshnessStatus = "yanked"
+
+	// FreshnessUnknown means the newest version could not be determined, e.g. because the buildpack is not in any of
+	// the registries or the image is not in a registry.
+	FreshnessUnknown FreshnessStatus = "unknown"
+)
+
+// BuilderFreshness reports how up to date the images, lifecycle and buildpacks of a builder are.
+type BuilderFreshness struct {
+	// Builder image, which is also the build image.
+	Builder ImageFreshness
+
+	// Default run images of the builder.
+	RunImages []ImageFreshness
+
+	Lifecycle  LifecycleFreshness
+	Buildpacks []BuildpackFreshness
+}
+
+// ImageFreshness compares the digest of an image in use with the digest its tag currently points to in its registry.
+type ImageFreshness struct {
+	Name string
+
+	// Digest of the image in use. For images in the daemon, this is the digest the image was pulled with.
+	Digest string
+
+	// LatestDigest the tag points to in the registry.
+	LatestDigest string
+
+	Status FreshnessStatus
+}
+
+// LifecycleFreshness compares the lifecycle version of a builder with the latest version known to pack.
+type LifecycleFreshness struct {
+	Version       string
+	LatestVersion string
+	Status        FreshnessStatus
+}
+
+// BuildpackFreshness compares the version of a buildpack with the versions published to the buildpack registries.
+type BuildpackFreshness struct {
+	ID            string
+	Version       string
+	LatestVersion string
+
+	// Registry the buildpack was found in.
+	Registry string
+
+	Status FreshnessStatus
+}
+
+// builderFreshness checks the images, lifecycle and buildpacks of the builder described by info for newer versions.
+// Buildpacks are looked up in the given registries, in order.
+func (c *Client) builderFreshness(ctx context.Context, builderName string, daemon bool, info builder.Info, registryNames []string) *BuilderFreshness {
+	freshness := &BuilderFreshness{
+		Builder:   c.imageFreshness(ctx, builderName, daemon),
+		Lifecycle: lifecycleFreshness(info.Lifecycle.Info.Version),
+	}
+
+	for _, runImage := range info.RunImages {
+		// run images are taken from the daemon when building, regardless of where the builder is
+		freshness.RunImages = append(freshness.RunImages, c.imageFreshness(ctx, runImage.Image, true))
+	}
+
+	published := c.publishedBuildpacks(ctx, registryNames)
+	for _, bp := range info.Buildpacks {
+		freshness.Buildpacks = append(freshness.Buildpacks, buildpackFreshness(bp.ID, bp.Version, published[bp.ID]))
+	}
+
+	return freshness
+}
+
+// imageFreshness compares the digest of imageName in use with the digest its tag points to. If daemon is false, or
+// the image is not in the daemon, the image in the registry is the one in use.
+func (c *Client) imageFreshness(ctx context.Context, imageName string, daemon bool) ImageFreshness {
+	freshness := ImageFreshness{Name: imageName, Status: FreshnessUnknown}
+
+	ref, err := name.ParseReference(imageName, name.WeakValidation)
+	if err != nil {
+		c.logger.Debugf("Unable to parse image name %s: %s", style.Symbol(imageName), err)
+		return freshness
+	}
+
+	if daemon {
+		freshness.Digest, _ = c.localImageDigest(ctx, ref)
+	}
+
+	latest, err := c.remoteImageDigest(ctx, ref)
+	if err != nil {
+		c.logger.Debugf("Unable to find image %s in its registry: %s", style.Symbol(imageName), err)
+		return freshness
+	}
+	freshness.LatestDigest = latest
+	if freshness.Digest == "" {
+		freshness.Digest = latest
+	}
+
+	freshness.Status = FreshnessCurrent
+	if freshness.Digest != freshness.LatestDigest {
+		freshness.Status = FreshnessOutdated
+	}
+	return freshness
+}
+
+func lifecycleFreshness(version *builder.Version) LifecycleFreshness {
+	latest := builder.VersionMustParse(builder.DefaultLifecycleVersion)
+	freshness := LifecycleFreshness{LatestVersion: latest.String(), Status: FreshnessUnknown}
+	if version == nil {
+		return freshness
+	}
+
+	freshness.Version = version.String()
+	freshness.Status = FreshnessCurrent
+	if version.LessThan(&latest.Version) {
+		freshness.Status = FreshnessOutdated
+	}
+	return freshness
+}
+
+// publishedBuildpack is a version of a buildpack in a buildpack registry
+type publishedBuildpack struct {
+	registry string
+	registry.Buildpack
+}
+
+// publishedBuildpacks returns every version of every buildpack in the given registries by buildpack ID. Each ID is
+// taken from the first registry it is found in.
+func (c *Client) publishedBuildpacks(ctx context.Context, registryNames []string) map[string][]publishedBuildpack {
+	published := map[string][]publishedBuildpack{}
+	for _, registryName := range registryNames {
+		index, err := getRegistryIndex(ctx, c.logger, c.keychain, registryName)
+		if err != nil {
+			c.logger.Warnf("Unable to check registry %s: %s", style.Symbol(registryName), err)
+			continue
+		}
+
+		buildpacks, err := index.Buildpacks()
+		if err != nil {
+			c.logger.Warnf("Unable to check registry %s: %s", style.Symbol(registryName), err)
+			continue
+		}
+
+		found := map[string][]publishedBuildpack{}
+		for _, bp := range buildpacks {
+			id := bp.Namespace + "/" + bp.Name
+			found[id] = append(found[id], publishedBuildpack{registry: registryName, Buildpack: bp})
+		}
+		for id, versions := range found {
+			if _, ok := published[id]; !ok {
+				published[id] = versions
+			}
+		}
+	}
+	return published
+}
+
+func buildpackFreshness(id, version string, published []publishedBuildpack) BuildpackFreshness {
+	freshness := BuildpackFreshness{ID: id, Version: version, Status: FreshnessUnknown}
+	if len(published) == 0 {
+		return freshness
+	}
+	freshness.Registry = published[0].registry
+
+	var latest *semver.Version
+	for _, bp := range published {
+		if bp.Version == version && bp.Yanked {
+			freshness.Status = FreshnessYanked
+		}
+
+		v, err := semver.NewVersion(bp.Version)
+		if err != nil || bp.Yanked {
+			continue
+		}
+		if latest == nil || v.GreaterThan(latest) {
+			latest = v
+			freshness.LatestVersion = bp.Version
+		}
+	}
+
+	if freshness.Status == FreshnessYanked || latest == nil {
+		return freshness
+	}
+
+	current, err := semver.NewVersion(version)
+	if err != nil {
+		return freshness
+	}
+
+	freshness.Status = FreshnessCurrent
+	if current.LessThan(latest) {
+		freshness.Status = FreshnessOutdated
+	}
+	return freshness
+}
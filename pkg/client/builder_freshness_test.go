@@ -0,0 +1,207 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuilderFreshness(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuilderFreshness", testBuilderFreshness, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuilderFreshness(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockController   *gomock.Controller
+		mockDockerClient *testmocks.MockCommonAPIClient
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		server           *h.RegistryIndexServer
+		tmpDir           string
+		builderName      string
+		runImageName     string
+		out              bytes.Buffer
+	)
+
+	push := func(imageName string) string {
+		ref, err := name.ParseReference(imageName)
+		h.AssertNil(t, err)
+		img, err := random.Image(1024, 1)
+		h.AssertNil(t, err)
+		h.AssertNil(t, remote.Write(ref, img))
+		digest, err := img.Digest()
+		h.AssertNil(t, err)
+		return digest.String()
+	}
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "builder-freshness")
+		h.AssertNil(t, err)
+
+		server = h.NewRegistryIndexServer("", "")
+		builderName = server.Host() + "/some/builder:latest"
+		runImageName = server.Host() + "/some/run:latest"
+
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		fakeImageFetcher.LocalImages[builderName] = newFakeBuilderImage(t, tmpDir, builderName, "some.stack.id", runImageName, "0.17.0", newLinuxImage)
+		fakeImageFetcher.RemoteImages[builderName] = newFakeBuilderImage(t, tmpDir, builderName, "some.stack.id", runImageName, "0.17.0", newLinuxImage)
+
+		mockController = gomock.NewController(t)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: fakeImageFetcher,
+			docker:       mockDockerClient,
+			keychain:     authn.DefaultKeychain,
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		server.Close()
+		h.AssertNilE(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#InspectBuilder", func() {
+		when("WithFreshness", func() {
+			var (
+				builderDigest  string
+				runImageDigest string
+			)
+
+			it.Before(func() {
+				builderDigest = push(builderName)
+				runImageDigest = push(runImageName)
+
+				mockDockerClient.EXPECT().
+					ImageInspectWithRaw(gomock.Any(), runImageName).
+					Return(types.ImageInspect{RepoDigests: []string{server.Host() + "/some/run@" + runImageDigest}}, nil, nil).
+					AnyTimes()
+			})
+
+			it("reports an outdated builder in the daemon", func() {
+				mockDockerClient.EXPECT().
+					ImageInspectWithRaw(gomock.Any(), builderName).
+					Return(types.ImageInspect{RepoDigests: []string{server.Host() + "/some/builder@sha256:1111111111111111111111111111111111111111111111111111111111111111"}}, nil, nil)
+
+				info, err := subject.InspectBuilder(builderName, true, WithFreshness(nil))
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, info.Freshness.Builder, ImageFreshness{
+					Name:         builderName,
+					Digest:       "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+					LatestDigest: builderDigest,
+					Status:       FreshnessOutdated,
+				})
+				h.AssertEq(t, info.Freshness.RunImages, []ImageFreshness{{
+					Name:         runImageName,
+					Digest:       runImageDigest,
+					LatestDigest: runImageDigest,
+					Status:       FreshnessCurrent,
+				}})
+				h.AssertEq(t, info.Freshness.Lifecycle, LifecycleFreshness{
+					Version:       "0.17.0",
+					LatestVersion: "0.18.5",
+					Status:        FreshnessOutdated,
+				})
+				h.AssertEq(t, info.Freshness.Buildpacks[0], BuildpackFreshness{
+					ID:      "buildpack.1.id",
+					Version: "buildpack.1.version",
+					Status:  FreshnessUnknown,
+				})
+			})
+
+			it("reports the builder in the registry as current", func() {
+				info, err := subject.InspectBuilder(builderName, false, WithFreshness(nil))
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, info.Freshness.Builder.Digest, builderDigest)
+				h.AssertEq(t, info.Freshness.Builder.Status, FreshnessCurrent)
+			})
+
+			it("reports images that are not in a registry as unknown", func() {
+				mockDockerClient.EXPECT().
+					ImageInspectWithRaw(gomock.Any(), "some/local-run").
+					Return(types.ImageInspect{}, nil, errors.New("no such image"))
+
+				freshness := subject.imageFreshness(context.TODO(), "some/local-run", true)
+				h.AssertEq(t, freshness.Status, FreshnessUnknown)
+			})
+		})
+
+		it("does not check freshness by default", func() {
+			info, err := subject.InspectBuilder(builderName, false)
+			h.AssertNil(t, err)
+			h.AssertNil(t, info.Freshness)
+		})
+	})
+
+	when("#buildpackFreshness", func() {
+		published := func(versions ...registry.Buildpack) []publishedBuildpack {
+			var bps []publishedBuildpack
+			for _, bp := range versions {
+				bps = append(bps, publishedBuildpack{registry: "some-registry", Buildpack: bp})
+			}
+			return bps
+		}
+
+		it("is outdated if a newer version was published", func() {
+			freshness := buildpackFreshness("example/foo", "1.1.0", published(
+				registry.Buildpack{Version: "1.1.0"},
+				registry.Buildpack{Version: "1.10.0"},
+				registry.Buildpack{Version: "2.0.0", Yanked: true},
+			))
+			h.AssertEq(t, freshness, BuildpackFreshness{
+				ID:            "example/foo",
+				Version:       "1.1.0",
+				LatestVersion: "1.10.0",
+				Registry:      "some-registry",
+				Status:        FreshnessOutdated,
+			})
+		})
+
+		it("is current if it is the newest version", func() {
+			freshness := buildpackFreshness("example/foo", "1.10.0", published(
+				registry.Buildpack{Version: "1.1.0"},
+				registry.Buildpack{Version: "1.10.0"},
+			))
+			h.AssertEq(t, freshness.Status, FreshnessCurrent)
+		})
+
+		it("is yanked if the version was yanked", func() {
+			freshness := buildpackFreshness("example/foo", "1.1.0", published(
+				registry.Buildpack{Version: "1.0.0"},
+				registry.Buildpack{Version: "1.1.0", Yanked: true},
+			))
+			h.AssertEq(t, freshness.Status, FreshnessYanked)
+			h.AssertEq(t, freshness.LatestVersion, "1.0.0")
+		})
+
+		it("is unknown if the buildpack is not in a registry", func() {
+			freshness := buildpackFreshness("example/foo", "1.1.0", nil)
+			h.AssertEq(t, freshness.Status, FreshnessUnknown)
+		})
+	})
+}
@@ -0,0 +1,163 @@
+package client
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
+)
+
+func validateBuilderFormat(opts CreateBuilderOptions) error {
+	switch opts.Format {
+	case FormatImage:
+		if opts.OutputPath != "" {
+			return errors.Errorf("an output path can only be used to save a builder as %s, %s or %s",
+				style.Symbol(FormatOCILayout), style.Symbol(FormatOCIArchive), style.Symbol(FormatDockerArchive))
+		}
+		return nil
+	case FormatOCILayout, FormatOCIArchive, FormatDockerArchive:
+		if opts.Publish {
+			return errors.Errorf("cannot publish a builder saved as %s", style.Symbol(opts.Format))
+		}
+		if opts.OutputPath == "" {
+			return errors.Errorf("an output path is required to save a builder as %s", style.Symbol(opts.Format))
+		}
+		return nil
+	default:
+		return errors.Errorf("unknown format: %s", style.Symbol(opts.Format))
+	}
+}
+
+// builderLayoutDir returns the directory the builder is created in as an OCI layout, or an empty string if the
+// builder is created as an image. Archives are created from a layout in a temporary directory.
+func builderLayoutDir(opts CreateBuilderOptions) (string, error) {
+	switch opts.Format {
+	case FormatOCILayout:
+		return opts.OutputPath, nil
+	case FormatOCIArchive, FormatDockerArchive:
+		tmpDir, err := os.MkdirTemp("", "builder-layout")
+		if err != nil {
+			return "", errors.Wrap(err, "creating temp directory")
+		}
+		return filepath.Join(tmpDir, "oci-layout"), nil
+	default:
+		return "", nil
+	}
+}
+
+// exportBuilder writes the builder saved as an OCI layout in layoutDir to the output path as an archive, if the
+// format asks for one
+func exportBuilder(opts CreateBuilderOptions, layoutDir string) error {
+	switch opts.Format {
+	case FormatOCIArchive:
+		return errors.Wrap(writeOCIArchive(layoutDir, opts.OutputPath), "writing OCI archive")
+	case FormatDockerArchive:
+		return errors.Wrap(writeDockerArchive(layoutDir, opts.OutputPath, opts.BuilderName), "writing docker archive")
+	default:
+		return nil
+	}
+}
+
+func writeOCIArchive(layoutDir, outputPath string) error {
+	outputFile, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	defer outputFile.Close()
+
+	tw := tar.NewWriter(outputFile)
+	defer tw.Close()
+
+	return archive.WriteDirToTar(tw, layoutDir, "", 0, 0, -1, true, false, nil)
+}
+
+func writeDockerArchive(layoutDir, outputPath, imageName string) error {
+	ref, err := name.NewTag(imageName, name.WeakValidation)
+	if err != nil {
+		return errors.Wrapf(err, "invalid image name %s", style.Symbol(imageName))
+	}
+
+	img, err := layoutImage(layoutDir)
+	if err != nil {
+		return err
+	}
+
+	return tarball.WriteToFile(outputPath, ref, img)
+}
+
+// layoutImage returns the first image of the OCI layout in layoutDir
+func layoutImage(layoutDir string) (v1.Image, error) {
+	layoutPath, err := layout.FromPath(layoutDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading OCI layout %s", style.Symbol(layoutDir))
+	}
+
+	index, err := layoutPath.ImageIndex()
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading OCI layout %s", style.Symbol(layoutDir))
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading OCI layout %s", style.Symbol(layoutDir))
+	}
+	if len(manifest.Manifests) == 0 {
+		return nil, errors.Errorf("OCI layout %s has no images", style.Symbol(layoutDir))
+	}
+
+	return index.Image(manifest.Manifests[0].Digest)
+}
+
+// loadLayoutBuilder loads the builder in the OCI layout at layoutDir into the daemon, and returns the name it was loaded
+// as. The name is derived from the digest of the builder, so the same builder is only loaded once.
+func (c *Client) loadLayoutBuilder(ctx context.Context, layoutDir string) (string, error) {
+	img, err := layoutImage(layoutDir)
+	if err != nil {
+		return "", err
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return "", errors.Wrapf(err, "getting digest of builder in %s", style.Symbol(layoutDir))
+	}
+
+	imageName := fmt.Sprintf("pack.local/layout-builder/%s:latest", digest.Hex)
+	if _, _, err := c.docker.ImageInspectWithRaw(ctx, imageName); err == nil {
+		c.logger.Debugf("Builder in %s is already loaded as %s", style.Symbol(layoutDir), style.Symbol(imageName))
+		return imageName, nil
+	}
+
+	ref, err := name.NewTag(imageName)
+	if err != nil {
+		return "", err
+	}
+
+	c.logger.Debugf("Loading builder in %s as %s", style.Symbol(layoutDir), style.Symbol(imageName))
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(tarball.Write(ref, img, pw))
+	}()
+
+	resp, err := c.docker.ImageLoad(ctx, pr, true)
+	if err != nil {
+		pr.CloseWithError(err)
+		return "", errors.Wrapf(err, "loading builder in %s", style.Symbol(layoutDir))
+	}
+	defer resp.Body.Close()
+
+	if err := jsonmessage.DisplayJSONMessagesStream(resp.Body, io.Discard, 0, false, nil); err != nil {
+		return "", errors.Wrapf(err, "loading builder in %s", style.Symbol(layoutDir))
+	}
+
+	return imageName, nil
+}
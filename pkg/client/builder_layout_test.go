@@ -0,0 +1,225 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuilderLayout(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuilderLayout", testBuilderLayout, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuilderLayout(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockController   *gomock.Controller
+		mockDockerClient *testmocks.MockCommonAPIClient
+		tmpDir           string
+		layoutDir        string
+		img              v1.Image
+		out              bytes.Buffer
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "builder-layout")
+		h.AssertNil(t, err)
+
+		img, err = random.Image(1024, 1)
+		h.AssertNil(t, err)
+		layoutDir = filepath.Join(tmpDir, "layout")
+		layoutPath, err := layout.Write(layoutDir, empty.Index)
+		h.AssertNil(t, err)
+		h.AssertNil(t, layoutPath.AppendImage(img))
+
+		mockController = gomock.NewController(t)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+
+		subject = &Client{
+			logger: logging.NewLogWithWriters(&out, &out),
+			docker: mockDockerClient,
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNilE(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#exportBuilder", func() {
+		it("writes an OCI archive", func() {
+			h.AssertNil(t, os.Chmod(filepath.Join(layoutDir, "oci-layout"), 0600))
+			outputPath := filepath.Join(tmpDir, "builder.tar")
+			h.AssertNil(t, exportBuilder(CreateBuilderOptions{Format: FormatOCIArchive, OutputPath: outputPath}, layoutDir))
+
+			for _, entry := range []string{"index.json", "oci-layout"} {
+				fi, err := os.Stat(filepath.Join(layoutDir, entry))
+				h.AssertNil(t, err)
+				h.AssertOnTarEntry(t, outputPath, entry, h.HasFileMode(int64(fi.Mode().Perm())))
+			}
+		})
+
+		it("writes an OCI archive that can be used as a layout builder", func() {
+			outputPath := filepath.Join(tmpDir, "builder.tar")
+			h.AssertNil(t, exportBuilder(CreateBuilderOptions{Format: FormatOCIArchive, OutputPath: outputPath}, layoutDir))
+
+			extractedDir := filepath.Join(tmpDir, "extracted")
+			extractTar(t, outputPath, extractedDir)
+
+			loaded, err := layoutImage(extractedDir)
+			h.AssertNil(t, err)
+			expectedDigest, err := img.Digest()
+			h.AssertNil(t, err)
+			actualDigest, err := loaded.Digest()
+			h.AssertNil(t, err)
+			h.AssertEq(t, actualDigest, expectedDigest)
+
+			imageName := "pack.local/layout-builder/" + expectedDigest.Hex + ":latest"
+			mockDockerClient.EXPECT().
+				ImageInspectWithRaw(gomock.Any(), imageName).
+				Return(types.ImageInspect{}, nil, errors.New("no such image"))
+			mockDockerClient.EXPECT().
+				ImageLoad(gomock.Any(), gomock.Any(), true).
+				DoAndReturn(func(_ context.Context, input io.Reader, _ bool) (types.ImageLoadResponse, error) {
+					_, err := io.Copy(io.Discard, input)
+					h.AssertNil(t, err)
+					return types.ImageLoadResponse{Body: io.NopCloser(strings.NewReader(`{"stream":"Loaded image"}`))}, nil
+				})
+
+			builderRef := ParseInputImageReference("oci:" + extractedDir)
+			h.AssertEq(t, builderRef.Layout(), true)
+			builderDir, err := builderRef.FullName()
+			h.AssertNil(t, err)
+			loadedName, err := subject.loadLayoutBuilder(context.TODO(), builderDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, loadedName, imageName)
+		})
+
+		it("writes a docker archive", func() {
+			outputPath := filepath.Join(tmpDir, "builder.tar")
+			h.AssertNil(t, exportBuilder(CreateBuilderOptions{Format: FormatDockerArchive, OutputPath: outputPath, BuilderName: "some/builder"}, layoutDir))
+
+			tag, err := name.NewTag("some/builder")
+			h.AssertNil(t, err)
+			loaded, err := tarball.ImageFromPath(outputPath, &tag)
+			h.AssertNil(t, err)
+			expectedDigest, err := img.Digest()
+			h.AssertNil(t, err)
+			actualDigest, err := loaded.Digest()
+			h.AssertNil(t, err)
+			h.AssertEq(t, actualDigest, expectedDigest)
+		})
+
+		it("does nothing for an OCI layout", func() {
+			h.AssertNil(t, exportBuilder(CreateBuilderOptions{Format: FormatOCILayout, OutputPath: layoutDir}, layoutDir))
+		})
+	})
+
+	when("#loadLayoutBuilder", func() {
+		var imageName string
+
+		it.Before(func() {
+			digest, err := img.Digest()
+			h.AssertNil(t, err)
+			imageName = "pack.local/layout-builder/" + digest.Hex + ":latest"
+		})
+
+		it("loads the builder into the daemon", func() {
+			mockDockerClient.EXPECT().
+				ImageInspectWithRaw(gomock.Any(), imageName).
+				Return(types.ImageInspect{}, nil, errors.New("no such image"))
+			mockDockerClient.EXPECT().
+				ImageLoad(gomock.Any(), gomock.Any(), true).
+				DoAndReturn(func(_ context.Context, input io.Reader, _ bool) (types.ImageLoadResponse, error) {
+					contents, err := io.ReadAll(input)
+					h.AssertNil(t, err)
+					h.AssertContains(t, string(contents), imageName)
+					return types.ImageLoadResponse{Body: io.NopCloser(strings.NewReader(`{"stream":"Loaded image"}`))}, nil
+				})
+
+			loadedName, err := subject.loadLayoutBuilder(context.TODO(), layoutDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, loadedName, imageName)
+		})
+
+		it("does not load a builder that was already loaded", func() {
+			mockDockerClient.EXPECT().
+				ImageInspectWithRaw(gomock.Any(), imageName).
+				Return(types.ImageInspect{}, nil, nil)
+
+			loadedName, err := subject.loadLayoutBuilder(context.TODO(), layoutDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, loadedName, imageName)
+		})
+
+		it("surfaces errors of the daemon", func() {
+			mockDockerClient.EXPECT().
+				ImageInspectWithRaw(gomock.Any(), imageName).
+				Return(types.ImageInspect{}, nil, errors.New("no such image"))
+			mockDockerClient.EXPECT().
+				ImageLoad(gomock.Any(), gomock.Any(), true).
+				DoAndReturn(func(_ context.Context, input io.Reader, _ bool) (types.ImageLoadResponse, error) {
+					_, err := io.Copy(io.Discard, input)
+					h.AssertNil(t, err)
+					return types.ImageLoadResponse{Body: io.NopCloser(strings.NewReader(`{"errorDetail":{"message":"no space left"},"error":"no space left"}`))}, nil
+				})
+
+			_, err := subject.loadLayoutBuilder(context.TODO(), layoutDir)
+			h.AssertError(t, err, "no space left")
+		})
+	})
+}
+
+// extractTar writes the entries of the tar at path to dir, failing on entries outside of it
+func extractTar(t *testing.T, path, dir string) {
+	t.Helper()
+
+	f, err := os.Open(path)
+	h.AssertNil(t, err)
+	defer f.Close()
+
+	tr := tar.NewReader(f)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return
+		}
+		h.AssertNil(t, err)
+		h.AssertEq(t, filepath.IsAbs(header.Name), false)
+
+		target := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if header.Typeflag == tar.TypeDir {
+			h.AssertNil(t, os.MkdirAll(target, 0755))
+			continue
+		}
+		h.AssertNil(t, os.MkdirAll(filepath.Dir(target), 0755))
+		contents, err := io.ReadAll(tr)
+		h.AssertNil(t, err)
+		h.AssertNil(t, os.WriteFile(target, contents, os.FileMode(header.Mode)))
+	}
+}
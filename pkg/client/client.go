@@ -25,10 +25,12 @@ import (
 	dockerClient "github.com/docker/docker/client"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/buildpacks/pack"
 	"github.com/buildpacks/pack/internal/build"
 	iconfig "github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/parallel"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/blob"
 	"github.com/buildpacks/pack/pkg/buildpack"
@@ -102,9 +104,16 @@ type Client struct {
 	lifecycleExecutor   LifecycleExecutor
 	buildpackDownloader BuildpackDownloader
 
-	experimental    bool
-	registryMirrors map[string]string
-	version         string
+	experimental     bool
+	registryMirrors  map[string]string
+	version          string
+	fetchConcurrency int
+	fetchLimiter     *parallel.Limiter
+	daemonDialer     DaemonDialer
+
+	tracerProvider trace.TracerProvider
+
+	options []Option
 }
 
 // Option is a type of function that mutate settings on the client.
@@ -194,6 +203,31 @@ func WithKeychain(keychain authn.Keychain) Option {
 	}
 }
 
+// WithTracerProvider sets the OpenTelemetry tracer provider used to emit spans
+// for client operations, image fetches, downloads and lifecycle phases.
+// See tracing.NewProviderFromEnv to configure one from the standard OTLP environment variables.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *Client) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithFetchConcurrency sets the maximum number of images and buildpacks fetched concurrently
+// while preparing a build. Non-positive values select a default limit.
+func WithFetchConcurrency(limit int) Option {
+	return func(c *Client) {
+		c.fetchConcurrency = limit
+	}
+}
+
+// WithDaemonDialer sets how the docker daemons of DaemonProfiles with ssh:// hosts are dialed, e.g. to prompt for
+// passwords and unknown host keys, or to only connect once the daemon is first used.
+func WithDaemonDialer(dialer DaemonDialer) Option {
+	return func(c *Client) {
+		c.daemonDialer = dialer
+	}
+}
+
 const DockerAPIVersion = "1.38"
 
 // NewClient allocates and returns a Client configured with the specified options.
@@ -201,12 +235,15 @@ func NewClient(opts ...Option) (*Client, error) {
 	client := &Client{
 		version:  pack.Version,
 		keychain: authn.DefaultKeychain,
+		options:  opts,
 	}
 
 	for _, opt := range opts {
 		opt(client)
 	}
 
+	client.fetchLimiter = parallel.NewLimiter(client.fetchConcurrency)
+
 	if client.logger == nil {
 		client.logger = logging.NewSimpleLogger(os.Stderr)
 	}
@@ -229,10 +266,16 @@ func NewClient(opts ...Option) (*Client, error) {
 		}
 		client.downloader = blob.NewDownloader(client.logger, filepath.Join(packHome, "download-cache"))
 	}
+	if client.tracerProvider != nil {
+		client.downloader = &tracingBlobDownloader{downloader: client.downloader}
+	}
 
 	if client.imageFetcher == nil {
 		client.imageFetcher = image.NewFetcher(client.logger, client.docker, image.WithRegistryMirrors(client.registryMirrors), image.WithKeychain(client.keychain))
 	}
+	if client.tracerProvider != nil {
+		client.imageFetcher = &tracingImageFetcher{fetcher: client.imageFetcher}
+	}
 
 	if client.imageFactory == nil {
 		client.imageFactory = &imageFactory{
@@ -251,10 +294,14 @@ func NewClient(opts ...Option) (*Client, error) {
 			client.imageFetcher,
 			client.downloader,
 			&registryResolver{
-				logger: client.logger,
+				logger:   client.logger,
+				keychain: client.keychain,
 			},
 		)
 	}
+	if client.tracerProvider != nil {
+		client.buildpackDownloader = &tracingBuildpackDownloader{downloader: client.buildpackDownloader}
+	}
 
 	client.lifecycleExecutor = build.NewLifecycleExecutor(client.logger, client.docker)
 
@@ -262,11 +309,12 @@ func NewClient(opts ...Option) (*Client, error) {
 }
 
 type registryResolver struct {
-	logger logging.Logger
+	logger   logging.Logger
+	keychain authn.Keychain
 }
 
 func (r *registryResolver) Resolve(registryName, bpName string) (string, error) {
-	cache, err := getRegistry(r.logger, registryName)
+	cache, err := getRegistryIndex(context.Background(), r.logger, r.keychain, registryName)
 	if err != nil {
 		return "", errors.Wrapf(err, "lookup registry %s", style.Symbol(registryName))
 	}
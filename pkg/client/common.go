@@ -1,9 +1,11 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 
 	"github.com/buildpacks/pack/internal/builder"
@@ -11,6 +13,7 @@ import (
 	"github.com/buildpacks/pack/internal/registry"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/logging"
+	registryTypes "github.com/buildpacks/pack/registry"
 )
 
 func (c *Client) parseTagReference(imageName string) (name.Reference, error) {
@@ -86,6 +89,44 @@ func getRegistry(logger logging.Logger, registryName string) (registry.Cache, er
 	return registry.Cache{}, fmt.Errorf("registry %s is not defined in your config file", style.Symbol(registryName))
 }
 
+// getRegistryIndex returns the index of the named registry, or of the default registry if registryName is empty.
+// Indexes served over HTTP or stored in an OCI registry are accessed with the credentials in keychain.
+func getRegistryIndex(ctx context.Context, logger logging.Logger, keychain authn.Keychain, registryName string) (registry.Index, error) {
+	if registryName != "" {
+		cfg, err := getConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, reg := range config.GetRegistries(cfg) {
+			if reg.Name == registryName && isRemoteIndexType(reg.Type) {
+				return newRemoteIndex(ctx, logger, keychain, reg.Type, reg.URL)
+			}
+		}
+	}
+
+	registryCache, err := getRegistry(logger, registryName)
+	if err != nil {
+		return nil, err
+	}
+	return &registryCache, nil
+}
+
+func isRemoteIndexType(registryType string) bool {
+	return registryType == registryTypes.TypeHTTP || registryType == registryTypes.TypeOCI
+}
+
+func newRemoteIndex(ctx context.Context, logger logging.Logger, keychain authn.Keychain, registryType, registryURL string) (*registry.RemoteIndex, error) {
+	home, err := config.PackHome()
+	if err != nil {
+		return nil, err
+	}
+	if err := config.MkdirAll(home); err != nil {
+		return nil, err
+	}
+	return registry.NewRemoteIndex(ctx, logger, home, registryType, registryURL, keychain)
+}
+
 func getConfig() (config.Config, error) {
 	path, err := config.DefaultConfigPath()
 	if err != nil {
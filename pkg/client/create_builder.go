@@ -3,12 +3,15 @@ package client
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 
 	"github.com/Masterminds/semver"
 	"github.com/buildpacks/imgutil"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 
@@ -17,7 +20,9 @@ import (
 	"github.com/buildpacks/pack/internal/paths"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/tracing"
 )
 
 // CreateBuilderOptions is a configuration object used to change the behavior of
@@ -50,20 +55,57 @@ type CreateBuilderOptions struct {
 
 	// List of modules to be flattened
 	Flatten buildpack.FlattenModuleInfos
+
+	// Type of output format. The options are the const FormatImage, FormatOCILayout, FormatOCIArchive or
+	// FormatDockerArchive. Defaults to FormatImage.
+	Format string
+
+	// Path the builder is written to when Format is not FormatImage.
+	OutputPath string
+}
+
+// daemon returns true if images used to create the builder are looked up in the daemon
+func (o CreateBuilderOptions) daemon() bool {
+	return !o.Publish && (o.Format == "" || o.Format == FormatImage)
 }
 
 // CreateBuilder creates and saves a builder image to a registry with the provided options.
 // If any configuration is invalid, it will error and exit without creating any images.
-func (c *Client) CreateBuilder(ctx context.Context, opts CreateBuilderOptions) error {
+func (c *Client) CreateBuilder(ctx context.Context, opts CreateBuilderOptions) (err error) {
+	ctx, span := c.startSpan(ctx, "pack.create_builder",
+		attribute.String("pack.builder", opts.BuilderName),
+		attribute.Bool("pack.publish", opts.Publish),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	if opts.Format == "" {
+		opts.Format = FormatImage
+	}
+
 	if err := c.validateConfig(ctx, opts); err != nil {
 		return err
 	}
 
-	bldr, err := c.createBaseBuilder(ctx, opts)
+	layoutDir, err := builderLayoutDir(opts)
+	if err != nil {
+		return err
+	}
+	if opts.Format == FormatOCIArchive || opts.Format == FormatDockerArchive {
+		defer os.RemoveAll(filepath.Dir(layoutDir))
+	}
+
+	bldr, err := c.createBaseBuilder(ctx, opts, layoutDir)
 	if err != nil {
 		return errors.Wrap(err, "failed to create builder")
 	}
 
+	order, orderExtensions := opts.Config.Order, opts.Config.OrderExtensions
+	if opts.Config.BaseBuilder.Image != "" {
+		order = mergeOrder(bldr.Order(), order, opts.Config.BaseBuilder)
+		orderExtensions = mergeOrder(bldr.OrderExtensions(), orderExtensions, pubbldr.BaseBuilderConfig{MergeOrder: opts.Config.BaseBuilder.MergeOrder})
+		removeBuildpacks(bldr, opts.Config.BaseBuilder.RemoveBuildpacks)
+	}
+
 	if err := c.addBuildpacksToBuilder(ctx, opts, bldr); err != nil {
 		return errors.Wrap(err, "failed to add buildpacks to builder")
 	}
@@ -72,16 +114,30 @@ func (c *Client) CreateBuilder(ctx context.Context, opts CreateBuilderOptions) e
 		return errors.Wrap(err, "failed to add extensions to builder")
 	}
 
-	bldr.SetOrder(opts.Config.Order)
-	bldr.SetOrderExtensions(opts.Config.OrderExtensions)
+	if opts.Config.BaseBuilder.Image != "" {
+		if order, err = c.pruneOrder(bldr, order); err != nil {
+			return err
+		}
+	}
+
+	bldr.SetOrder(order)
+	bldr.SetOrderExtensions(orderExtensions)
 
 	if opts.Config.Stack.ID != "" {
 		bldr.SetStack(opts.Config.Stack)
 	}
-	bldr.SetRunImage(opts.Config.Run)
+	if opts.Config.BaseBuilder.Image == "" || len(opts.Config.Run.Images) > 0 {
+		bldr.SetRunImage(opts.Config.Run)
+	}
 	bldr.SetBuildConfigEnv(opts.BuildConfigEnv)
 
-	return bldr.Save(c.logger, builder.CreatorMetadata{Version: c.version})
+	if err := traceSave(ctx, opts.BuilderName, opts.Publish, func() error {
+		return bldr.Save(c.logger, builder.CreatorMetadata{Version: c.version})
+	}); err != nil {
+		return err
+	}
+
+	return exportBuilder(opts, layoutDir)
 }
 
 func (c *Client) validateConfig(ctx context.Context, opts CreateBuilderOptions) error {
@@ -89,6 +145,10 @@ func (c *Client) validateConfig(ctx context.Context, opts CreateBuilderOptions)
 		return errors.Wrap(err, "invalid builder config")
 	}
 
+	if err := validateBuilderFormat(opts); err != nil {
+		return err
+	}
+
 	if err := c.validateRunImageConfig(ctx, opts); err != nil {
 		return errors.Wrap(err, "invalid run image config")
 	}
@@ -100,7 +160,7 @@ func (c *Client) validateRunImageConfig(ctx context.Context, opts CreateBuilderO
 	var runImages []imgutil.Image
 	for _, r := range opts.Config.Run.Images {
 		for _, i := range append([]string{r.Image}, r.Mirrors...) {
-			if !opts.Publish {
+			if opts.daemon() {
 				img, err := c.imageFetcher.Fetch(ctx, i, image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy})
 				if err != nil {
 					if errors.Cause(err) != image.ErrNotFound {
@@ -145,12 +205,36 @@ func (c *Client) validateRunImageConfig(ctx context.Context, opts CreateBuilderO
 	return nil
 }
 
-func (c *Client) createBaseBuilder(ctx context.Context, opts CreateBuilderOptions) (*builder.Builder, error) {
-	baseImage, err := c.imageFetcher.Fetch(ctx, opts.Config.Build.Image, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy})
+// createBaseBuilder creates the builder from its build image or base builder. When layoutDir is set, the builder is
+// created as an OCI layout in that directory.
+func (c *Client) createBaseBuilder(ctx context.Context, opts CreateBuilderOptions, layoutDir string) (*builder.Builder, error) {
+	baseBuilder := opts.Config.BaseBuilder.Image != ""
+	baseImageName := opts.Config.Build.Image
+	if baseBuilder {
+		baseImageName = opts.Config.BaseBuilder.Image
+	}
+
+	builderName := opts.BuilderName
+	fetchOptions := image.FetchOptions{Daemon: opts.daemon(), PullPolicy: opts.PullPolicy}
+	if layoutDir != "" {
+		builderName = layoutDir
+		fetchOptions.LayoutOption = image.LayoutOption{Path: layoutDir}
+	}
+
+	baseImage, err := c.imageFetcher.Fetch(ctx, baseImageName, fetchOptions)
 	if err != nil {
+		if baseBuilder {
+			return nil, errors.Wrap(err, "fetch base builder")
+		}
 		return nil, errors.Wrap(err, "fetch build image")
 	}
 
+	if baseBuilder {
+		if _, err := builder.FromImage(baseImage); err != nil {
+			return nil, errors.Wrap(err, "invalid base builder")
+		}
+	}
+
 	c.logger.Debugf("Creating builder %s from build-image %s", style.Symbol(opts.BuilderName), style.Symbol(baseImage.Name()))
 
 	var builderOpts []builder.BuilderOption
@@ -161,11 +245,17 @@ func (c *Client) createBaseBuilder(ctx context.Context, opts CreateBuilderOption
 		builderOpts = append(builderOpts, builder.WithLabels(opts.Labels))
 	}
 
-	bldr, err := builder.New(baseImage, opts.BuilderName, builderOpts...)
+	bldr, err := builder.New(baseImage, builderName, builderOpts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "invalid build-image")
 	}
 
+	if layoutDir != "" {
+		if err := baseImage.AnnotateRefName(opts.BuilderName); err != nil {
+			return nil, errors.Wrap(err, "annotating builder name")
+		}
+	}
+
 	architecture, err := baseImage.Architecture()
 	if err != nil {
 		return nil, errors.Wrap(err, "lookup image Architecture")
@@ -180,7 +270,9 @@ func (c *Client) createBaseBuilder(ctx context.Context, opts CreateBuilderOption
 		return nil, NewExperimentError("Windows containers support is currently experimental.")
 	}
 
-	bldr.SetDescription(opts.Config.Description)
+	if !baseBuilder || opts.Config.Description != "" {
+		bldr.SetDescription(opts.Config.Description)
+	}
 
 	if opts.Config.Stack.ID != "" && bldr.StackID != opts.Config.Stack.ID {
 		return nil, fmt.Errorf(
@@ -190,17 +282,127 @@ func (c *Client) createBaseBuilder(ctx context.Context, opts CreateBuilderOption
 		)
 	}
 
-	lifecycle, err := c.fetchLifecycle(ctx, opts.Config.Lifecycle, opts.RelativeBaseDir, os, architecture)
-	if err != nil {
-		return nil, errors.Wrap(err, "fetch lifecycle")
-	}
+	// the lifecycle of a base builder is kept unless the builder config declares one
+	if !baseBuilder || opts.Config.Lifecycle.Version != "" || opts.Config.Lifecycle.URI != "" {
+		lifecycle, err := c.fetchLifecycle(ctx, opts.Config.Lifecycle, opts.RelativeBaseDir, os, architecture)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetch lifecycle")
+		}
 
-	bldr.SetLifecycle(lifecycle)
+		bldr.SetLifecycle(lifecycle)
+	}
 	bldr.SetBuildConfigEnv(opts.BuildConfigEnv)
 
 	return bldr, nil
 }
 
+// mergeOrder merges the order of a builder config with the order of the base builder it extends. Buildpacks removed
+// from the base builder are removed from its order, and groups left empty are dropped.
+func mergeOrder(baseOrder, order dist.Order, config pubbldr.BaseBuilderConfig) dist.Order {
+	if config.MergeOrder == pubbldr.MergeOrderReplace {
+		return order
+	}
+
+	var inherited dist.Order
+	for _, entry := range baseOrder {
+		var group []dist.ModuleRef
+		for _, ref := range entry.Group {
+			if !contains(config.RemoveBuildpacks, ref.ID) {
+				group = append(group, ref)
+			}
+		}
+		if len(group) > 0 {
+			inherited = append(inherited, dist.OrderEntry{Group: group})
+		}
+	}
+
+	if config.MergeOrder == pubbldr.MergeOrderAppend {
+		return append(inherited, order...)
+	}
+	return append(append(dist.Order{}, order...), inherited...)
+}
+
+// pruneOrder drops the groups of the order that use a buildpack missing from the builder, either directly or through
+// the order of a composite buildpack, and warns about each of them.
+func (c *Client) pruneOrder(bldr *builder.Builder, order dist.Order) (dist.Order, error) {
+	bpLayers := dist.ModuleLayers{}
+	if _, err := dist.GetLabel(bldr.Image(), dist.BuildpackLayersLabel, &bpLayers); err != nil {
+		return nil, errors.Wrapf(err, "getting label %s", dist.BuildpackLayersLabel)
+	}
+
+	compositeOrders := map[string]dist.Order{}
+	for id, versions := range bpLayers {
+		for version, layer := range versions {
+			compositeOrders[dist.ModuleInfo{ID: id, Version: version}.FullName()] = layer.Order
+		}
+	}
+	for _, module := range bldr.AllModules(buildpack.KindBuildpack) {
+		compositeOrders[module.Descriptor().Info().FullName()] = module.Descriptor().Order()
+	}
+
+	var pruned dist.Order
+	for _, entry := range order {
+		var missing string
+		for _, ref := range entry.Group {
+			if missing = missingBuildpack(ref, bldr.Buildpacks(), compositeOrders, map[string]bool{}); missing != "" {
+				break
+			}
+		}
+		if missing != "" {
+			c.logger.Warnf("Removing group %s from the builder order, as buildpack %s is not on the builder", style.Symbol(groupName(entry)), style.Symbol(missing))
+			continue
+		}
+		pruned = append(pruned, entry)
+	}
+	return pruned, nil
+}
+
+// missingBuildpack returns the name of the first buildpack used by ref, or by the order of the composite buildpack it
+// refers to, that is not on the builder, or an empty string if there is none.
+func missingBuildpack(ref dist.ModuleRef, onBuilder []dist.ModuleInfo, compositeOrders map[string]dist.Order, seen map[string]bool) string {
+	var matching []dist.ModuleInfo
+	for _, bp := range onBuilder {
+		if bp.ID == ref.ID && (ref.Version == "" || bp.Version == ref.Version) {
+			matching = append(matching, bp)
+		}
+	}
+	if len(matching) == 0 {
+		return ref.FullName()
+	}
+
+	for _, bp := range matching {
+		if seen[bp.FullName()] {
+			continue
+		}
+		seen[bp.FullName()] = true
+		for _, entry := range compositeOrders[bp.FullName()] {
+			for _, nested := range entry.Group {
+				if missing := missingBuildpack(nested, onBuilder, compositeOrders, seen); missing != "" {
+					return missing
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func groupName(entry dist.OrderEntry) string {
+	var names []string
+	for _, ref := range entry.Group {
+		names = append(names, ref.FullName())
+	}
+	return strings.Join(names, ", ")
+}
+
+// removeBuildpacks removes every version of the buildpacks with the given IDs from the builder
+func removeBuildpacks(bldr *builder.Builder, ids []string) {
+	for _, bp := range bldr.Buildpacks() {
+		if contains(ids, bp.ID) {
+			bldr.RemoveBuildpack(bp)
+		}
+	}
+}
+
 func (c *Client) fetchLifecycle(ctx context.Context, config pubbldr.LifecycleConfig, relativeBaseDir, os string, architecture string) (builder.Lifecycle, error) {
 	if config.Version != "" && config.URI != "" {
 		return nil, errors.Errorf(
@@ -260,19 +462,38 @@ func (c *Client) addExtensionsToBuilder(ctx context.Context, opts CreateBuilderO
 }
 
 func (c *Client) addConfig(ctx context.Context, kind string, config pubbldr.ModuleConfig, opts CreateBuilderOptions, bldr *builder.Builder) error {
+	mainBP, depBPs, err := c.downloadModule(ctx, kind, config, opts, bldr)
+	if err != nil {
+		return err
+	}
+
+	switch kind {
+	case buildpack.KindBuildpack:
+		bldr.AddBuildpacks(mainBP, depBPs)
+	case buildpack.KindExtension:
+		// Extensions can't be composite
+		bldr.AddExtension(mainBP)
+	default:
+		return fmt.Errorf("unknown module kind: %s", kind)
+	}
+	return nil
+}
+
+// downloadModule downloads and validates the module described by config, and returns it with its dependencies
+func (c *Client) downloadModule(ctx context.Context, kind string, config pubbldr.ModuleConfig, opts CreateBuilderOptions, bldr *builder.Builder) (buildpack.BuildModule, []buildpack.BuildModule, error) {
 	c.logger.Debugf("Looking up %s %s", kind, style.Symbol(config.DisplayString()))
 
 	builderOS, err := bldr.Image().OS()
 	if err != nil {
-		return errors.Wrapf(err, "getting builder OS")
+		return nil, nil, errors.Wrapf(err, "getting builder OS")
 	}
 	builderArch, err := bldr.Image().Architecture()
 	if err != nil {
-		return errors.Wrapf(err, "getting builder architecture")
+		return nil, nil, errors.Wrapf(err, "getting builder architecture")
 	}
 
 	mainBP, depBPs, err := c.buildpackDownloader.Download(ctx, config.URI, buildpack.DownloadOptions{
-		Daemon:          !opts.Publish,
+		Daemon:          opts.daemon(),
 		ImageName:       config.ImageName,
 		ImageOS:         builderOS,
 		Platform:        fmt.Sprintf("%s/%s", builderOS, builderArch),
@@ -282,11 +503,11 @@ func (c *Client) addConfig(ctx context.Context, kind string, config pubbldr.Modu
 		RelativeBaseDir: opts.RelativeBaseDir,
 	})
 	if err != nil {
-		return errors.Wrapf(err, "downloading %s", kind)
+		return nil, nil, errors.Wrapf(err, "downloading %s", kind)
 	}
 	err = validateModule(kind, mainBP, config.URI, config.ID, config.Version)
 	if err != nil {
-		return errors.Wrapf(err, "invalid %s", kind)
+		return nil, nil, errors.Wrapf(err, "invalid %s", kind)
 	}
 
 	bpDesc := mainBP.Descriptor()
@@ -311,16 +532,7 @@ func (c *Client) addConfig(ctx context.Context, kind string, config pubbldr.Modu
 		return compareID < 0
 	})
 
-	switch kind {
-	case buildpack.KindBuildpack:
-		bldr.AddBuildpacks(mainBP, depBPs)
-	case buildpack.KindExtension:
-		// Extensions can't be composite
-		bldr.AddExtension(mainBP)
-	default:
-		return fmt.Errorf("unknown module kind: %s", kind)
-	}
-	return nil
+	return mainBP, depBPs, nil
 }
 
 func validateModule(kind string, module buildpack.BuildModule, source, expectedID, expectedVersion string) error {
@@ -1000,6 +1000,164 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("extending a base builder", func() {
+			it.Before(func() {
+				baseBuilder, err := builder.New(fakeBuildImage, "some/base-builder")
+				h.AssertNil(t, err)
+				lifecycle, err := builder.NewLifecycle(blob.NewBlob(filepath.Join("testdata", "lifecycle", "platform-0.4")))
+				h.AssertNil(t, err)
+				baseBuilder.SetLifecycle(lifecycle)
+				bp, err := buildpack.FromBuildpackRootBlob(blob.NewBlob(filepath.Join("testdata", "buildpack")), archive.DefaultTarWriterFactory())
+				h.AssertNil(t, err)
+				baseBuilder.AddBuildpack(bp)
+				baseBuilder.AddBuildpack(createBuildpack(dist.BuildpackDescriptor{
+					WithAPI:    api.MustParse("0.3"),
+					WithInfo:   dist.ModuleInfo{ID: "example/bar", Version: "1.0.0"},
+					WithStacks: []dist.Stack{{ID: "some.stack.id"}},
+				}))
+				baseBuilder.SetOrder(dist.Order{
+					{Group: []dist.ModuleRef{{ModuleInfo: dist.ModuleInfo{ID: "bp.one"}}}},
+					{Group: []dist.ModuleRef{{ModuleInfo: dist.ModuleInfo{ID: "example/bar"}}}},
+				})
+				baseBuilder.SetDescription("Base description")
+				baseBuilder.SetRunImage(opts.Config.Run)
+				h.AssertNil(t, baseBuilder.Save(logger, builder.CreatorMetadata{}))
+
+				mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/base-builder", gomock.Any()).Return(fakeBuildImage, nil)
+				shouldCallBuildpackDownloaderWith("https://example.fake/foo.tgz", buildpack.DownloadOptions{})
+
+				opts.Config = pubbldr.Config{
+					BaseBuilder: pubbldr.BaseBuilderConfig{Image: "some/base-builder"},
+					Buildpacks: []pubbldr.ModuleConfig{{
+						ImageOrURI: dist.ImageOrURI{BuildpackURI: dist.BuildpackURI{URI: "https://example.fake/foo.tgz"}},
+					}},
+					Order: dist.Order{{Group: []dist.ModuleRef{{ModuleInfo: dist.ModuleInfo{ID: "example/foo"}}}}},
+				}
+			})
+
+			var orderIDs = func(bldr *builder.Builder) []string {
+				var ids []string
+				for _, entry := range bldr.Order() {
+					for _, ref := range entry.Group {
+						ids = append(ids, ref.ID)
+					}
+				}
+				return ids
+			}
+
+			it("inherits the buildpacks, lifecycle and run images of the base builder", func() {
+				bldr := successfullyCreateBuilder()
+
+				h.AssertEq(t, bldr.Name(), "some/builder")
+				h.AssertEq(t, bldr.Description(), "Base description")
+				h.AssertEq(t, bldr.Buildpacks(), []dist.ModuleInfo{
+					{ID: "bp.one", Version: "1.2.3", Homepage: "http://one.buildpack"},
+					{ID: "example/bar", Version: "1.0.0"},
+					{ID: "example/foo", Version: "1.1.0"},
+				})
+				h.AssertEq(t, orderIDs(bldr), []string{"example/foo", "bp.one", "example/bar"})
+				h.AssertEq(t, bldr.LifecycleDescriptor().Info.Version.String(), "0.0.0")
+				h.AssertEq(t, bldr.DefaultRunImage().Image, "some/run-image")
+			})
+
+			it("appends its order and removes buildpacks", func() {
+				opts.Config.BaseBuilder.MergeOrder = pubbldr.MergeOrderAppend
+				opts.Config.BaseBuilder.RemoveBuildpacks = []string{"example/bar"}
+
+				bldr := successfullyCreateBuilder()
+
+				h.AssertEq(t, len(bldr.Buildpacks()), 2)
+				h.AssertEq(t, orderIDs(bldr), []string{"bp.one", "example/foo"})
+				_, err := fakeBuildImage.FindLayerWithPath("/cnb/buildpacks/example_bar/.wh.1.0.0")
+				h.AssertNil(t, err)
+			})
+
+			it("replaces the order of the base builder", func() {
+				opts.Config.BaseBuilder.MergeOrder = pubbldr.MergeOrderReplace
+
+				bldr := successfullyCreateBuilder()
+
+				h.AssertEq(t, orderIDs(bldr), []string{"example/foo"})
+			})
+
+			when("a composite buildpack of the base builder uses a removed buildpack", func() {
+				it.Before(func() {
+					baseBuilder, err := builder.FromImage(fakeBuildImage)
+					h.AssertNil(t, err)
+					baseBuilder.AddBuildpack(createBuildpack(dist.BuildpackDescriptor{
+						WithAPI:   api.MustParse("0.3"),
+						WithInfo:  dist.ModuleInfo{ID: "example/composite", Version: "1.0.0"},
+						WithOrder: dist.Order{{Group: []dist.ModuleRef{{ModuleInfo: dist.ModuleInfo{ID: "example/bar", Version: "1.0.0"}}}}},
+					}))
+					baseBuilder.SetOrder(append(baseBuilder.Order(), dist.OrderEntry{
+						Group: []dist.ModuleRef{{ModuleInfo: dist.ModuleInfo{ID: "example/composite", Version: "1.0.0"}}},
+					}))
+					h.AssertNil(t, baseBuilder.Save(logger, builder.CreatorMetadata{}))
+				})
+
+				it("drops the groups using it from the order with a warning", func() {
+					opts.Config.BaseBuilder.RemoveBuildpacks = []string{"example/bar"}
+
+					bldr := successfullyCreateBuilder()
+
+					h.AssertEq(t, orderIDs(bldr), []string{"example/foo", "bp.one"})
+					h.AssertContains(t, out.String(), "Warning: Removing group 'example/composite@1.0.0' from the builder order, as buildpack 'example/bar@1.0.0' is not on the builder")
+				})
+
+				it("keeps the groups when the buildpack is not removed", func() {
+					bldr := successfullyCreateBuilder()
+
+					h.AssertEq(t, orderIDs(bldr), []string{"example/foo", "bp.one", "example/bar", "example/composite"})
+					h.AssertNotContains(t, out.String(), "Removing group")
+				})
+			})
+		})
+
+		when("saving to an OCI layout", func() {
+			it.Before(func() {
+				opts.Format = client.FormatOCILayout
+				opts.OutputPath = filepath.Join(tmpDir, "builder-layout")
+			})
+
+			it("creates the builder in the layout", func() {
+				mockImageFetcher.EXPECT().
+					Fetch(gomock.Any(), "some/build-image", image.FetchOptions{PullPolicy: image.PullAlways, LayoutOption: image.LayoutOption{Path: opts.OutputPath}}).
+					Return(fakeBuildImage, nil)
+				mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/run-image", image.FetchOptions{PullPolicy: image.PullAlways}).Return(fakeRunImage, nil)
+				mockImageFetcher.EXPECT().Fetch(gomock.Any(), "localhost:5000/some/run-image", image.FetchOptions{PullPolicy: image.PullAlways}).Return(fakeRunImageMirror, nil)
+
+				successfullyCreateBuilder()
+
+				h.AssertEq(t, fakeBuildImage.Name(), opts.OutputPath)
+				refName, err := fakeBuildImage.GetAnnotateRefName()
+				h.AssertNil(t, err)
+				h.AssertEq(t, refName, "some/builder")
+			})
+
+			it("requires an output path", func() {
+				opts.OutputPath = ""
+
+				err := subject.CreateBuilder(context.TODO(), opts)
+				h.AssertError(t, err, "an output path is required to save a builder as 'oci-layout'")
+			})
+
+			it("cannot be published", func() {
+				opts.Publish = true
+
+				err := subject.CreateBuilder(context.TODO(), opts)
+				h.AssertError(t, err, "cannot publish a builder saved as 'oci-layout'")
+			})
+		})
+
+		when("saving as an image", func() {
+			it("cannot have an output path", func() {
+				opts.OutputPath = filepath.Join(tmpDir, "builder-layout")
+
+				err := subject.CreateBuilder(context.TODO(), opts)
+				h.AssertError(t, err, "an output path can only be used to save a builder as 'oci-layout', 'oci-archive' or 'docker-archive'")
+			})
+		})
+
 		when("flatten option is set", func() {
 			/*       1
 			 *    /    \
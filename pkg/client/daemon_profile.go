@@ -0,0 +1,245 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	dockerClient "github.com/docker/docker/client"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/sshdialer"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// DaemonProfile is a docker daemon, other than the one of the client, that builds may run on
+type DaemonProfile struct {
+	// Name of the daemon, as configured with `pack config docker-hosts add`.
+	Name string
+
+	// Address of the daemon, e.g. ssh://user@host or tcp://host:2376.
+	Host string
+
+	// Platform of the daemon, in the form os/arch[/variant], e.g. linux/arm64.
+	Platform string
+
+	// Path of the private key used to connect to ssh:// hosts. When empty, the keys of the SSH agent or ~/.ssh are used.
+	SSHIdentity string
+
+	// Directory with the ca.pem, cert.pem and key.pem files used to connect to tcp:// hosts over TLS.
+	TLSCertPath string
+
+	// Lifecycle image to use when building on the daemon, instead of BuildOptions.LifecycleImage.
+	LifecycleImage string
+}
+
+// selectDaemon returns the daemon the build runs on, or nil to build on the daemon of the client. It's the daemon
+// of the options, if any, or else the first candidate whose platform the builder is available for, when the builder is
+// not known to be available for the platform of the daemon of the client. Candidates are only considered for builders
+// that may be pulled from a registry.
+func (c *Client) selectDaemon(ctx context.Context, opts BuildOptions) (*DaemonProfile, error) {
+	if opts.Daemon != nil {
+		if err := validateDaemonOptions(*opts.Daemon, opts); err != nil {
+			return nil, err
+		}
+		return opts.Daemon, nil
+	}
+	if len(opts.DaemonCandidates) == 0 || opts.PullPolicy == image.PullNever || ParseInputImageReference(opts.Builder).Layout() {
+		return nil, nil
+	}
+
+	builderPlatforms, err := c.imagePlatforms(ctx, opts.Builder)
+	if err != nil {
+		c.logger.Debugf("Not selecting a daemon, as the platforms of builder %s could not be read: %s", style.Symbol(opts.Builder), err)
+		return nil, nil
+	}
+
+	if info, err := c.docker.Info(ctx); err != nil {
+		c.logger.Debugf("The platform of the local daemon is unknown: %s", err)
+	} else if platformsContain(builderPlatforms, info.OSType+"/"+normalizeArch(info.Architecture)) {
+		return nil, nil
+	}
+
+	for i, candidate := range opts.DaemonCandidates {
+		if candidate.Platform == "" || !platformsContain(builderPlatforms, candidate.Platform) {
+			continue
+		}
+		if err := validateDaemonOptions(candidate, opts); err != nil {
+			c.logger.Debugf("Not selecting daemon %s: %s", style.Symbol(candidate.Name), err)
+			continue
+		}
+		c.logger.Debugf("Selected daemon %s, as builder %s is available for %s", style.Symbol(candidate.Name), style.Symbol(opts.Builder), style.Symbol(candidate.Platform))
+		return &opts.DaemonCandidates[i], nil
+	}
+	return nil, nil
+}
+
+// validateDaemonOptions returns an error if the build options cannot be used when building on daemon.
+func validateDaemonOptions(daemon DaemonProfile, opts BuildOptions) error {
+	if opts.DockerHost != "" {
+		return errors.Errorf("the docker host of the build containers cannot be set when building on daemon %s", style.Symbol(daemon.Name))
+	}
+	if opts.KeepAppVolume {
+		return errors.Errorf("the app volume cannot be kept when building on daemon %s", style.Symbol(daemon.Name))
+	}
+
+	if strings.HasPrefix(daemon.Host, "unix://") {
+		return nil
+	}
+	if len(opts.Secrets) > 0 {
+		return errors.Errorf("build secrets require a Docker daemon on the local machine, daemon %s is not local", style.Symbol(daemon.Name))
+	}
+	if egressAllow, err := egressAllowlist(opts.EgressPolicy, opts.ProjectDescriptor.Build.Egress); err == nil && egressAllow != nil {
+		return errors.Errorf("egress policies require a Docker daemon on the local machine, daemon %s is not local", style.Symbol(daemon.Name))
+	}
+	return nil
+}
+
+// imagePlatforms returns the platforms an image in a registry is available for, in the form os/arch[/variant]
+func (c *Client) imagePlatforms(ctx context.Context, imageName string) ([]string, error) {
+	ref, err := name.ParseReference(imageName, name.WeakValidation)
+	if err != nil {
+		return nil, err
+	}
+
+	desc, err := remote.Get(ref, c.remoteOptions(ctx)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if desc.MediaType.IsIndex() {
+		index, err := desc.ImageIndex()
+		if err != nil {
+			return nil, err
+		}
+		manifest, err := index.IndexManifest()
+		if err != nil {
+			return nil, err
+		}
+		var platforms []string
+		for _, m := range manifest.Manifests {
+			if m.Platform != nil {
+				platforms = append(platforms, m.Platform.String())
+			}
+		}
+		return platforms, nil
+	}
+
+	img, err := desc.Image()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := img.ConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	return []string{cfg.Platform().String()}, nil
+}
+
+// platformsContain returns true if one of the platforms matches the given one. Variants are only compared when both
+// platforms have one.
+func platformsContain(platforms []string, platform string) bool {
+	want := strings.Split(platform, "/")
+	for _, p := range platforms {
+		got := strings.Split(p, "/")
+		if len(got) < 2 || len(want) < 2 || got[0] != want[0] || got[1] != want[1] {
+			continue
+		}
+		if len(got) > 2 && len(want) > 2 && got[2] != want[2] {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// normalizeArch converts the architecture reported by a daemon, e.g. x86_64, to its name in image platforms
+func normalizeArch(arch string) string {
+	switch arch {
+	case "x86_64":
+		return "amd64"
+	case "aarch64":
+		return "arm64"
+	default:
+		return arch
+	}
+}
+
+// DialContext dials the docker daemon, like net.Dialer.DialContext.
+type DialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// DaemonDialer returns how to dial the docker daemon at an ssh:// host, using the private key at identity if it isn't empty.
+type DaemonDialer func(hostURL *url.URL, identity string) (DialContext, error)
+
+// dialSSHDaemon is the DaemonDialer used without WithDaemonDialer. It connects right away, without prompting for
+// passwords or unknown host keys.
+func dialSSHDaemon(hostURL *url.URL, identity string) (DialContext, error) {
+	dialContext, err := sshdialer.NewDialContext(hostURL, sshdialer.Config{
+		Identity:   identity,
+		PassPhrase: os.Getenv("DOCKER_HOST_SSH_IDENTITY_PASSPHRASE"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return DialContext(dialContext), nil
+}
+
+// onDaemon returns a client like this one, but using the docker daemon of the profile. The returned closer releases
+// the connection to the daemon once the client is no longer used.
+func (c *Client) onDaemon(daemon DaemonProfile) (*Client, io.Closer, error) {
+	docker, err := c.newDaemonClient(daemon)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "connecting to daemon %s", style.Symbol(daemon.Name))
+	}
+	daemonClient, err := NewClient(append(c.options, WithDockerClient(docker))...)
+	if err != nil {
+		docker.Close()
+		return nil, nil, err
+	}
+	return daemonClient, docker, nil
+}
+
+func (c *Client) newDaemonClient(daemon DaemonProfile) (*dockerClient.Client, error) {
+	hostURL, err := url.Parse(daemon.Host)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid address %s", style.Symbol(daemon.Host))
+	}
+
+	if hostURL.Scheme != "ssh" {
+		opts := []dockerClient.Opt{
+			dockerClient.WithHost(daemon.Host),
+			dockerClient.WithVersion(DockerAPIVersion),
+		}
+		if daemon.TLSCertPath != "" {
+			opts = append(opts, dockerClient.WithTLSClientConfig(
+				filepath.Join(daemon.TLSCertPath, "ca.pem"),
+				filepath.Join(daemon.TLSCertPath, "cert.pem"),
+				filepath.Join(daemon.TLSCertPath, "key.pem"),
+			))
+		}
+		return dockerClient.NewClientWithOpts(opts...)
+	}
+
+	dial := c.daemonDialer
+	if dial == nil {
+		dial = dialSSHDaemon
+	}
+	dialContext, err := dial(hostURL, daemon.SSHIdentity)
+	if err != nil {
+		return nil, err
+	}
+	return dockerClient.NewClientWithOpts(
+		dockerClient.WithVersion(DockerAPIVersion),
+		dockerClient.WithHTTPClient(&http.Client{Transport: &http.Transport{DialContext: dialContext}}),
+		dockerClient.WithHost("http://dummy"),
+		dockerClient.WithDialContext(dialContext),
+	)
+}
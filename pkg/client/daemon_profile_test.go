@@ -0,0 +1,272 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/system"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDaemonProfile(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "DaemonProfile", testDaemonProfile, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDaemonProfile(t *testing.T, when spec.G, it spec.S) {
+	when("#selectDaemon", func() {
+		var (
+			subject          *Client
+			mockController   *gomock.Controller
+			mockDockerClient *testmocks.MockCommonAPIClient
+			server           *httptest.Server
+			builderName      string
+			out              bytes.Buffer
+			armBox           = DaemonProfile{Name: "arm-box", Host: "ssh://arm-box", Platform: "linux/arm64"}
+			windowsBox       = DaemonProfile{Name: "windows-box", Host: "tcp://windows-box:2376", Platform: "windows/amd64"}
+		)
+
+		it.Before(func() {
+			server = httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+
+			mockController = gomock.NewController(t)
+			mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+			subject = &Client{
+				logger:   logging.NewLogWithWriters(&out, &out),
+				docker:   mockDockerClient,
+				keychain: authn.DefaultKeychain,
+			}
+
+			builderName = strings.TrimPrefix(server.URL, "http://") + "/some/builder:latest"
+			ref, err := name.ParseReference(builderName)
+			h.AssertNil(t, err)
+			img, err := random.Image(1, 1)
+			h.AssertNil(t, err)
+			cfg, err := img.ConfigFile()
+			h.AssertNil(t, err)
+			cfg.OS, cfg.Architecture = "linux", "arm64"
+			img, err = mutate.ConfigFile(img, cfg)
+			h.AssertNil(t, err)
+			h.AssertNil(t, remote.Write(ref, img))
+		})
+
+		it.After(func() {
+			server.Close()
+			mockController.Finish()
+		})
+
+		it("returns the daemon of the options", func() {
+			daemon, err := subject.selectDaemon(context.TODO(), BuildOptions{Builder: builderName, Daemon: &windowsBox})
+			h.AssertNil(t, err)
+			h.AssertEq(t, *daemon, windowsBox)
+		})
+
+		it("selects a candidate of the platform of the builder", func() {
+			mockDockerClient.EXPECT().Info(gomock.Any()).Return(system.Info{OSType: "linux", Architecture: "x86_64"}, nil)
+
+			daemon, err := subject.selectDaemon(context.TODO(), BuildOptions{Builder: builderName, DaemonCandidates: []DaemonProfile{windowsBox, armBox}})
+			h.AssertNil(t, err)
+			h.AssertEq(t, *daemon, armBox)
+		})
+
+		it("uses the daemon of the client when it has the platform of the builder", func() {
+			mockDockerClient.EXPECT().Info(gomock.Any()).Return(system.Info{OSType: "linux", Architecture: "aarch64"}, nil)
+
+			daemon, err := subject.selectDaemon(context.TODO(), BuildOptions{Builder: builderName, DaemonCandidates: []DaemonProfile{armBox}})
+			h.AssertNil(t, err)
+			h.AssertNil(t, daemon)
+		})
+
+		it("uses the daemon of the client when no candidate has the platform of the builder", func() {
+			mockDockerClient.EXPECT().Info(gomock.Any()).Return(system.Info{OSType: "linux", Architecture: "x86_64"}, nil)
+
+			daemon, err := subject.selectDaemon(context.TODO(), BuildOptions{Builder: builderName, DaemonCandidates: []DaemonProfile{windowsBox}})
+			h.AssertNil(t, err)
+			h.AssertNil(t, daemon)
+		})
+
+		it("selects a candidate when the platform of the daemon of the client is unknown", func() {
+			mockDockerClient.EXPECT().Info(gomock.Any()).Return(system.Info{}, errors.New("daemon unavailable"))
+
+			daemon, err := subject.selectDaemon(context.TODO(), BuildOptions{Builder: builderName, DaemonCandidates: []DaemonProfile{armBox}})
+			h.AssertNil(t, err)
+			h.AssertEq(t, *daemon, armBox)
+		})
+
+		it("does not select a candidate when the builder is not pulled", func() {
+			daemon, err := subject.selectDaemon(context.TODO(), BuildOptions{Builder: builderName, PullPolicy: image.PullNever, DaemonCandidates: []DaemonProfile{armBox}})
+			h.AssertNil(t, err)
+			h.AssertNil(t, daemon)
+		})
+
+		it("does not select a candidate for a builder in an OCI layout", func() {
+			daemon, err := subject.selectDaemon(context.TODO(), BuildOptions{Builder: "oci:/some/builder", DaemonCandidates: []DaemonProfile{armBox}})
+			h.AssertNil(t, err)
+			h.AssertNil(t, daemon)
+		})
+
+		it("does not select a candidate that does not support the options", func() {
+			mockDockerClient.EXPECT().Info(gomock.Any()).Return(system.Info{OSType: "linux", Architecture: "x86_64"}, nil)
+
+			daemon, err := subject.selectDaemon(context.TODO(), BuildOptions{Builder: builderName, KeepAppVolume: true, DaemonCandidates: []DaemonProfile{armBox}})
+			h.AssertNil(t, err)
+			h.AssertNil(t, daemon)
+		})
+
+		when("the daemon of the options does not support the options", func() {
+			it("errors for a docker host", func() {
+				_, err := subject.selectDaemon(context.TODO(), BuildOptions{Builder: builderName, Daemon: &armBox, DockerHost: "inherit"})
+				h.AssertError(t, err, "the docker host of the build containers cannot be set when building on daemon 'arm-box'")
+			})
+
+			it("errors for a kept app volume", func() {
+				_, err := subject.selectDaemon(context.TODO(), BuildOptions{Builder: builderName, Daemon: &armBox, KeepAppVolume: true})
+				h.AssertError(t, err, "the app volume cannot be kept when building on daemon 'arm-box'")
+			})
+
+			it("errors for secrets on a daemon that is not local", func() {
+				_, err := subject.selectDaemon(context.TODO(), BuildOptions{Builder: builderName, Daemon: &armBox, Secrets: []BuildSecret{{ID: "token"}}})
+				h.AssertError(t, err, "build secrets require a Docker daemon on the local machine, daemon 'arm-box' is not local")
+			})
+
+			it("errors for an egress policy on a daemon that is not local", func() {
+				_, err := subject.selectDaemon(context.TODO(), BuildOptions{Builder: builderName, Daemon: &windowsBox, EgressPolicy: &EgressPolicy{Restrict: true}})
+				h.AssertError(t, err, "egress policies require a Docker daemon on the local machine, daemon 'windows-box' is not local")
+			})
+
+			it("allows secrets on a local daemon", func() {
+				localBox := DaemonProfile{Name: "local-box", Host: "unix:///var/run/other.sock"}
+				daemon, err := subject.selectDaemon(context.TODO(), BuildOptions{Builder: builderName, Daemon: &localBox, Secrets: []BuildSecret{{ID: "token"}}})
+				h.AssertNil(t, err)
+				h.AssertEq(t, *daemon, localBox)
+			})
+		})
+
+		it("uses the daemon of the client when the builder is not in a registry", func() {
+			daemon, err := subject.selectDaemon(context.TODO(), BuildOptions{Builder: "localhost:1/missing/builder", DaemonCandidates: []DaemonProfile{armBox}})
+			h.AssertNil(t, err)
+			h.AssertNil(t, daemon)
+		})
+	})
+
+	when("#newDaemonClient", func() {
+		it("dials ssh hosts with the configured dialer only once the daemon is used", func() {
+			var (
+				dialedHost     *url.URL
+				dialedIdentity string
+				dialed         bool
+			)
+			subject := &Client{
+				daemonDialer: func(hostURL *url.URL, identity string) (DialContext, error) {
+					dialedHost = hostURL
+					dialedIdentity = identity
+					return func(ctx context.Context, network, addr string) (net.Conn, error) {
+						dialed = true
+						return nil, errors.New("no daemon")
+					}, nil
+				},
+			}
+
+			docker, err := subject.newDaemonClient(DaemonProfile{
+				Name:        "arm",
+				Host:        "ssh://user@arm-host",
+				SSHIdentity: "/some/key",
+			})
+			h.AssertNil(t, err)
+			defer docker.Close()
+
+			h.AssertEq(t, dialedHost.String(), "ssh://user@arm-host")
+			h.AssertEq(t, dialedIdentity, "/some/key")
+			h.AssertEq(t, dialed, false)
+
+			_, err = docker.Ping(context.Background())
+			h.AssertNotNil(t, err)
+			h.AssertEq(t, dialed, true)
+		})
+
+		it("returns the error of the dialer", func() {
+			subject := &Client{
+				daemonDialer: func(hostURL *url.URL, identity string) (DialContext, error) {
+					return nil, errors.New("bad identity")
+				},
+			}
+
+			_, err := subject.newDaemonClient(DaemonProfile{Name: "arm", Host: "ssh://user@arm-host"})
+			h.AssertError(t, err, "bad identity")
+		})
+
+		it("does not use the dialer for other hosts", func() {
+			subject := &Client{
+				daemonDialer: func(hostURL *url.URL, identity string) (DialContext, error) {
+					t.Fatal("unexpected dial")
+					return nil, nil
+				},
+			}
+
+			docker, err := subject.newDaemonClient(DaemonProfile{Name: "arm", Host: "tcp://arm-host:2375"})
+			h.AssertNil(t, err)
+			defer docker.Close()
+			h.AssertEq(t, docker.DaemonHost(), "tcp://arm-host:2375")
+		})
+	})
+
+	when("#platformsContain", func() {
+		it("compares variants only when both platforms have one", func() {
+			h.AssertEq(t, platformsContain([]string{"linux/arm64/v8"}, "linux/arm64"), true)
+			h.AssertEq(t, platformsContain([]string{"linux/arm/v7"}, "linux/arm/v6"), false)
+			h.AssertEq(t, platformsContain([]string{"linux/amd64", "windows/amd64"}, "windows/amd64"), true)
+			h.AssertEq(t, platformsContain([]string{"linux/amd64"}, "linux/arm64"), false)
+		})
+	})
+
+	when("#imagePlatforms", func() {
+		it("returns the platforms of an index", func() {
+			server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+			defer server.Close()
+
+			ref, err := name.ParseReference(strings.TrimPrefix(server.URL, "http://") + "/some/index:latest")
+			h.AssertNil(t, err)
+			index, err := random.Index(1, 1, 2)
+			h.AssertNil(t, err)
+			manifest, err := index.IndexManifest()
+			h.AssertNil(t, err)
+			for i, platform := range []v1.Platform{{OS: "linux", Architecture: "amd64"}, {OS: "linux", Architecture: "arm64", Variant: "v8"}} {
+				platform := platform
+				img, err := index.Image(manifest.Manifests[i].Digest)
+				h.AssertNil(t, err)
+				index = mutate.RemoveManifests(index, func(desc v1.Descriptor) bool { return desc.Digest == manifest.Manifests[i].Digest })
+				index = mutate.AppendManifests(index, mutate.IndexAddendum{Add: img, Descriptor: v1.Descriptor{Platform: &platform}})
+			}
+			h.AssertNil(t, remote.WriteIndex(ref, index))
+
+			subject := &Client{keychain: authn.DefaultKeychain}
+			platforms, err := subject.imagePlatforms(context.TODO(), ref.Name())
+			h.AssertNil(t, err)
+			h.AssertEq(t, platforms, []string{"linux/amd64", "linux/arm64/v8"})
+		})
+	})
+}
@@ -0,0 +1,517 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/buildpacks/lifecycle/launch"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/sbom"
+)
+
+// DiffStatus describes how an element of an app image differs between two images.
+type DiffStatus string
+
+const (
+	// DiffAdded indicates the element is only present in the second image.
+	DiffAdded DiffStatus = "added"
+
+	// DiffRemoved indicates the element is only present in the first image.
+	DiffRemoved DiffStatus = "removed"
+
+	// DiffChanged indicates the element is present in both images but differs.
+	DiffChanged DiffStatus = "changed"
+)
+
+// ImageDiff describes the differences between two app images built using Cloud Native Buildpacks.
+// Only elements that differ are included.
+type ImageDiff struct {
+	// Names of the compared images.
+	ImageA string
+	ImageB string
+
+	// The run image of both images, if it differs.
+	RunImage *RunImageDiff
+
+	// Buildpacks that were added, removed or changed version.
+	Buildpacks []BuildpackDiff
+
+	// Processes that were added, removed or changed.
+	Processes []ProcessDiff
+
+	// Bill of materials entries that were added, removed or changed.
+	BOM []BOMDiff
+
+	// Layers contributed by buildpacks or the lifecycle that were added, removed or changed.
+	Layers []LayerDiff
+}
+
+// RunImageDiff contains the run image of both compared images.
+type RunImageDiff struct {
+	Before files.RunImageForRebase
+	After  files.RunImageForRebase
+}
+
+// BuildpackDiff describes a buildpack that differs between two images.
+type BuildpackDiff struct {
+	ID     string
+	Status DiffStatus
+
+	// Versions of the buildpack in each image. Empty if the buildpack is absent.
+	Before string
+	After  string
+}
+
+// ProcessDiff describes a process type that differs between two images.
+type ProcessDiff struct {
+	Type   string
+	Status DiffStatus
+
+	// The process in each image. Nil if the process is absent.
+	Before *launch.Process
+	After  *launch.Process
+}
+
+// BOMDiff describes a bill of materials entry that differs between two images.
+type BOMDiff struct {
+	Name      string
+	Buildpack string
+	Status    DiffStatus
+
+	// Versions of the entry in each image. Empty if the entry is absent or does not have a version.
+	Before string
+	After  string
+}
+
+// LayerDiff describes a named layer that differs between two images.
+type LayerDiff struct {
+	// Name of the layer, e.g. "<buildpack-id>:<layer-name>", "app", "launcher".
+	Name string
+
+	// ID of the buildpack that contributed the layer, if any.
+	Buildpack string
+
+	Status DiffStatus
+
+	// Diff IDs of the layer in each image. Empty if the layer is absent.
+	Before string
+	After  string
+}
+
+// Empty returns true if no differences were found.
+func (d *ImageDiff) Empty() bool {
+	return d.RunImage == nil && len(d.Buildpacks) == 0 && len(d.Processes) == 0 && len(d.BOM) == 0 && len(d.Layers) == 0
+}
+
+// DiffImages compares two app images. Each image is read from the daemon if present, otherwise from its registry.
+func (c *Client) DiffImages(ctx context.Context, imageA, imageB string) (*ImageDiff, error) {
+	before, err := c.fetchForInspection(ctx, imageA)
+	if err != nil {
+		return nil, err
+	}
+	after, err := c.fetchForInspection(ctx, imageB)
+	if err != nil {
+		return nil, err
+	}
+
+	beforeInfo, err := imageInfo(before)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading metadata of %s", style.Symbol(imageA))
+	}
+	afterInfo, err := imageInfo(after)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading metadata of %s", style.Symbol(imageB))
+	}
+
+	beforeLayers, err := namedLayers(before)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading layers of %s", style.Symbol(imageA))
+	}
+	afterLayers, err := namedLayers(after)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading layers of %s", style.Symbol(imageB))
+	}
+
+	beforeBOM, err := imageBOM(before, beforeInfo)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading bill of materials of %s", style.Symbol(imageA))
+	}
+	afterBOM, err := imageBOM(after, afterInfo)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading bill of materials of %s", style.Symbol(imageB))
+	}
+
+	diff := &ImageDiff{
+		ImageA:     imageA,
+		ImageB:     imageB,
+		Buildpacks: diffBuildpacks(beforeInfo.Buildpacks, afterInfo.Buildpacks),
+		Processes:  diffProcesses(beforeInfo.Processes, afterInfo.Processes),
+		BOM:        diffBOM(beforeBOM, afterBOM),
+		Layers:     diffLayers(beforeLayers, afterLayers),
+	}
+	if beforeInfo.Base.Reference != afterInfo.Base.Reference || beforeInfo.Base.TopLayer != afterInfo.Base.TopLayer {
+		diff.RunImage = &RunImageDiff{Before: beforeInfo.Base, After: afterInfo.Base}
+	}
+	return diff, nil
+}
+
+// fetchForInspection fetches an image from the daemon if present, otherwise from its registry.
+func (c *Client) fetchForInspection(ctx context.Context, name string) (imgutil.Image, error) {
+	img, err := c.imageFetcher.Fetch(ctx, name, image.FetchOptions{Daemon: true, PullPolicy: image.PullNever})
+	if err == nil {
+		return img, nil
+	}
+	if errors.Cause(err) != image.ErrNotFound {
+		return nil, err
+	}
+
+	img, err = c.imageFetcher.Fetch(ctx, name, image.FetchOptions{Daemon: false})
+	if errors.Cause(err) == image.ErrNotFound {
+		return nil, errors.Errorf("unable to find image %s locally or remotely", style.Symbol(name))
+	}
+	return img, err
+}
+
+func diffBuildpacks(before, after []buildpack.GroupElement) []BuildpackDiff {
+	versions := func(group []buildpack.GroupElement) map[string]string {
+		result := map[string]string{}
+		for _, bp := range group {
+			result[bp.ID] = bp.Version
+		}
+		return result
+	}
+	beforeVersions, afterVersions := versions(before), versions(after)
+
+	var diffs []BuildpackDiff
+	for _, id := range unionKeys(beforeVersions, afterVersions) {
+		beforeVersion, inBefore := beforeVersions[id]
+		afterVersion, inAfter := afterVersions[id]
+		if status, differs := diffStatus(inBefore, inAfter, beforeVersion == afterVersion); differs {
+			diffs = append(diffs, BuildpackDiff{ID: id, Status: status, Before: beforeVersion, After: afterVersion})
+		}
+	}
+	return diffs
+}
+
+func diffProcesses(before, after ProcessDetails) []ProcessDiff {
+	processes := func(details ProcessDetails) map[string]launch.Process {
+		result := map[string]launch.Process{}
+		if details.DefaultProcess != nil {
+			proc := *details.DefaultProcess
+			proc.Default = true
+			result[proc.Type] = proc
+		}
+		for _, proc := range details.OtherProcesses {
+			result[proc.Type] = proc.NoDefault()
+		}
+		return result
+	}
+	beforeProcs, afterProcs := processes(before), processes(after)
+
+	var diffs []ProcessDiff
+	for _, processType := range unionKeys(beforeProcs, afterProcs) {
+		beforeProc, inBefore := beforeProcs[processType]
+		afterProc, inAfter := afterProcs[processType]
+		status, differs := diffStatus(inBefore, inAfter, reflect.DeepEqual(beforeProc, afterProc))
+		if !differs {
+			continue
+		}
+
+		processDiff := ProcessDiff{Type: processType, Status: status}
+		if inBefore {
+			processDiff.Before = &beforeProc
+		}
+		if inAfter {
+			processDiff.After = &afterProc
+		}
+		diffs = append(diffs, processDiff)
+	}
+	return diffs
+}
+
+// bomItem is a package listed in the bill of materials of an app image, either by the build metadata or by the SBOM
+// layer.
+type bomItem struct {
+	source    string
+	buildpack string
+	name      string
+	version   string
+
+	// Everything recorded about the package, to tell apart entries with the same name and version.
+	identity string
+}
+
+// imageBOM lists the packages of the legacy bill of materials and of the SBOM layer of an app image.
+func imageBOM(img imgutil.Image, info *ImageInfo) ([]bomItem, error) {
+	var items []bomItem
+	for _, entry := range info.BOM {
+		items = append(items, bomItem{
+			source:    "bom",
+			buildpack: entry.Buildpack.ID,
+			name:      entry.Name,
+			version:   bomVersion(entry),
+			identity:  bomIdentity(entry),
+		})
+	}
+
+	var sbomMD sbomMetadata
+	if _, err := dist.GetLabel(img, platform.LifecycleMetadataLabel, &sbomMD); err != nil {
+		return nil, err
+	}
+	if sbomMD.isMissing() {
+		return items, nil
+	}
+
+	tmpDir, err := os.MkdirTemp("", "pack.diff.sbom.")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	buildpackIDs, err := extractSBOMLayer(img, sbomMD, tmpDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "extracting SBOM layer")
+	}
+	docs, err := sbom.ReadDir(tmpDir, buildpackIDs...)
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range docs {
+		for _, pkg := range doc.Packages {
+			items = append(items, bomItem{
+				source:    "sbom",
+				buildpack: pkg.Buildpack,
+				name:      pkg.Name,
+				version:   pkg.Version,
+				identity:  bomIdentity(pkg),
+			})
+		}
+	}
+	return items, nil
+}
+
+// diffBOM compares the packages of two images. A buildpack may list several versions of a package, so the packages
+// with the same name are compared as multisets: identical packages cancel out, the remaining ones are paired up in
+// version order and reported as changed, and any left over are reported as added or removed.
+func diffBOM(before, after []bomItem) []BOMDiff {
+	group := func(items []bomItem) map[string][]bomItem {
+		result := map[string][]bomItem{}
+		for _, item := range items {
+			k := item.buildpack + "\x00" + item.name + "\x00" + item.source
+			result[k] = append(result[k], item)
+		}
+		return result
+	}
+	beforeItems, afterItems := group(before), group(after)
+
+	var diffs []BOMDiff
+	for _, k := range unionKeys(beforeItems, afterItems) {
+		removed, added := cancelBOMItems(beforeItems[k], afterItems[k])
+		for i := 0; i < len(removed) || i < len(added); i++ {
+			var beforeItem, afterItem bomItem
+			if i < len(removed) {
+				beforeItem = removed[i]
+			}
+			if i < len(added) {
+				afterItem = added[i]
+			}
+			status, _ := diffStatus(i < len(removed), i < len(added), false)
+
+			item := afterItem
+			if i >= len(added) {
+				item = beforeItem
+			}
+			diffs = append(diffs, BOMDiff{
+				Name:      item.name,
+				Buildpack: item.buildpack,
+				Status:    status,
+				Before:    beforeItem.version,
+				After:     afterItem.version,
+			})
+		}
+	}
+	return diffs
+}
+
+// cancelBOMItems drops the packages present in both lists, and returns the remaining ones sorted by version.
+func cancelBOMItems(before, after []bomItem) (removed, added []bomItem) {
+	remaining := map[string]int{}
+	for _, item := range after {
+		remaining[item.identity]++
+	}
+	for _, item := range before {
+		if remaining[item.identity] > 0 {
+			remaining[item.identity]--
+			continue
+		}
+		removed = append(removed, item)
+	}
+	for _, item := range after {
+		if remaining[item.identity] > 0 {
+			remaining[item.identity]--
+			added = append(added, item)
+		}
+	}
+
+	byVersion := func(items []bomItem) {
+		sort.SliceStable(items, func(i, j int) bool { return items[i].version < items[j].version })
+	}
+	byVersion(removed)
+	byVersion(added)
+	return removed, added
+}
+
+// bomIdentity returns everything recorded about a package, so that packages only compare equal if nothing differs.
+func bomIdentity(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%#v", v)
+	}
+	return string(data)
+}
+
+// bomVersion returns the version of a BOM entry, which buildpacks may record either as a field or in its metadata.
+func bomVersion(entry buildpack.BOMEntry) string {
+	if entry.Version != "" {
+		return entry.Version
+	}
+	if version, ok := entry.Metadata["version"]; ok {
+		return fmt.Sprint(version)
+	}
+	return ""
+}
+
+func diffLayers(before, after map[string]namedLayer) []LayerDiff {
+	var diffs []LayerDiff
+	for _, name := range unionKeys(before, after) {
+		beforeLayer, inBefore := before[name]
+		afterLayer, inAfter := after[name]
+		if status, differs := diffStatus(inBefore, inAfter, beforeLayer.diffID == afterLayer.diffID); differs {
+			layer := afterLayer
+			if !inAfter {
+				layer = beforeLayer
+			}
+			diffs = append(diffs, LayerDiff{
+				Name:      name,
+				Buildpack: layer.buildpack,
+				Status:    status,
+				Before:    beforeLayer.diffID,
+				After:     afterLayer.diffID,
+			})
+		}
+	}
+	return diffs
+}
+
+type namedLayer struct {
+	buildpack string
+	diffID    string
+}
+
+// namedLayers maps the layers recorded in the lifecycle metadata of an app image to their names.
+func namedLayers(img imgutil.Image) (map[string]namedLayer, error) {
+	var md files.LayersMetadataCompat
+	if _, err := dist.GetLabel(img, platform.LifecycleMetadataLabel, &md); err != nil {
+		return nil, err
+	}
+
+	layers := map[string]namedLayer{}
+	add := func(name, bp, diffID string) {
+		if diffID != "" {
+			layers[name] = namedLayer{buildpack: bp, diffID: diffID}
+		}
+	}
+
+	appLayers, err := appLayerDiffIDs(md.App)
+	if err != nil {
+		return nil, err
+	}
+	for i, diffID := range appLayers {
+		name := "app"
+		if len(appLayers) > 1 {
+			name = fmt.Sprintf("app:%d", i)
+		}
+		add(name, "", diffID)
+	}
+
+	for _, bp := range md.Buildpacks {
+		for layerName, layer := range bp.Layers {
+			add(bp.ID+":"+layerName, bp.ID, layer.SHA)
+		}
+	}
+
+	add("config", "", md.Config.SHA)
+	add("launcher", "", md.Launcher.SHA)
+	add("process-types", "", md.ProcessTypes.SHA)
+	if md.BOM != nil {
+		add("sbom", "", md.BOM.SHA)
+	}
+	return layers, nil
+}
+
+// appLayerDiffIDs reads the app layers, which older lifecycles recorded as a single layer rather than a list.
+func appLayerDiffIDs(app interface{}) ([]string, error) {
+	if app == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(app)
+	if err != nil {
+		return nil, err
+	}
+
+	var layers []files.LayerMetadata
+	if err := json.Unmarshal(data, &layers); err != nil {
+		var layer files.LayerMetadata
+		if err := json.Unmarshal(data, &layer); err != nil {
+			return nil, errors.Wrap(err, "reading app layers")
+		}
+		layers = []files.LayerMetadata{layer}
+	}
+
+	var diffIDs []string
+	for _, layer := range layers {
+		diffIDs = append(diffIDs, layer.SHA)
+	}
+	return diffIDs, nil
+}
+
+// diffStatus returns the status of an element present in the given images and whether it differs at all.
+func diffStatus(inBefore, inAfter, equal bool) (DiffStatus, bool) {
+	switch {
+	case inBefore && !inAfter:
+		return DiffRemoved, true
+	case !inBefore && inAfter:
+		return DiffAdded, true
+	case !equal:
+		return DiffChanged, true
+	default:
+		return "", false
+	}
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	var keys []string
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
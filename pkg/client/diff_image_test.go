@@ -0,0 +1,280 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/lifecycle/launch"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDiffImages(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "DiffImages", testDiffImages, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDiffImages(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		imageA           *fakes.Image
+		imageB           *fakes.Image
+		out              bytes.Buffer
+	)
+
+	newAppImage := func(name, lifecycleMetadata, buildMetadata string) *fakes.Image {
+		img := fakes.NewImage(name, "", nil)
+		h.AssertNil(t, img.SetLabel("io.buildpacks.lifecycle.metadata", lifecycleMetadata))
+		h.AssertNil(t, img.SetLabel("io.buildpacks.build.metadata", buildMetadata))
+		return img
+	}
+
+	it.Before(func() {
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: fakeImageFetcher,
+		}
+
+		imageA = newAppImage("some/app:v1", `{
+  "app": [{"sha": "sha256:app-v1"}],
+  "config": {"sha": "sha256:config"},
+  "launcher": {"sha": "sha256:launcher"},
+  "buildpacks": [
+    {"key": "some/bp", "version": "1.0.0", "layers": {"node": {"sha": "sha256:node-18"}, "cache": {"sha": "sha256:cache"}}},
+    {"key": "old/bp", "version": "0.1.0", "layers": {"tool": {"sha": "sha256:tool"}}}
+  ],
+  "runImage": {"topLayer": "sha256:run-top-v1", "reference": "some/run@sha256:v1"}
+}`, `{
+  "bom": [
+    {"name": "node", "metadata": {"version": "18.0.0"}, "buildpack": {"id": "some/bp", "version": "1.0.0"}},
+    {"name": "tool", "buildpack": {"id": "old/bp", "version": "0.1.0"}}
+  ],
+  "buildpacks": [{"id": "some/bp", "version": "1.0.0"}, {"id": "old/bp", "version": "0.1.0"}],
+  "processes": [
+    {"type": "web", "command": "/start", "args": ["--port", "8080"], "direct": true},
+    {"type": "worker", "command": "/work", "direct": true}
+  ]
+}`)
+		h.AssertNil(t, imageA.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+		h.AssertNil(t, imageA.SetEnv("CNB_PROCESS_TYPE", "web"))
+
+		imageB = newAppImage("some/app:v2", `{
+  "app": [{"sha": "sha256:app-v2"}],
+  "config": {"sha": "sha256:config"},
+  "launcher": {"sha": "sha256:launcher"},
+  "buildpacks": [
+    {"key": "some/bp", "version": "1.1.0", "layers": {"node": {"sha": "sha256:node-20"}, "cache": {"sha": "sha256:cache"}}},
+    {"key": "new/bp", "version": "2.0.0", "layers": {"extra": {"sha": "sha256:extra"}}}
+  ],
+  "runImage": {"topLayer": "sha256:run-top-v2", "reference": "some/run@sha256:v2"}
+}`, `{
+  "bom": [
+    {"name": "node", "metadata": {"version": "20.0.0"}, "buildpack": {"id": "some/bp", "version": "1.1.0"}}
+  ],
+  "buildpacks": [{"id": "some/bp", "version": "1.1.0"}, {"id": "new/bp", "version": "2.0.0"}],
+  "processes": [
+    {"type": "web", "command": "/start", "args": ["--port", "9090"], "direct": true}
+  ]
+}`)
+		h.AssertNil(t, imageB.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+		h.AssertNil(t, imageB.SetEnv("CNB_PROCESS_TYPE", "web"))
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, imageA.Cleanup())
+		h.AssertNilE(t, imageB.Cleanup())
+	})
+
+	when("#DiffImages", func() {
+		it.Before(func() {
+			fakeImageFetcher.LocalImages[imageA.Name()] = imageA
+			fakeImageFetcher.RemoteImages[imageB.Name()] = imageB
+		})
+
+		it("reads each image from the daemon or the registry", func() {
+			diff, err := subject.DiffImages(context.TODO(), "some/app:v1", "some/app:v2")
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, diff.ImageA, "some/app:v1")
+			h.AssertEq(t, diff.ImageB, "some/app:v2")
+			h.AssertEq(t, fakeImageFetcher.FetchCalls["some/app:v1"].Daemon, true)
+			h.AssertEq(t, fakeImageFetcher.FetchCalls["some/app:v2"].Daemon, false)
+		})
+
+		it("reports the run image change", func() {
+			diff, err := subject.DiffImages(context.TODO(), "some/app:v1", "some/app:v2")
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, diff.RunImage, &RunImageDiff{
+				Before: files.RunImageForRebase{TopLayer: "sha256:run-top-v1", Reference: "some/run@sha256:v1"},
+				After:  files.RunImageForRebase{TopLayer: "sha256:run-top-v2", Reference: "some/run@sha256:v2"},
+			})
+		})
+
+		it("reports added, removed and changed buildpacks", func() {
+			diff, err := subject.DiffImages(context.TODO(), "some/app:v1", "some/app:v2")
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, diff.Buildpacks, []BuildpackDiff{
+				{ID: "new/bp", Status: DiffAdded, After: "2.0.0"},
+				{ID: "old/bp", Status: DiffRemoved, Before: "0.1.0"},
+				{ID: "some/bp", Status: DiffChanged, Before: "1.0.0", After: "1.1.0"},
+			})
+		})
+
+		it("reports changed processes", func() {
+			diff, err := subject.DiffImages(context.TODO(), "some/app:v1", "some/app:v2")
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(diff.Processes), 2)
+			h.AssertEq(t, diff.Processes[0].Type, "web")
+			h.AssertEq(t, diff.Processes[0].Status, DiffChanged)
+			h.AssertEq(t, diff.Processes[0].Before.Args, []string{"--port", "8080"})
+			h.AssertEq(t, diff.Processes[0].After.Args, []string{"--port", "9090"})
+			h.AssertEq(t, diff.Processes[1].Type, "worker")
+			h.AssertEq(t, diff.Processes[1].Status, DiffRemoved)
+			h.AssertEq(t, diff.Processes[1].After, (*launch.Process)(nil))
+		})
+
+		it("reports BOM changes", func() {
+			diff, err := subject.DiffImages(context.TODO(), "some/app:v1", "some/app:v2")
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, diff.BOM, []BOMDiff{
+				{Name: "tool", Buildpack: "old/bp", Status: DiffRemoved},
+				{Name: "node", Buildpack: "some/bp", Status: DiffChanged, Before: "18.0.0", After: "20.0.0"},
+			})
+		})
+
+		it("reports changed layers by name", func() {
+			diff, err := subject.DiffImages(context.TODO(), "some/app:v1", "some/app:v2")
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, diff.Layers, []LayerDiff{
+				{Name: "app", Status: DiffChanged, Before: "sha256:app-v1", After: "sha256:app-v2"},
+				{Name: "new/bp:extra", Buildpack: "new/bp", Status: DiffAdded, After: "sha256:extra"},
+				{Name: "old/bp:tool", Buildpack: "old/bp", Status: DiffRemoved, Before: "sha256:tool"},
+				{Name: "some/bp:node", Buildpack: "some/bp", Status: DiffChanged, Before: "sha256:node-18", After: "sha256:node-20"},
+			})
+		})
+
+		when("a buildpack lists several versions of a package", func() {
+			it("compares all versions", func() {
+				imageC := newAppImage("some/app:jdk-v1", `{"buildpacks": [{"key": "some/bp", "version": "1.0.0"}]}`, `{
+  "bom": [
+    {"name": "jdk", "version": "11.0.0", "buildpack": {"id": "some/bp", "version": "1.0.0"}},
+    {"name": "jdk", "version": "17.0.0", "buildpack": {"id": "some/bp", "version": "1.0.0"}},
+    {"name": "jre", "version": "8.0.0", "buildpack": {"id": "some/bp", "version": "1.0.0"}},
+    {"name": "jre", "version": "17.0.0", "buildpack": {"id": "some/bp", "version": "1.0.0"}}
+  ],
+  "buildpacks": [{"id": "some/bp", "version": "1.0.0"}]
+}`)
+				defer imageC.Cleanup()
+				imageD := newAppImage("some/app:jdk-v2", `{"buildpacks": [{"key": "some/bp", "version": "1.0.0"}]}`, `{
+  "bom": [
+    {"name": "jdk", "version": "17.0.0", "buildpack": {"id": "some/bp", "version": "1.0.0"}},
+    {"name": "jdk", "version": "21.0.0", "buildpack": {"id": "some/bp", "version": "1.0.0"}},
+    {"name": "jre", "version": "17.0.0", "buildpack": {"id": "some/bp", "version": "1.0.0"}}
+  ],
+  "buildpacks": [{"id": "some/bp", "version": "1.0.0"}]
+}`)
+				defer imageD.Cleanup()
+				fakeImageFetcher.LocalImages[imageC.Name()] = imageC
+				fakeImageFetcher.LocalImages[imageD.Name()] = imageD
+
+				diff, err := subject.DiffImages(context.TODO(), "some/app:jdk-v1", "some/app:jdk-v2")
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, diff.BOM, []BOMDiff{
+					{Name: "jdk", Buildpack: "some/bp", Status: DiffChanged, Before: "11.0.0", After: "21.0.0"},
+					{Name: "jre", Buildpack: "some/bp", Status: DiffRemoved, Before: "8.0.0"},
+				})
+			})
+		})
+
+		when("the images have an SBOM layer", func() {
+			var tmpDir string
+
+			it.Before(func() {
+				var err error
+				tmpDir, err = os.MkdirTemp("", "pack.diff.image.test.")
+				h.AssertNil(t, err)
+			})
+
+			it.After(func() {
+				h.AssertNil(t, os.RemoveAll(tmpDir))
+			})
+
+			newSBOMImage := func(name, components string) *fakes.Image {
+				layerTar := filepath.Join(tmpDir, strings.ReplaceAll(name, "/", "_")+".tar")
+				h.AssertNil(t, archive.CreateSingleFileTar(layerTar, "layers/sbom/launch/some_bp/sbom.cdx.json",
+					fmt.Sprintf(`{"bomFormat": "CycloneDX", "components": %s}`, components)))
+				data, err := os.ReadFile(layerTar)
+				h.AssertNil(t, err)
+				diffID := fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+
+				img := newAppImage(name,
+					fmt.Sprintf(`{"sbom": {"sha": %q}, "buildpacks": [{"key": "some/bp", "version": "1.0.0"}]}`, diffID),
+					`{"buildpacks": [{"id": "some/bp", "version": "1.0.0"}]}`)
+				h.AssertNil(t, img.AddLayerWithDiffID(layerTar, diffID))
+				fakeImageFetcher.LocalImages[name] = img
+				return img
+			}
+
+			it("reports changes to its packages", func() {
+				imageC := newSBOMImage("some/app:sbom-v1", `[
+  {"type": "library", "name": "openssl", "version": "3.0.2"},
+  {"type": "library", "name": "zlib", "version": "1.2.11"}
+]`)
+				defer imageC.Cleanup()
+				imageD := newSBOMImage("some/app:sbom-v2", `[
+  {"type": "library", "name": "openssl", "version": "3.0.8"},
+  {"type": "library", "name": "zlib", "version": "1.2.11"},
+  {"type": "library", "name": "curl", "version": "8.0.1"}
+]`)
+				defer imageD.Cleanup()
+
+				diff, err := subject.DiffImages(context.TODO(), "some/app:sbom-v1", "some/app:sbom-v2")
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, diff.BOM, []BOMDiff{
+					{Name: "curl", Buildpack: "some/bp", Status: DiffAdded, After: "8.0.1"},
+					{Name: "openssl", Buildpack: "some/bp", Status: DiffChanged, Before: "3.0.2", After: "3.0.8"},
+				})
+			})
+		})
+
+		when("the images are identical", func() {
+			it("returns an empty diff", func() {
+				diff, err := subject.DiffImages(context.TODO(), "some/app:v1", "some/app:v1")
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, diff.Empty(), true)
+			})
+		})
+
+		when("an image cannot be found", func() {
+			it("returns an error", func() {
+				_, err := subject.DiffImages(context.TODO(), "some/app:v1", "missing/app")
+				h.AssertError(t, err, "unable to find image 'missing/app' locally or remotely")
+			})
+		})
+	})
+}
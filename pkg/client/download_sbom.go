@@ -3,6 +3,8 @@ package client
 import (
 	"context"
 
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/lifecycle/buildpack"
 	"github.com/buildpacks/lifecycle/layers"
 	"github.com/buildpacks/lifecycle/platform"
 	"github.com/buildpacks/lifecycle/platform/files"
@@ -15,11 +17,16 @@ import (
 type DownloadSBOMOptions struct {
 	Daemon         bool
 	DestinationDir string
+
+	// Download the SBOMs attached to the image as OCI referrers (see AttachSBOM)
+	// instead of the SBOM layer. The image is always read from its registry.
+	Referrer bool
 }
 
 // Deserialize just the subset of fields we need to avoid breaking changes
 type sbomMetadata struct {
-	BOM *files.LayerMetadata `json:"sbom" toml:"sbom"`
+	BOM        *files.LayerMetadata       `json:"sbom" toml:"sbom"`
+	Buildpacks []buildpack.LayersMetadata `json:"buildpacks" toml:"buildpacks"`
 }
 
 func (s *sbomMetadata) isMissing() bool {
@@ -37,29 +44,53 @@ const (
 // It reads the SBOM metadata of an image then
 // pulls the corresponding diffId, if it exists
 func (c *Client) DownloadSBOM(name string, options DownloadSBOMOptions) error {
-	img, err := c.imageFetcher.Fetch(context.Background(), name, image.FetchOptions{Daemon: options.Daemon, PullPolicy: image.PullNever})
+	if options.Referrer {
+		return c.downloadReferrerSBOM(context.Background(), name, options.DestinationDir)
+	}
+	_, err := c.extractSBOM(context.Background(), name, options.Daemon, options.DestinationDir)
+	return err
+}
+
+// extractSBOM extracts the SBOM layer of an image to destinationDir, and returns the IDs of the buildpacks that built
+// the image
+func (c *Client) extractSBOM(ctx context.Context, name string, daemon bool, destinationDir string) ([]string, error) {
+	img, err := c.imageFetcher.Fetch(ctx, name, image.FetchOptions{Daemon: daemon, PullPolicy: image.PullNever})
 	if err != nil {
 		if errors.Cause(err) == image.ErrNotFound {
 			c.logger.Warnf("if the image is saved on a registry run with the flag '--remote', for example: 'pack sbom download --remote %s'", name)
-			return errors.Wrapf(image.ErrNotFound, "image '%s' cannot be found", name)
+			return nil, errors.Wrapf(image.ErrNotFound, "image '%s' cannot be found", name)
 		}
-		return err
+		return nil, err
 	}
 
 	var sbomMD sbomMetadata
 	if _, err := dist.GetLabel(img, platform.LifecycleMetadataLabel, &sbomMD); err != nil {
-		return err
+		return nil, err
 	}
 
 	if sbomMD.isMissing() {
-		return errors.Errorf("could not find SBoM information on '%s'", name)
+		return nil, errors.Errorf("could not find SBoM information on '%s'", name)
 	}
 
+	return extractSBOMLayer(img, sbomMD, destinationDir)
+}
+
+// extractSBOMLayer extracts the SBOM layer recorded in the metadata of an image to destinationDir, and returns the IDs
+// of the buildpacks that built the image
+func extractSBOMLayer(img imgutil.Image, sbomMD sbomMetadata, destinationDir string) ([]string, error) {
 	rc, err := img.GetLayer(sbomMD.BOM.SHA)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer rc.Close()
 
-	return layers.Extract(rc, options.DestinationDir)
+	if err := layers.Extract(rc, destinationDir); err != nil {
+		return nil, err
+	}
+
+	var buildpackIDs []string
+	for _, bp := range sbomMD.Buildpacks {
+		buildpackIDs = append(buildpackIDs, bp.ID)
+	}
+	return buildpackIDs, nil
 }
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/docker/docker/api/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/egress"
+	"github.com/buildpacks/pack/internal/style"
+	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+)
+
+const (
+	// EgressPolicyAllowAll does not restrict the hosts build containers may contact.
+	EgressPolicyAllowAll = "allow-all"
+
+	// EgressPolicyAllowlist only allows build containers to contact allowed hosts.
+	EgressPolicyAllowlist = "allowlist"
+
+	egressReportFile = "egress.toml"
+)
+
+// EgressPolicy restricts the hosts build containers may contact.
+//
+// The policy is enforced by a proxy run by pack. The detect and build phases, where buildpacks run, are attached to an
+// internal Docker network on which the proxy, listening on the network gateway, is their only route out, and are
+// configured to use it through the standard proxy environment variables. This requires the Docker daemon to run on the
+// local machine. The proxy forwards allowed requests through the proxy configured for the build, if any.
+type EgressPolicy struct {
+	// Restrict egress to Allow. When false, egress is unrestricted regardless of the project descriptor.
+	Restrict bool
+
+	// Hosts build containers may contact, in addition to those in the project descriptor.
+	// A host may start with "*." to allow all of its subdomains.
+	Allow []string
+}
+
+// egressAllowlist returns the hosts build containers may contact, or nil if egress is not restricted.
+func egressAllowlist(policy *EgressPolicy, descriptor projectTypes.Egress) ([]string, error) {
+	switch descriptor.Policy {
+	case "", EgressPolicyAllowAll, EgressPolicyAllowlist:
+	default:
+		return nil, errors.Errorf("invalid egress policy %s in project descriptor, must be one of %s or %s", style.Symbol(descriptor.Policy), EgressPolicyAllowAll, EgressPolicyAllowlist)
+	}
+
+	restrict := descriptor.Policy == EgressPolicyAllowlist
+	if policy != nil {
+		restrict = policy.Restrict
+	}
+	if !restrict {
+		return nil, nil
+	}
+
+	allow := []string{}
+	if policy != nil {
+		allow = append(allow, policy.Allow...)
+	}
+	return append(allow, descriptor.Allow...), nil
+}
+
+// createEgressNetwork creates an internal network for the build containers restricted by an egress policy, and returns
+// its name and the IPv4 address of its gateway, on which the egress proxy must listen to be reachable from it.
+func (c *Client) createEgressNetwork(ctx context.Context) (string, string, error) {
+	if !strings.HasPrefix(c.docker.DaemonHost(), "unix://") {
+		return "", "", errors.Errorf("egress policies require a Docker daemon on the local machine, %s is not local", style.Symbol(c.docker.DaemonHost()))
+	}
+
+	name := "pack-egress-" + randString(10)
+	if _, err := c.docker.NetworkCreate(ctx, name, types.NetworkCreate{
+		Driver:   "bridge",
+		Internal: true,
+		Labels:   map[string]string{"author": "pack"},
+	}); err != nil {
+		return "", "", errors.Wrap(err, "creating egress network")
+	}
+
+	network, err := c.docker.NetworkInspect(ctx, name, types.NetworkInspectOptions{})
+	if err != nil {
+		c.removeEgressNetwork(name)
+		return "", "", errors.Wrap(err, "inspecting egress network")
+	}
+	for _, config := range network.IPAM.Config {
+		if ip := net.ParseIP(config.Gateway); ip != nil && ip.To4() != nil {
+			return name, config.Gateway, nil
+		}
+	}
+
+	c.removeEgressNetwork(name)
+	return "", "", errors.Errorf("egress network %s has no IPv4 gateway", style.Symbol(name))
+}
+
+func (c *Client) removeEgressNetwork(name string) {
+	if err := c.docker.NetworkRemove(context.Background(), name); err != nil {
+		c.logger.Warnf("Unable to remove egress network %s: %s", style.Symbol(name), err)
+	}
+}
+
+// processEgressReport logs the hosts contacted during the build, writes them to reportDir when set, and errors if any
+// were blocked.
+func (c *Client) processEgressReport(report egress.Report, reportDir string) error {
+	if len(report.Contacted) > 0 {
+		c.logger.Infof("Hosts contacted during build: %s", strings.Join(report.Contacted, ", "))
+	}
+
+	if reportDir != "" {
+		if err := os.MkdirAll(reportDir, 0755); err != nil {
+			return errors.Wrap(err, "creating report directory")
+		}
+		f, err := os.Create(filepath.Join(reportDir, egressReportFile))
+		if err != nil {
+			return errors.Wrap(err, "writing egress report")
+		}
+		defer f.Close()
+		if err := toml.NewEncoder(f).Encode(report); err != nil {
+			return errors.Wrap(err, "writing egress report")
+		}
+	}
+
+	if len(report.Blocked) > 0 {
+		return errors.Errorf("build attempted to contact hosts not allowed by the egress policy: %s", strings.Join(report.Blocked, ", "))
+	}
+	return nil
+}
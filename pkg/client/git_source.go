@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	v02 "github.com/buildpacks/pack/pkg/project/v02"
+)
+
+var (
+	gitURLPrefixes = []string{"https://", "http://", "ssh://", "git://", "git@"}
+	commitPattern  = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+)
+
+// gitSource is an app path pointing at a git repository, in the form <url>[#<ref>[:<subdir>]]
+type gitSource struct {
+	URL    string
+	Ref    string
+	Subdir string
+}
+
+// IsGitSource returns true if the app path is the URL of a git repository rather than a local path
+func IsGitSource(appPath string) bool {
+	for _, prefix := range gitURLPrefixes {
+		if strings.HasPrefix(appPath, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseGitSource(appPath string) (gitSource, error) {
+	repoURL, fragment, _ := strings.Cut(appPath, "#")
+	if repoURL == "" {
+		return gitSource{}, errors.Errorf("git source %s has no repository URL", style.Symbol(appPath))
+	}
+	ref, subdir, _ := strings.Cut(fragment, ":")
+	if strings.HasPrefix(ref, "-") {
+		return gitSource{}, errors.Errorf("invalid git ref %s", style.Symbol(ref))
+	}
+	return gitSource{URL: repoURL, Ref: ref, Subdir: subdir}, nil
+}
+
+// redactedURL returns the repository URL without credentials
+func (s gitSource) redactedURL() string {
+	u, err := url.Parse(s.URL)
+	if err != nil || u.User == nil || u.Scheme == "ssh" {
+		return s.URL
+	}
+	u.User = nil
+	return u.String()
+}
+
+// GitCheckout is a git source checked out to a temporary directory
+type GitCheckout struct {
+	// AppPath is the path of the app in the checkout
+	AppPath string
+
+	dir           string
+	projectSource *files.ProjectSource
+}
+
+// Close removes the checkout
+func (g *GitCheckout) Close() error {
+	if g.dir == "" {
+		return nil
+	}
+	return os.RemoveAll(g.dir)
+}
+
+// FetchGitSource checks out the git repository given as app path, in the form <url>[#<ref>[:<subdir>]], to a temporary
+// directory. It allows reading the project descriptor of the app before building it, the checkout may then be passed
+// to Build with the GitCheckout option so that it is not fetched again. The checkout must be closed by the caller.
+func (c *Client) FetchGitSource(ctx context.Context, appPath string) (*GitCheckout, error) {
+	source, err := parseGitSource(appPath)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp("", "pack.git-source.")
+	if err != nil {
+		return nil, errors.Wrap(err, "creating temp directory")
+	}
+
+	localAppPath, projectSource, err := c.fetchGitSource(ctx, source, dir)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, errors.Wrapf(err, "fetching git source %s", style.Symbol(source.redactedURL()))
+	}
+	return &GitCheckout{AppPath: localAppPath, dir: dir, projectSource: projectSource}, nil
+}
+
+// fetchGitSource shallow fetches the ref of the git source into dir and checks it out. Native git is used so that the
+// local credentials, i.e. credential helpers and SSH keys, are used to access the repository. It returns the path of
+// the app in dir and the project metadata of the checkout.
+func (c *Client) fetchGitSource(ctx context.Context, source gitSource, dir string) (string, *files.ProjectSource, error) {
+	git := func(args ...string) error {
+		cmd := exec.CommandContext(ctx, "git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return errors.Wrapf(err, "running git %s: %s", args[0], strings.TrimSpace(string(output)))
+		}
+		return nil
+	}
+
+	ref := source.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	c.logger.Infof("Fetching %s from %s", style.Symbol(ref), style.Symbol(source.redactedURL()))
+	if err := git("init", "--quiet"); err != nil {
+		return "", nil, err
+	}
+	if err := git("fetch", "--quiet", "--depth", "1", "--", source.URL, ref); err != nil {
+		// servers may refuse to serve commits that are not the tip of a ref, so fetch all of them instead
+		if !commitPattern.MatchString(ref) {
+			return "", nil, errors.Wrapf(err, "fetching %s", style.Symbol(ref))
+		}
+		c.logger.Debugf("Fetching commit %s directly failed, fetching the whole repository", style.Symbol(ref))
+		if err := git("fetch", "--quiet", "--tags", "--", source.URL, "+refs/heads/*:refs/remotes/origin/*"); err != nil {
+			return "", nil, errors.Wrapf(err, "fetching %s", style.Symbol(ref))
+		}
+		if err := git("checkout", "--quiet", ref, "--"); err != nil {
+			return "", nil, errors.Wrapf(err, "checking out %s", style.Symbol(ref))
+		}
+	} else if err := git("checkout", "--quiet", "FETCH_HEAD"); err != nil {
+		return "", nil, errors.Wrapf(err, "checking out %s", style.Symbol(ref))
+	}
+	if err := git("remote", "add", "origin", source.redactedURL()); err != nil {
+		return "", nil, err
+	}
+
+	appPath := filepath.Join(dir, filepath.Clean(string(filepath.Separator)+source.Subdir))
+	if fi, err := os.Stat(appPath); err != nil || !fi.IsDir() {
+		return "", nil, errors.Errorf("subdirectory %s does not exist in %s", style.Symbol(source.Subdir), style.Symbol(source.redactedURL()))
+	}
+
+	projectSource := v02.GitMetadata(dir)
+	if projectSource == nil {
+		return "", nil, errors.Errorf("reading git metadata of %s", style.Symbol(source.redactedURL()))
+	}
+	return appPath, projectSource, nil
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"errors"
 
 	pubbldr "github.com/buildpacks/pack/builder"
@@ -50,6 +51,10 @@ type BuilderInfo struct {
 
 	// Detailed ordering of extensions.
 	OrderExtensions pubbldr.DetectionOrder
+
+	// How up to date the images, lifecycle and buildpacks of the builder are.
+	// Only set when inspected WithFreshness.
+	Freshness *BuilderFreshness
 }
 
 // BuildpackInfoKey contains all information needed to determine buildpack equivalence.
@@ -60,6 +65,8 @@ type BuildpackInfoKey struct {
 
 type BuilderInspectionConfig struct {
 	OrderDetectionDepth int
+	Freshness           bool
+	RegistryNames       []string
 }
 
 type BuilderInspectionModifier func(config *BuilderInspectionConfig)
@@ -70,6 +77,15 @@ func WithDetectionOrderDepth(depth int) BuilderInspectionModifier {
 	}
 }
 
+// WithFreshness checks the builder for newer images, lifecycle and buildpacks. Buildpacks are looked up in the
+// buildpack registries with the given names, in order.
+func WithFreshness(registryNames []string) BuilderInspectionModifier {
+	return func(config *BuilderInspectionConfig) {
+		config.Freshness = true
+		config.RegistryNames = registryNames
+	}
+}
+
 // InspectBuilder reads label metadata of a local or remote builder image. It initializes a BuilderInfo
 // object with this metadata, and returns it. This method will error if the name image cannot be found
 // both locally and remotely, or if the found image does not contain the proper labels.
@@ -93,6 +109,11 @@ func (c *Client) InspectBuilder(name string, daemon bool, modifiers ...BuilderIn
 		return nil, err
 	}
 
+	var freshness *BuilderFreshness
+	if inspectionConfig.Freshness {
+		freshness = c.builderFreshness(context.Background(), name, daemon, info, inspectionConfig.RegistryNames)
+	}
+
 	return &BuilderInfo{
 		Description:     info.Description,
 		Stack:           info.StackID,
@@ -105,5 +126,6 @@ func (c *Client) InspectBuilder(name string, daemon bool, modifiers ...BuilderIn
 		CreatedBy:       info.CreatedBy,
 		Extensions:      info.Extensions,
 		OrderExtensions: info.OrderExtensions,
+		Freshness:       freshness,
 	}, nil
 }
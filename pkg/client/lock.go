@@ -0,0 +1,325 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/project"
+	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+)
+
+// ResolveLock resolves the builder, run image and declared buildpacks and extensions of a build to exact digests and
+// checksums, without building. Buildpacks from the builder and buildpacks in local directories are not locked.
+func (c *Client) ResolveLock(ctx context.Context, opts BuildOptions) (project.Lock, error) {
+	builderRef, err := c.processBuilderName(opts.Builder)
+	if err != nil {
+		return project.Lock{}, errors.Wrapf(err, "invalid builder '%s'", opts.Builder)
+	}
+
+	rawBuilderImage, err := c.imageFetcher.Fetch(ctx, builderRef.Name(), image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy})
+	if err != nil {
+		return project.Lock{}, errors.Wrapf(err, "failed to fetch builder image '%s'", builderRef.Name())
+	}
+
+	bldr, err := c.getBuilder(rawBuilderImage)
+	if err != nil {
+		return project.Lock{}, errors.Wrapf(err, "invalid builder %s", style.Symbol(opts.Builder))
+	}
+
+	builderDigest, err := c.imageDigest(ctx, builderRef.Name())
+	if err != nil {
+		return project.Lock{}, err
+	}
+
+	var imgRegistry string
+	if opts.Image != "" {
+		imageRef, err := c.parseReference(opts)
+		if err != nil {
+			return project.Lock{}, errors.Wrapf(err, "invalid image name '%s'", opts.Image)
+		}
+		imgRegistry = imageRef.Context().RegistryStr()
+	}
+
+	runImageName := c.resolveRunImage(opts.RunImage, imgRegistry, builderRef.Context().RegistryStr(), bldr.DefaultRunImage(), opts.AdditionalMirrors, opts.Publish, c.accessChecker)
+	runImageDigest, err := c.imageDigest(ctx, runImageName)
+	if err != nil {
+		return project.Lock{}, err
+	}
+
+	lock := project.Lock{
+		Version:  project.LockVersion,
+		Builder:  project.LockedImage{Name: opts.Builder, Digest: builderDigest},
+		RunImage: project.LockedImage{Name: runImageName, Digest: runImageDigest},
+	}
+
+	buildpacks, relativeBaseDir, err := declaredBuildpacks(opts, bldr.StackID)
+	if err != nil {
+		return project.Lock{}, err
+	}
+	for _, declared := range []struct {
+		locators        []string
+		relativeBaseDir string
+		builtIn         []dist.ModuleInfo
+	}{
+		{buildpacks, relativeBaseDir, bldr.Buildpacks()},
+		{opts.Extensions, opts.RelativeBaseDir, bldr.Extensions()},
+	} {
+		for _, locator := range declared.locators {
+			locked, ok, err := c.lockBuildpack(ctx, locator, declared.relativeBaseDir, declared.builtIn, opts.Registry)
+			if err != nil {
+				return project.Lock{}, errors.Wrapf(err, "locking %s", style.Symbol(locator))
+			}
+			if ok {
+				lock.Buildpacks = append(lock.Buildpacks, locked)
+			}
+		}
+	}
+
+	return lock, nil
+}
+
+// declaredBuildpacks returns the buildpack locators declared on the command line or, if there are none, in the project
+// descriptor, along with the directory relative locators are resolved from. Inline buildpacks are not included.
+func declaredBuildpacks(opts BuildOptions, stackID string) ([]string, string, error) {
+	descriptor := opts.ProjectDescriptor.Build
+	relativeBaseDir := opts.RelativeBaseDir
+	if len(opts.Buildpacks) == 0 && len(descriptor.Buildpacks) != 0 {
+		relativeBaseDir = opts.ProjectDescriptorBaseDir
+	}
+
+	var locators []string
+	for _, declared := range []struct {
+		flags      []string
+		descriptor []projectTypes.Buildpack
+	}{
+		{opts.Buildpacks, descriptor.Buildpacks},
+		{opts.PreBuildpacks, descriptor.Pre.Buildpacks},
+		{opts.PostBuildpacks, descriptor.Post.Buildpacks},
+	} {
+		if len(declared.flags) != 0 {
+			locators = append(locators, declared.flags...)
+			continue
+		}
+
+		for _, bp := range declared.descriptor {
+			if bp.Script.Inline != "" {
+				continue
+			}
+			locator, err := getBuildpackLocator(bp, stackID)
+			if err != nil {
+				return nil, "", err
+			}
+			locators = append(locators, locator)
+		}
+	}
+	return locators, relativeBaseDir, nil
+}
+
+// lockBuildpack resolves a buildpack locator to a digest or checksum. It returns false if the locator does not need
+// to be locked.
+func (c *Client) lockBuildpack(ctx context.Context, locator, relativeBaseDir string, builtIn []dist.ModuleInfo, registryName string) (project.LockedBuildpack, bool, error) {
+	locatorType, err := buildpack.GetLocatorType(locator, relativeBaseDir, builtIn)
+	if err != nil {
+		return project.LockedBuildpack{}, false, err
+	}
+
+	locked := project.LockedBuildpack{Locator: locator}
+	switch locatorType {
+	case buildpack.RegistryLocator:
+		index, err := getRegistryIndex(ctx, c.logger, c.keychain, registryName)
+		if err != nil {
+			return project.LockedBuildpack{}, false, errors.Wrapf(err, "lookup registry %s", style.Symbol(registryName))
+		}
+		registryBp, err := index.LocateBuildpack(locator)
+		if err != nil {
+			return project.LockedBuildpack{}, false, err
+		}
+		locked.ID = registryBp.Namespace + "/" + registryBp.Name
+		locked.Version = registryBp.Version
+		locked.Image = registryBp.Address
+	case buildpack.PackageLocator:
+		imageName := buildpack.ParsePackageLocator(locator)
+		ref, err := name.ParseReference(imageName, name.WeakValidation)
+		if err != nil {
+			return project.LockedBuildpack{}, false, err
+		}
+		digest, err := c.imageDigest(ctx, imageName)
+		if err != nil {
+			return project.LockedBuildpack{}, false, err
+		}
+		locked.Image = ref.Context().Name() + "@" + digest
+	case buildpack.URILocator:
+		uri, err := paths.FilePathToURI(locator, relativeBaseDir)
+		if err != nil {
+			return project.LockedBuildpack{}, false, errors.Wrapf(err, "making absolute: %s", style.Symbol(locator))
+		}
+		if isLocalDirectory(uri) {
+			return project.LockedBuildpack{}, false, nil
+		}
+		checksum, err := c.uriChecksum(ctx, uri)
+		if err != nil {
+			return project.LockedBuildpack{}, false, err
+		}
+		locked.URI = uri
+		locked.SHA256 = checksum
+	default:
+		return project.LockedBuildpack{}, false, nil
+	}
+	return locked, true, nil
+}
+
+// lockedImageName returns the image pinned by locked if it was resolved from requested, and requested otherwise. It
+// warns when requested has moved on in its registry since it was locked.
+func (c *Client) lockedImageName(ctx context.Context, locked project.LockedImage, requested, kind string) string {
+	if locked.Name != requested || locked.Digest == "" {
+		c.logger.Warnf("The %s %s is not locked in %s (locked %s is %s). Run %s to update it.",
+			kind, style.Symbol(requested), project.LockFileName, kind, style.Symbol(locked.Name), style.Symbol("pack lock"))
+		return requested
+	}
+
+	if ref, err := name.ParseReference(requested, name.WeakValidation); err == nil {
+		if _, isDigest := ref.(name.Digest); !isDigest {
+			digest, err := c.remoteImageDigest(ctx, ref)
+			switch {
+			case err != nil:
+				c.logger.Debugf("Unable to check whether %s %s changed since it was locked: %s", kind, style.Symbol(requested), err)
+			case digest != locked.Digest:
+				c.logger.Warnf("The %s %s now resolves to %s, but is locked to %s in %s. Run %s to update it.",
+					kind, style.Symbol(requested), style.Symbol(digest), style.Symbol(locked.Digest), project.LockFileName, style.Symbol("pack lock"))
+			}
+		}
+	}
+
+	c.logger.Debugf("Using %s %s locked to %s", kind, style.Symbol(requested), style.Symbol(locked.Digest))
+	return locked.Reference()
+}
+
+// lockedLocator returns the locator that pins the buildpack declared with locator, verifying the checksum of buildpacks
+// downloaded from a URI. Locators that are not in the lock are returned unchanged.
+func (c *Client) lockedLocator(ctx context.Context, lock *project.Lock, locator string, locatorType buildpack.LocatorType, relativeBaseDir string) (string, error) {
+	if lock == nil {
+		return locator, nil
+	}
+
+	locked, ok := lock.FindBuildpack(locator)
+	if !ok {
+		switch locatorType {
+		case buildpack.RegistryLocator, buildpack.PackageLocator, buildpack.URILocator:
+			if uri, err := paths.FilePathToURI(locator, relativeBaseDir); err == nil && isLocalDirectory(uri) {
+				return locator, nil
+			}
+			c.logger.Warnf("Buildpack %s is not locked in %s. Run %s to update it.", style.Symbol(locator), project.LockFileName, style.Symbol("pack lock"))
+		}
+		return locator, nil
+	}
+
+	switch {
+	case locked.Image != "":
+		c.logger.Debugf("Using buildpack %s locked to %s", style.Symbol(locator), style.Symbol(locked.Image))
+		return "docker://" + locked.Image, nil
+	case locked.SHA256 != "":
+		checksum, err := c.uriChecksum(ctx, locked.URI)
+		if err != nil {
+			return "", err
+		}
+		if checksum != locked.SHA256 {
+			return "", errors.Errorf("checksum of buildpack %s does not match %s: expected %s, got %s",
+				style.Symbol(locator), project.LockFileName, locked.SHA256, checksum)
+		}
+		return locked.URI, nil
+	}
+	return locator, nil
+}
+
+// imageDigest returns the digest of the manifest imageName refers to in its registry or, for images that are not in
+// a registry, the digest recorded by the daemon when the image was pulled.
+func (c *Client) imageDigest(ctx context.Context, imageName string) (string, error) {
+	ref, err := name.ParseReference(imageName, name.WeakValidation)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing image name %s", style.Symbol(imageName))
+	}
+	if digest, ok := ref.(name.Digest); ok {
+		return digest.DigestStr(), nil
+	}
+
+	digest, err := c.remoteImageDigest(ctx, ref)
+	if err == nil {
+		return digest, nil
+	}
+	if digest, ok := c.localImageDigest(ctx, ref); ok {
+		return digest, nil
+	}
+	return "", errors.Wrapf(err, "resolving digest of %s", style.Symbol(imageName))
+}
+
+// remoteImageDigest returns the digest of the manifest ref points to in its registry
+func (c *Client) remoteImageDigest(ctx context.Context, ref name.Reference) (string, error) {
+	desc, err := remote.Head(ref, c.remoteOptions(ctx)...)
+	if err != nil {
+		return "", err
+	}
+	return desc.Digest.String(), nil
+}
+
+// localImageDigest returns the digest recorded by the daemon when the image ref refers to was pulled
+func (c *Client) localImageDigest(ctx context.Context, ref name.Reference) (string, bool) {
+	if c.docker == nil {
+		return "", false
+	}
+
+	inspect, _, err := c.docker.ImageInspectWithRaw(ctx, ref.String())
+	if err != nil {
+		return "", false
+	}
+	for _, repoDigest := range inspect.RepoDigests {
+		digest, err := name.NewDigest(repoDigest, name.WeakValidation)
+		if err == nil && digest.Context().Name() == ref.Context().Name() {
+			return digest.DigestStr(), true
+		}
+	}
+	return "", false
+}
+
+// uriChecksum returns the sha256 checksum of the blob at uri
+func (c *Client) uriChecksum(ctx context.Context, uri string) (string, error) {
+	blob, err := c.downloader.Download(ctx, uri)
+	if err != nil {
+		return "", errors.Wrapf(err, "downloading %s", style.Symbol(uri))
+	}
+
+	rc, err := blob.Open()
+	if err != nil {
+		return "", errors.Wrapf(err, "reading %s", style.Symbol(uri))
+	}
+	defer rc.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, rc); err != nil {
+		return "", errors.Wrapf(err, "reading %s", style.Symbol(uri))
+	}
+	return fmt.Sprintf("sha256:%x", hash.Sum(nil)), nil
+}
+
+func isLocalDirectory(uri string) bool {
+	if !strings.HasPrefix(uri, "file://") {
+		return false
+	}
+	path, err := paths.URIToFilePath(uri)
+	if err != nil {
+		return false
+	}
+	isDir, err := paths.IsDir(path)
+	return err == nil && isDir
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/builder"
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/project"
+	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestResolveLock(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ResolveLock", testResolveLock, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testResolveLock(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		server           *h.RegistryIndexServer
+		tmpDir           string
+		builderName      string
+		runImageName     string
+		out              bytes.Buffer
+	)
+
+	push := func(imageName string) string {
+		ref, err := name.ParseReference(imageName)
+		h.AssertNil(t, err)
+		img, err := random.Image(1024, 1)
+		h.AssertNil(t, err)
+		h.AssertNil(t, remote.Write(ref, img))
+		digest, err := img.Digest()
+		h.AssertNil(t, err)
+		return digest.String()
+	}
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "resolve-lock")
+		h.AssertNil(t, err)
+
+		server = h.NewRegistryIndexServer("", "")
+		builderName = server.Host() + "/some/builder:latest"
+		runImageName = server.Host() + "/some/run:latest"
+
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		fakeImageFetcher.LocalImages[builderName] = newFakeBuilderImage(t, tmpDir, builderName, "some.stack.id", runImageName, builder.DefaultLifecycleVersion, newLinuxImage)
+
+		logger := logging.NewLogWithWriters(&out, &out)
+		subject = &Client{
+			logger:        logger,
+			imageFetcher:  fakeImageFetcher,
+			downloader:    blob.NewDownloader(logger, filepath.Join(tmpDir, "dl-cache")),
+			accessChecker: ifakes.NewFakeAccessChecker(),
+			keychain:      authn.DefaultKeychain,
+		}
+	})
+
+	it.After(func() {
+		server.Close()
+		h.AssertNilE(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#ResolveLock", func() {
+		it("locks the builder, run image and buildpacks to digests and checksums", func() {
+			builderDigest := push(builderName)
+			runImageDigest := push(runImageName)
+			packageDigest := push(server.Host() + "/some/buildpack:1.0.0")
+			buildpackTgz := h.CreateTGZ(t, filepath.Join("testdata", "buildpack2"), "./", 0755)
+			defer os.Remove(buildpackTgz)
+
+			lock, err := subject.ResolveLock(context.TODO(), BuildOptions{
+				Builder: builderName,
+				Buildpacks: []string{
+					"buildpack.1.id@buildpack.1.version",
+					filepath.Join("testdata", "buildpack"),
+					"docker://" + server.Host() + "/some/buildpack:1.0.0",
+					buildpackTgz,
+				},
+			})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, lock.Builder, project.LockedImage{Name: builderName, Digest: builderDigest})
+			h.AssertEq(t, lock.RunImage, project.LockedImage{Name: runImageName, Digest: runImageDigest})
+			h.AssertEq(t, len(lock.Buildpacks), 2)
+			h.AssertEq(t, lock.Buildpacks[0], project.LockedBuildpack{
+				Locator: "docker://" + server.Host() + "/some/buildpack:1.0.0",
+				Image:   server.Host() + "/some/buildpack@" + packageDigest,
+			})
+			h.AssertEq(t, lock.Buildpacks[1].Locator, buildpackTgz)
+			h.AssertContains(t, lock.Buildpacks[1].URI, "file://")
+			h.AssertContains(t, lock.Buildpacks[1].SHA256, "sha256:")
+		})
+
+		it("locks buildpacks declared in the project descriptor", func() {
+			push(builderName)
+			push(runImageName)
+			packageDigest := push(server.Host() + "/some/buildpack:1.0.0")
+
+			lock, err := subject.ResolveLock(context.TODO(), BuildOptions{
+				Builder: builderName,
+				ProjectDescriptor: projectTypes.Descriptor{
+					Build: projectTypes.Build{
+						Buildpacks: []projectTypes.Buildpack{
+							{URI: server.Host() + "/some/buildpack:1.0.0"},
+							{ID: "inline", Script: projectTypes.Script{API: api.MustParse("0.10").String(), Inline: "echo hi"}},
+						},
+					},
+				},
+			})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, lock.Buildpacks, []project.LockedBuildpack{{
+				Locator: server.Host() + "/some/buildpack:1.0.0",
+				Image:   server.Host() + "/some/buildpack@" + packageDigest,
+			}})
+		})
+
+		it("fails if an image is not in a registry", func() {
+			_, err := subject.ResolveLock(context.TODO(), BuildOptions{Builder: builderName})
+			h.AssertError(t, err, "resolving digest of '"+builderName+"'")
+		})
+	})
+
+	when("#lockedImageName", func() {
+		it("returns the locked image", func() {
+			digest := push(builderName)
+
+			imageName := subject.lockedImageName(context.TODO(), project.LockedImage{Name: builderName, Digest: digest}, builderName, "builder")
+			h.AssertEq(t, imageName, server.Host()+"/some/builder@"+digest)
+			h.AssertNotContains(t, out.String(), "Warning")
+		})
+
+		it("warns when the requested image changed since it was locked", func() {
+			lockedDigest := push(builderName)
+			digest := push(builderName)
+
+			imageName := subject.lockedImageName(context.TODO(), project.LockedImage{Name: builderName, Digest: lockedDigest}, builderName, "builder")
+			h.AssertEq(t, imageName, server.Host()+"/some/builder@"+lockedDigest)
+			h.AssertContains(t, out.String(), "Warning: The builder '"+builderName+"' now resolves to '"+digest+"', but is locked to '"+lockedDigest+"' in project.lock")
+		})
+
+		it("uses the locked image when the requested image is not in a registry", func() {
+			lockedDigest := "sha256:" + strings.Repeat("a", 64)
+
+			imageName := subject.lockedImageName(context.TODO(), project.LockedImage{Name: builderName, Digest: lockedDigest}, builderName, "builder")
+			h.AssertEq(t, imageName, server.Host()+"/some/builder@"+lockedDigest)
+			h.AssertNotContains(t, out.String(), "Warning")
+		})
+	})
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/pkg/sbom"
+)
+
+// MergeSBOMOptions describes the SBOMs to merge.
+type MergeSBOMOptions struct {
+	// Name of an app image whose launch SBOMs are merged.
+	Image string
+
+	// Whether to read Image from the daemon rather than its registry.
+	Daemon bool
+
+	// Directories containing SBOMs in the layout written by the lifecycle,
+	// e.g. the output of `pack sbom download` or `pack build --sbom-output-dir`.
+	Dirs []string
+}
+
+// MergeSBOM combines the launch, build and per-buildpack SBOMs of an image and/or SBOM directories
+// into a single document.
+func (c *Client) MergeSBOM(ctx context.Context, opts MergeSBOMOptions) (*sbom.Document, error) {
+	if opts.Image == "" && len(opts.Dirs) == 0 {
+		return nil, errors.New("an image or SBOM directory must be specified")
+	}
+
+	var (
+		dirs         = opts.Dirs
+		buildpackIDs []string
+	)
+	if opts.Image != "" {
+		tmpDir, err := os.MkdirTemp("", "pack.sbom.")
+		if err != nil {
+			return nil, errors.Wrap(err, "creating temporary directory")
+		}
+		defer os.RemoveAll(tmpDir)
+
+		if buildpackIDs, err = c.extractSBOM(ctx, opts.Image, opts.Daemon, tmpDir); err != nil {
+			return nil, err
+		}
+		dirs = append([]string{tmpDir}, dirs...)
+	}
+
+	var docs []*sbom.Document
+	for _, dir := range dirs {
+		dirDocs, err := sbom.ReadDir(dir, buildpackIDs...)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, dirDocs...)
+	}
+
+	return sbom.Merge(opts.Image, docs...), nil
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/sbom"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestMergeSBOM(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "MergeSBOM", testMergeSBOM, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testMergeSBOM(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockImageFetcher *testmocks.MockImageFetcher
+		mockController   *gomock.Controller
+		tmpDir           string
+		out              bytes.Buffer
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+
+		var err error
+		subject, err = NewClient(WithLogger(logging.NewLogWithWriters(&out, &out)), WithFetcher(mockImageFetcher), WithDockerClient(testmocks.NewMockCommonAPIClient(mockController)))
+		h.AssertNil(t, err)
+
+		tmpDir, err = os.MkdirTemp("", "pack.merge.sbom.test.")
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#MergeSBOM", func() {
+		it("merges the launch SBOMs of the image with SBOM directories, attributed to the buildpacks of the image", func() {
+			layerTar := filepath.Join(tmpDir, "sbom.tar")
+			h.AssertNil(t, archive.CreateSingleFileTar(layerTar, "layers/sbom/launch/some_bp/sbom.cdx.json",
+				`{"bomFormat": "CycloneDX", "components": [{"type": "library", "name": "openssl", "version": "3.0.2"}]}`))
+			data, err := os.ReadFile(layerTar)
+			h.AssertNil(t, err)
+			hsh := sha256.Sum256(data)
+			shasum := hex.EncodeToString(hsh[:])
+
+			mockImage := testmocks.NewImage("some/image", "", nil)
+			mockImage.AddLayerWithDiffID(layerTar, "sha256:"+shasum)
+			h.AssertNil(t, mockImage.SetLabel("io.buildpacks.lifecycle.metadata", fmt.Sprintf(`{"sbom": {"sha": "sha256:%s"}, "buildpacks": [{"key": "some/bp", "version": "1.0.0"}]}`, shasum)))
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/image", image.FetchOptions{Daemon: false, PullPolicy: image.PullNever}).Return(mockImage, nil)
+
+			buildDir := filepath.Join(tmpDir, "sbom-output")
+			h.AssertNil(t, os.MkdirAll(filepath.Join(buildDir, "build", "some_bp"), 0755))
+			h.AssertNil(t, os.WriteFile(filepath.Join(buildDir, "build", "some_bp", "sbom.syft.json"),
+				[]byte(`{"artifacts": [{"name": "gcc", "version": "11.2.0"}]}`), 0600))
+
+			doc, err := subject.MergeSBOM(context.TODO(), MergeSBOMOptions{Image: "some/image", Dirs: []string{buildDir}})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, doc, &sbom.Document{
+				Name: "some/image",
+				Packages: []sbom.Package{
+					{Name: "openssl", Version: "3.0.2", Type: "library", Buildpack: "some/bp", Scope: sbom.ScopeLaunch},
+					{Name: "gcc", Version: "11.2.0", Buildpack: "some/bp", Scope: sbom.ScopeBuild},
+				},
+			})
+		})
+
+		it("requires an image or directory", func() {
+			_, err := subject.MergeSBOM(context.TODO(), MergeSBOMOptions{})
+			h.AssertError(t, err, "an image or SBOM directory must be specified")
+		})
+	})
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const ociSourcePrefix = "oci://"
+
+// IsOCISource returns true if the app path is a reference to an OCI artifact, in the form oci://<reference>
+func IsOCISource(appPath string) bool {
+	return strings.HasPrefix(appPath, ociSourcePrefix)
+}
+
+// fetchOCISource pulls the OCI artifact the app path refers to and writes the contents of its layers, as a single tar,
+// to dir. It returns the path of the tar.
+func (c *Client) fetchOCISource(ctx context.Context, appPath, dir string) (string, error) {
+	ref, err := name.ParseReference(strings.TrimPrefix(appPath, ociSourcePrefix), name.WeakValidation)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid OCI artifact reference %s", style.Symbol(appPath))
+	}
+
+	c.logger.Infof("Pulling source from %s", style.Symbol(ref.Name()))
+	artifact, err := remote.Image(ref, c.remoteOptions(ctx)...)
+	if err != nil {
+		return "", errors.Wrapf(err, "pulling %s", style.Symbol(ref.Name()))
+	}
+
+	// artifacts pushed as plain files, for example with 'oras push', don't have tar layers to extract
+	layers, err := artifact.Layers()
+	if err != nil {
+		return "", errors.Wrapf(err, "reading layers of %s", style.Symbol(ref.Name()))
+	}
+	for _, layer := range layers {
+		mediaType, err := layer.MediaType()
+		if err != nil {
+			return "", errors.Wrapf(err, "reading layers of %s", style.Symbol(ref.Name()))
+		}
+		if !mediaType.IsLayer() {
+			return "", errors.Errorf("layer of %s has media type %s, only tar layers are supported for source artifacts", style.Symbol(ref.Name()), style.Symbol(string(mediaType)))
+		}
+	}
+
+	tarPath := filepath.Join(dir, "source.tar")
+	f, err := os.Create(tarPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	rc := mutate.Extract(artifact)
+	defer rc.Close()
+	if _, err := io.Copy(f, rc); err != nil {
+		return "", errors.Wrapf(err, "reading layers of %s", style.Symbol(ref.Name()))
+	}
+
+	return tarPath, nil
+}
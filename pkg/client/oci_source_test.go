@@ -0,0 +1,103 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestOCISource(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "OCISource", testOCISource, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testOCISource(t *testing.T, when spec.G, it spec.S) {
+	when("#IsOCISource", func() {
+		it("returns true for OCI artifact references", func() {
+			h.AssertEq(t, IsOCISource("oci://registry.example.com/org/source:v1"), true)
+		})
+
+		it("returns false for local paths and OCI layouts", func() {
+			h.AssertEq(t, IsOCISource("app.tgz"), false)
+			h.AssertEq(t, IsOCISource("oci:./layout"), false)
+		})
+	})
+
+	when("#fetchOCISource", func() {
+		var (
+			subject *Client
+			server  *h.RegistryIndexServer
+			tmpDir  string
+			out     bytes.Buffer
+		)
+
+		it.Before(func() {
+			var err error
+			tmpDir, err = os.MkdirTemp("", "oci-source")
+			h.AssertNil(t, err)
+			server = h.NewRegistryIndexServer("", "")
+
+			subject = &Client{
+				logger:   logging.NewLogWithWriters(&out, &out),
+				keychain: authn.DefaultKeychain,
+			}
+		})
+
+		it.After(func() {
+			server.Close()
+			h.AssertNilE(t, os.RemoveAll(tmpDir))
+		})
+
+		it("writes the contents of the artifact to a tar", func() {
+			layer, err := tarball.LayerFromReader(archive.CreateSingleFileTarReader("app/main.go", "package main"))
+			h.AssertNil(t, err)
+			artifact, err := mutate.AppendLayers(empty.Image, layer)
+			h.AssertNil(t, err)
+			ref, err := name.ParseReference(server.Host() + "/org/source:v1")
+			h.AssertNil(t, err)
+			h.AssertNil(t, remote.Write(ref, artifact))
+
+			tarPath, err := subject.fetchOCISource(context.TODO(), "oci://"+server.Host()+"/org/source:v1", tmpDir)
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, tarPath, filepath.Join(tmpDir, "source.tar"))
+			h.AssertOnTarEntry(t, tarPath, "app/main.go", h.ContentEquals("package main"))
+		})
+
+		it("fails for an artifact with non-tar layers", func() {
+			layer := static.NewLayer([]byte("package main"), types.MediaType("application/vnd.oci.image.layer.v1.go"))
+			artifact, err := mutate.AppendLayers(empty.Image, layer)
+			h.AssertNil(t, err)
+			ref, err := name.ParseReference(server.Host() + "/org/source:file")
+			h.AssertNil(t, err)
+			h.AssertNil(t, remote.Write(ref, artifact))
+
+			_, err = subject.fetchOCISource(context.TODO(), "oci://"+server.Host()+"/org/source:file", tmpDir)
+			h.AssertError(t, err, "has media type 'application/vnd.oci.image.layer.v1.go', only tar layers are supported")
+		})
+
+		it("fails for a missing artifact", func() {
+			_, err := subject.fetchOCISource(context.TODO(), "oci://"+server.Host()+"/org/missing:v1", tmpDir)
+			h.AssertError(t, err, "pulling")
+		})
+	})
+}
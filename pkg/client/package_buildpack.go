@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 
 	pubbldpkg "github.com/buildpacks/pack/buildpackage"
 	"github.com/buildpacks/pack/internal/layer"
@@ -12,6 +13,7 @@ import (
 	"github.com/buildpacks/pack/pkg/blob"
 	"github.com/buildpacks/pack/pkg/buildpack"
 	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/tracing"
 )
 
 const (
@@ -21,6 +23,15 @@ const (
 	// Packaging indicator that format of output will be a file on the host filesystem.
 	FormatFile = "file"
 
+	// Indicator that format of output will be an OCI image layout directory on the host filesystem.
+	FormatOCILayout = "oci-layout"
+
+	// Indicator that format of output will be a tar archive of an OCI image layout on the host filesystem.
+	FormatOCIArchive = "oci-archive"
+
+	// Indicator that format of output will be a tar archive that can be loaded with `docker load`.
+	FormatDockerArchive = "docker-archive"
+
 	// CNBExtension is the file extension for a cloud native buildpack tar archive
 	CNBExtension = ".cnb"
 )
@@ -34,7 +45,7 @@ type PackageBuildpackOptions struct {
 	// The name of the output buildpack artifact.
 	Name string
 
-	// Type of output format, The options are the either the const FormatImage, or FormatFile.
+	// Type of output format, The options are the either the const FormatImage, FormatFile, or FormatOCILayout.
 	Format string
 
 	// Defines the Buildpacks configuration.
@@ -62,7 +73,14 @@ type PackageBuildpackOptions struct {
 }
 
 // PackageBuildpack packages buildpack(s) into either an image or file.
-func (c *Client) PackageBuildpack(ctx context.Context, opts PackageBuildpackOptions) error {
+func (c *Client) PackageBuildpack(ctx context.Context, opts PackageBuildpackOptions) (err error) {
+	ctx, span := c.startSpan(ctx, "pack.package_buildpack",
+		attribute.String("pack.package", opts.Name),
+		attribute.String("pack.package.format", opts.Format),
+		attribute.Bool("pack.publish", opts.Publish),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	if opts.Format == "" {
 		opts.Format = FormatImage
 	}
@@ -71,8 +89,7 @@ func (c *Client) PackageBuildpack(ctx context.Context, opts PackageBuildpackOpti
 		return NewExperimentError("Windows buildpackage support is currently experimental.")
 	}
 
-	err := c.validateOSPlatform(ctx, opts.Config.Platform.OS, opts.Publish, opts.Format)
-	if err != nil {
+	if err = c.validateOSPlatform(ctx, opts.Config.Platform.OS, opts.Publish, opts.Format); err != nil {
 		return err
 	}
 
@@ -125,8 +142,13 @@ func (c *Client) PackageBuildpack(ctx context.Context, opts PackageBuildpackOpti
 	switch opts.Format {
 	case FormatFile:
 		return packageBuilder.SaveAsFile(opts.Name, opts.Config.Platform.OS, opts.Labels)
+	case FormatOCILayout:
+		return packageBuilder.SaveAsLayout(opts.Name, opts.Config.Platform.OS, opts.Labels)
 	case FormatImage:
-		_, err = packageBuilder.SaveAsImage(opts.Name, opts.Publish, opts.Config.Platform.OS, opts.Labels)
+		err = traceSave(ctx, opts.Name, opts.Publish, func() error {
+			_, saveErr := packageBuilder.SaveAsImage(opts.Name, opts.Publish, opts.Config.Platform.OS, opts.Labels)
+			return saveErr
+		})
 		return errors.Wrapf(err, "saving image")
 	default:
 		return errors.Errorf("unknown format: %s", style.Symbol(opts.Format))
@@ -150,7 +172,7 @@ func (c *Client) downloadBuildpackFromURI(ctx context.Context, uri, relativeBase
 }
 
 func (c *Client) validateOSPlatform(ctx context.Context, os string, publish bool, format string) error {
-	if publish || format == FormatFile {
+	if publish || format != FormatImage {
 		return nil
 	}
 
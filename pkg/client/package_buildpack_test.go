@@ -873,6 +873,34 @@ func testPackageBuildpack(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("FormatOCILayout", func() {
+		it("saves the package as an OCI image layout without using the daemon", func() {
+			tmpDir, err := os.MkdirTemp("", "package-buildpack")
+			h.AssertNil(t, err)
+			defer os.RemoveAll(tmpDir)
+
+			layoutDir := filepath.Join(tmpDir, "package")
+			h.AssertNil(t, subject.PackageBuildpack(context.TODO(), client.PackageBuildpackOptions{
+				Format: client.FormatOCILayout,
+				Name:   layoutDir,
+				Config: pubbldpkg.Config{
+					Platform: dist.Platform{OS: "linux"},
+					Buildpack: dist.BuildpackURI{URI: createBuildpack(dist.BuildpackDescriptor{
+						WithAPI:    api.MustParse("0.2"),
+						WithInfo:   dist.ModuleInfo{ID: "bp.basic", Version: "2.3.4"},
+						WithStacks: []dist.Stack{{ID: "some.stack.id"}},
+					})},
+				},
+				PullPolicy: image.PullNever,
+			}))
+
+			for _, file := range []string{"index.json", "oci-layout"} {
+				_, err := os.Stat(filepath.Join(layoutDir, file))
+				h.AssertNil(t, err)
+			}
+		})
+	})
+
 	when("unknown format is provided", func() {
 		it("should error", func() {
 			mockDockerClient.EXPECT().Info(context.TODO()).Return(system.Info{OSType: "linux"}, nil).AnyTimes()
@@ -52,6 +52,8 @@ func (c *Client) PackageExtension(ctx context.Context, opts PackageBuildpackOpti
 	switch opts.Format {
 	case FormatFile:
 		return packageBuilder.SaveAsFile(opts.Name, opts.Config.Platform.OS, map[string]string{})
+	case FormatOCILayout:
+		return packageBuilder.SaveAsLayout(opts.Name, opts.Config.Platform.OS, map[string]string{})
 	case FormatImage:
 		_, err = packageBuilder.SaveAsImage(opts.Name, opts.Publish, opts.Config.Platform.OS, map[string]string{})
 		return errors.Wrapf(err, "saving image")
@@ -153,4 +153,48 @@ func testPullBuildpack(t *testing.T, when spec.G, it spec.S) {
 			}))
 		})
 	})
+
+	when("pulling from a buildpack registry served over http", func() {
+		var (
+			tmpDir string
+			server *h.RegistryIndexServer
+		)
+
+		it.Before(func() {
+			var err error
+			tmpDir, err = os.MkdirTemp("", "registry")
+			h.AssertNil(t, err)
+
+			server = h.NewRegistryIndexServer("", "")
+			server.SetIndex(`{"buildpacks": [{"ns": "example", "name": "foo", "version": "1.1.0", "addr": "example.com/some/package@sha256:74eb48882e835d8767f62940d453eb96ed2737de3a16573881dcea7dea769df7"}]}`)
+
+			packageImage := fakes.NewImage("example.com/some/package@sha256:74eb48882e835d8767f62940d453eb96ed2737de3a16573881dcea7dea769df7", "", nil)
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), packageImage.Name(), image.FetchOptions{Daemon: true, PullPolicy: image.PullAlways}).Return(packageImage, nil)
+
+			packHome := filepath.Join(tmpDir, "packHome")
+			h.AssertNil(t, os.Setenv("PACK_HOME", packHome))
+			h.AssertNil(t, cfg.Write(cfg.Config{
+				Registries: []cfg.Registry{
+					{
+						Name: "some-registry",
+						Type: "http",
+						URL:  server.IndexURL(),
+					},
+				},
+			}, filepath.Join(packHome, "config.toml")))
+		})
+
+		it.After(func() {
+			server.Close()
+			os.Unsetenv("PACK_HOME")
+			_ = os.RemoveAll(tmpDir)
+		})
+
+		it("should fetch the image", func() {
+			h.AssertNil(t, subject.PullBuildpack(context.TODO(), client.PullBuildpackOptions{
+				URI:          "example/foo@1.1.0",
+				RegistryName: "some-registry",
+			}))
+		})
+	})
 }
@@ -7,16 +7,20 @@ import (
 	"path/filepath"
 
 	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/imgutil"
 	"github.com/buildpacks/lifecycle/phase"
 	"github.com/buildpacks/lifecycle/platform"
 	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/buildpacks/pack/internal/build"
 	"github.com/buildpacks/pack/internal/builder"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/tracing"
 )
 
 // RebaseOptions is a configuration struct that controls image rebase behavior.
@@ -49,11 +53,32 @@ type RebaseOptions struct {
 
 	// Image reference to use as the previous image for rebase.
 	PreviousImage string
+
+	// Verify that the new run image matches the stack, target, mixins and
+	// OS distribution recorded on the app image before rebasing.
+	Verify bool
+
+	// Command run in a container of the rebased image before it is tagged as RepoName.
+	// The rebase is discarded if the command fails. Only supported when Publish is false.
+	SmokeTest []string
+
+	// Run SmokeTest, joined by spaces, as a shell command: with /bin/sh -c, or cmd /c for Windows images.
+	SmokeTestShell bool
+
+	// Rebase the app image back onto the run image it was based on before its last rebase,
+	// as recorded in the PreviousRunImageLabel.
+	Rollback bool
 }
 
 // Rebase updates the run image layers in an app image.
 // This operation mutates the image specified in opts.
-func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
+func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) (err error) {
+	ctx, span := c.startSpan(ctx, "pack.rebase",
+		attribute.String("pack.image", opts.RepoName),
+		attribute.Bool("pack.publish", opts.Publish),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	imageRef, err := c.parseTagReference(opts.RepoName)
 	if err != nil {
 		return errors.Wrapf(err, "invalid image name '%s'", opts.RepoName)
@@ -70,21 +95,35 @@ func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
 		return err
 	}
 
+	_, err = c.rebaseImage(ctx, opts, imageRef, appImage)
+	return err
+}
+
+// rebaseImage rebases the already fetched appImage and returns the identifier of the rebased image.
+func (c *Client) rebaseImage(ctx context.Context, opts RebaseOptions, imageRef name.Reference, appImage imgutil.Image) (string, error) {
+	if opts.Rollback && opts.RunImage != "" {
+		return "", errors.New("run image cannot be specified when rolling back")
+	}
+
+	if len(opts.SmokeTest) > 0 && opts.Publish {
+		return "", errors.New("smoke tests are only supported when rebasing images in the daemon")
+	}
+
 	appOS, err := appImage.OS()
 	if err != nil {
-		return errors.Wrapf(err, "getting app OS")
+		return "", errors.Wrapf(err, "getting app OS")
 	}
 
 	appArch, err := appImage.Architecture()
 	if err != nil {
-		return errors.Wrapf(err, "getting app architecture")
+		return "", errors.Wrapf(err, "getting app architecture")
 	}
 
 	var md files.LayersMetadataCompat
 	if ok, err := dist.GetLabel(appImage, platform.LifecycleMetadataLabel, &md); err != nil {
-		return err
+		return "", err
 	} else if !ok {
-		return errors.Errorf("could not find label %s on image", style.Symbol(platform.LifecycleMetadataLabel))
+		return "", errors.Errorf("could not find label %s on image", style.Symbol(platform.LifecycleMetadataLabel))
 	}
 	var runImageMD builder.RunImageMetadata
 	if md.RunImage.Image != "" {
@@ -98,18 +137,30 @@ func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
 			Mirrors: md.Stack.RunImage.Mirrors,
 		}
 	}
-	runImageName := c.resolveRunImage(
-		opts.RunImage,
-		imageRef.Context().RegistryStr(),
-		"",
-		runImageMD,
-		opts.AdditionalMirrors,
-		opts.Publish,
-		c.accessChecker,
+
+	var (
+		runImageName string
+		previous     files.RunImageForRebase
 	)
+	if opts.Rollback {
+		if previous, err = previousRunImage(appImage); err != nil {
+			return "", err
+		}
+		runImageName = previousRunImageName(previous)
+	} else {
+		runImageName = c.resolveRunImage(
+			opts.RunImage,
+			imageRef.Context().RegistryStr(),
+			"",
+			runImageMD,
+			opts.AdditionalMirrors,
+			opts.Publish,
+			c.accessChecker,
+		)
+	}
 
 	if runImageName == "" {
-		return errors.New("run image must be specified")
+		return "", errors.New("run image must be specified")
 	}
 
 	baseImage, err := c.imageFetcher.Fetch(ctx, runImageName, image.FetchOptions{
@@ -118,19 +169,66 @@ func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
 		Platform:   fmt.Sprintf("%s/%s", appOS, appArch),
 	})
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	if opts.Rollback {
+		topLayer, err := baseImage.TopLayer()
+		if err != nil {
+			return "", errors.Wrapf(err, "getting top layer of %s", style.Symbol(baseImage.Name()))
+		}
+		if topLayer != previous.TopLayer {
+			return "", errors.Errorf("previous run image %s is no longer available: top layer %s does not match recorded top layer %s",
+				style.Symbol(runImageName), style.Symbol(topLayer), style.Symbol(previous.TopLayer))
+		}
+	}
+
+	if opts.Verify {
+		if err := verifyRunImage(appImage, baseImage); err != nil {
+			return "", err
+		}
+		c.logger.Debugf("Run image %s passed verification", style.Symbol(baseImage.Name()))
+	}
+
+	if err := recordPreviousRunImage(appImage, md); err != nil {
+		return "", errors.Wrap(err, "recording previous run image")
+	}
+
+	outputImageRef := opts.RepoName
+	if len(opts.SmokeTest) > 0 {
+		outputImageRef = fmt.Sprintf("pack.local/rebase/%x:latest", randString(10))
+		defer c.removeImageTag(context.Background(), outputImageRef)
 	}
 
 	c.logger.Infof("Rebasing %s on run image %s", style.Symbol(appImage.Name()), style.Symbol(baseImage.Name()))
 	rebaser := &phase.Rebaser{Logger: c.logger, PlatformAPI: build.SupportedPlatformAPIVersions.Latest(), Force: opts.Force}
-	report, err := rebaser.Rebase(appImage, baseImage, opts.RepoName, nil)
+	var report phase.RebaseReport
+	err = traceSave(ctx, opts.RepoName, opts.Publish, func() error {
+		var rebaseErr error
+		report, rebaseErr = rebaser.Rebase(appImage, baseImage, outputImageRef, nil)
+		return rebaseErr
+	})
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	if len(opts.SmokeTest) > 0 {
+		appImageOS, err := appImage.OS()
+		if err != nil {
+			return "", err
+		}
+		if err := c.runSmokeTest(ctx, outputImageRef, appImageOS, smokeTestCommand(opts.SmokeTest, opts.SmokeTestShell, appImageOS)); err != nil {
+			return "", errors.Wrapf(err, "smoke test failed, %s was not updated", style.Symbol(opts.RepoName))
+		}
+		if err := c.docker.ImageTag(ctx, outputImageRef, opts.RepoName); err != nil {
+			return "", errors.Wrapf(err, "tagging rebased image as %s", style.Symbol(opts.RepoName))
+		}
+		report.Image.Tags = []string{opts.RepoName}
 	}
 
 	appImageIdentifier, err := appImage.Identifier()
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	c.logger.Infof("Rebased Image: %s", style.Symbol(appImageIdentifier.String()))
@@ -140,15 +238,15 @@ func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
 		reportFile, err := os.OpenFile(reportPath, os.O_RDWR|os.O_CREATE, 0644)
 		if err != nil {
 			c.logger.Warnf("unable to open %s for writing rebase report", reportPath)
-			return err
+			return "", err
 		}
 
 		defer reportFile.Close()
 		err = toml.NewEncoder(reportFile).Encode(report)
 		if err != nil {
 			c.logger.Warnf("unable to write rebase report to %s", reportPath)
-			return err
+			return "", err
 		}
 	}
-	return nil
+	return appImageIdentifier.String(), nil
 }
@@ -0,0 +1,299 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/buildpacks/pack/internal/parallel"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/tracing"
+)
+
+// RebaseStatus describes the outcome of rebasing a single image as part of RebaseAll.
+type RebaseStatus string
+
+const (
+	// RebaseStatusRebased indicates the image was rebased onto the new run image.
+	RebaseStatusRebased RebaseStatus = "rebased"
+
+	// RebaseStatusWouldRebase indicates the image matched and would have been rebased if DryRun was not set.
+	RebaseStatusWouldRebase RebaseStatus = "would-rebase"
+
+	// RebaseStatusSkipped indicates the image does not run on the requested run image.
+	RebaseStatusSkipped RebaseStatus = "skipped"
+
+	// RebaseStatusFailed indicates the image could not be inspected or rebased.
+	RebaseStatusFailed RebaseStatus = "failed"
+)
+
+// RebaseAllOptions is a configuration struct that controls the behavior of RebaseAll.
+type RebaseAllOptions struct {
+	// Run image the app images are currently based on. Only images whose
+	// run image metadata references this image (or one of its mirrors) are rebased.
+	// When a digest reference is provided, the recorded run image digest must match as well.
+	FromRunImage string
+
+	// Names of the candidate app images.
+	Images []string
+
+	// Registry repository prefix (e.g. registry.example.com/team/) used to discover
+	// additional candidate images. Every tag of every matching repository is considered.
+	RegistryPrefix string
+
+	// Image to rebase against. If omitted, the run image recorded in each app image is used.
+	RunImage string
+
+	// Flag to publish images to the remote registry after rebase completion.
+	Publish bool
+
+	// Strategy for pulling images during rebase.
+	PullPolicy image.PullPolicy
+
+	// A mapping from StackID to an array of mirrors.
+	AdditionalMirrors map[string][]string
+
+	// Pass-through force flag to lifecycle rebase command to skip target data
+	// validated (will not have any effect if API < 0.12).
+	Force bool
+
+	// Verify each new run image before rebasing. See RebaseOptions.Verify.
+	Verify bool
+
+	// Command run in a container of each rebased image before it is tagged. See RebaseOptions.SmokeTest.
+	SmokeTest []string
+
+	// Run SmokeTest as a shell command. See RebaseOptions.SmokeTestShell.
+	SmokeTestShell bool
+
+	// Only report which images would be rebased without modifying them.
+	DryRun bool
+
+	// Maximum number of images rebased concurrently. Non-positive values select a default limit.
+	Concurrency int
+}
+
+// RebaseResult is the outcome of rebasing a single image.
+type RebaseResult struct {
+	// Name of the app image.
+	Image string
+
+	Status RebaseStatus
+
+	// Identifier of the app image before it was rebased.
+	PreviousDigest string
+
+	// Identifier of the app image after it was rebased.
+	Digest string
+
+	// Why the image was skipped or failed.
+	Reason string
+}
+
+// RebaseSummary contains the result of every candidate image, in the order the images were provided.
+type RebaseSummary struct {
+	Results []RebaseResult
+}
+
+// Count returns the number of results with the given status.
+func (s RebaseSummary) Count(status RebaseStatus) int {
+	var count int
+	for _, result := range s.Results {
+		if result.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// RebaseAll rebases every provided or discovered image that currently runs on opts.FromRunImage.
+// Failures to rebase individual images are recorded in the returned summary rather than returned as an error.
+func (c *Client) RebaseAll(ctx context.Context, opts RebaseAllOptions) (summary RebaseSummary, err error) {
+	ctx, span := c.startSpan(ctx, "pack.rebase_all",
+		attribute.String("pack.run_image.from", opts.FromRunImage),
+		attribute.Bool("pack.publish", opts.Publish),
+		attribute.Bool("pack.dry_run", opts.DryRun),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	if opts.FromRunImage == "" {
+		return RebaseSummary{}, errors.New("run image to rebase from must be specified")
+	}
+	if _, err := name.ParseReference(opts.FromRunImage, name.WeakValidation); err != nil {
+		return RebaseSummary{}, errors.Wrapf(err, "invalid run image '%s'", opts.FromRunImage)
+	}
+
+	images := opts.Images
+	if opts.RegistryPrefix != "" {
+		discovered, err := c.listRegistryImages(ctx, opts.RegistryPrefix)
+		if err != nil {
+			return RebaseSummary{}, errors.Wrapf(err, "listing images with prefix %s", style.Symbol(opts.RegistryPrefix))
+		}
+		images = append(append([]string{}, images...), discovered...)
+	}
+	images = dedupe(images)
+	if len(images) == 0 {
+		return RebaseSummary{}, errors.New("no images to rebase")
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = c.fetchConcurrency
+	}
+
+	results, _ := parallel.Map(concurrency, images, func(imageName string) (RebaseResult, error) {
+		return c.rebaseFrom(ctx, imageName, opts), nil
+	})
+	return RebaseSummary{Results: results}, nil
+}
+
+// rebaseFrom rebases a single image if it runs on opts.FromRunImage.
+func (c *Client) rebaseFrom(ctx context.Context, imageName string, opts RebaseAllOptions) RebaseResult {
+	ctx, span := tracing.Start(ctx, "pack.rebase", attribute.String("pack.image", imageName))
+	result := RebaseResult{Image: imageName}
+	failed := func(err error) RebaseResult {
+		tracing.End(span, err)
+		result.Status = RebaseStatusFailed
+		result.Reason = err.Error()
+		return result
+	}
+
+	imageRef, err := c.parseTagReference(imageName)
+	if err != nil {
+		return failed(errors.Wrapf(err, "invalid image name '%s'", imageName))
+	}
+
+	appImage, err := c.imageFetcher.Fetch(ctx, imageName, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy})
+	if err != nil {
+		return failed(err)
+	}
+
+	if id, err := appImage.Identifier(); err == nil {
+		result.PreviousDigest = id.String()
+	}
+
+	matches, reason, err := runsOn(appImage, opts.FromRunImage)
+	if err != nil {
+		return failed(err)
+	}
+	if !matches {
+		tracing.End(span, nil)
+		result.Status = RebaseStatusSkipped
+		result.Reason = reason
+		return result
+	}
+
+	if opts.DryRun {
+		tracing.End(span, nil)
+		result.Status = RebaseStatusWouldRebase
+		return result
+	}
+
+	digest, err := c.rebaseImage(ctx, RebaseOptions{
+		RepoName:          imageName,
+		Publish:           opts.Publish,
+		PullPolicy:        opts.PullPolicy,
+		RunImage:          opts.RunImage,
+		AdditionalMirrors: opts.AdditionalMirrors,
+		Force:             opts.Force,
+		Verify:            opts.Verify,
+		SmokeTest:         opts.SmokeTest,
+		SmokeTestShell:    opts.SmokeTestShell,
+	}, imageRef, appImage)
+	if err != nil {
+		return failed(err)
+	}
+
+	tracing.End(span, nil)
+	result.Status = RebaseStatusRebased
+	result.Digest = digest
+	return result
+}
+
+// runsOn reports whether the run image recorded in the metadata of appImage is runImage.
+// If it is not, the returned reason explains why.
+func runsOn(appImage imgutil.Image, runImage string) (bool, string, error) {
+	var md files.LayersMetadataCompat
+	if ok, err := dist.GetLabel(appImage, platform.LifecycleMetadataLabel, &md); err != nil {
+		return false, "", err
+	} else if !ok {
+		return false, fmt.Sprintf("missing label %s", style.Symbol(platform.LifecycleMetadataLabel)), nil
+	}
+
+	contains := md.RunImage.Contains(runImage)
+	if !contains && md.Stack != nil {
+		contains = md.Stack.RunImage.Contains(runImage)
+	}
+	if !contains {
+		recorded := md.RunImage.Image
+		if recorded == "" && md.Stack != nil {
+			recorded = md.Stack.RunImage.Image
+		}
+		return false, fmt.Sprintf("run image %s does not match", style.Symbol(recorded)), nil
+	}
+
+	if digest, err := name.NewDigest(runImage, name.WeakValidation); err == nil && !strings.HasSuffix(md.RunImage.Reference, digest.DigestStr()) {
+		return false, fmt.Sprintf("run image digest %s does not match", style.Symbol(md.RunImage.Reference)), nil
+	}
+
+	return true, "", nil
+}
+
+// listRegistryImages returns a tag reference for every tag of every repository whose name starts with prefix.
+func (c *Client) listRegistryImages(ctx context.Context, prefix string) ([]string, error) {
+	registryName, repoPrefix, _ := strings.Cut(prefix, "/")
+	registry, err := name.NewRegistry(registryName, name.WeakValidation)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteOpts := c.remoteOptions(ctx)
+	repos, err := remote.Catalog(ctx, registry, remoteOpts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing repositories of %s", style.Symbol(registry.Name()))
+	}
+	sort.Strings(repos)
+
+	var images []string
+	for _, repo := range repos {
+		if !strings.HasPrefix(repo, repoPrefix) {
+			continue
+		}
+
+		repository := registry.Repo(repo)
+		tags, err := remote.List(repository, remoteOpts...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing tags of %s", style.Symbol(repository.Name()))
+		}
+		sort.Strings(tags)
+
+		for _, tag := range tags {
+			images = append(images, repository.Tag(tag).Name())
+		}
+	}
+	return images, nil
+}
+
+func dedupe(items []string) []string {
+	seen := map[string]bool{}
+	var unique []string
+	for _, item := range items {
+		if item == "" || seen[item] {
+			continue
+		}
+		seen[item] = true
+		unique = append(unique, item)
+	}
+	return unique
+}
@@ -0,0 +1,212 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRebaseAll(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "rebase_all", testRebaseAll, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testRebaseAll(t *testing.T, when spec.G, it spec.S) {
+	var (
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		subject          *Client
+		appImages        []*fakes.Image
+		fakeRunImage     *fakes.Image
+		out              bytes.Buffer
+	)
+
+	addAppImage := func(name, runImage string) *fakes.Image {
+		appImage := fakes.NewImage(name, "", &fakeIdentifier{name: name + "-digest"})
+		h.AssertNil(t, appImage.SetLabel("io.buildpacks.lifecycle.metadata",
+			`{"runImage":{"image":"`+runImage+`","reference":"`+runImage+`@sha256:1111111111111111111111111111111111111111111111111111111111111111"}}`))
+		h.AssertNil(t, appImage.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.jammy"))
+		fakeImageFetcher.LocalImages[name] = appImage
+		appImages = append(appImages, appImage)
+		return appImage
+	}
+
+	it.Before(func() {
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		appImages = nil
+
+		fakeRunImage = fakes.NewImage("some/run", "run-image-top-layer-sha", &fakeIdentifier{name: "run-image-digest"})
+		h.AssertNil(t, fakeRunImage.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.jammy"))
+		fakeImageFetcher.LocalImages["some/run"] = fakeRunImage
+
+		subject = &Client{
+			logger:        logging.NewLogWithWriters(&out, &out),
+			imageFetcher:  fakeImageFetcher,
+			accessChecker: ifakes.NewFakeAccessChecker(),
+			keychain:      authn.DefaultKeychain,
+		}
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, fakeRunImage.Cleanup())
+		for _, appImage := range appImages {
+			h.AssertNilE(t, appImage.Cleanup())
+		}
+	})
+
+	when("#RebaseAll", func() {
+		it("rebases only images running on the given run image", func() {
+			matching := addAppImage("some/app", "some/run")
+			other := addAppImage("other/app", "other/run")
+
+			summary, err := subject.RebaseAll(context.TODO(), RebaseAllOptions{
+				FromRunImage: "some/run",
+				Images:       []string{"some/app", "other/app"},
+			})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, summary.Results, []RebaseResult{
+				{Image: "some/app", Status: RebaseStatusRebased, PreviousDigest: "some/app-digest", Digest: "some/app-digest"},
+				{Image: "other/app", Status: RebaseStatusSkipped, PreviousDigest: "other/app-digest", Reason: "run image 'other/run' does not match"},
+			})
+			h.AssertEq(t, matching.Base(), "some/run")
+			h.AssertEq(t, other.Base(), "")
+		})
+
+		it("records images that could not be rebased", func() {
+			addAppImage("some/app", "some/run")
+
+			summary, err := subject.RebaseAll(context.TODO(), RebaseAllOptions{
+				FromRunImage: "some/run",
+				Images:       []string{"missing/app", "some/app"},
+			})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, summary.Count(RebaseStatusRebased), 1)
+			h.AssertEq(t, summary.Count(RebaseStatusFailed), 1)
+			h.AssertEq(t, summary.Results[0].Image, "missing/app")
+			h.AssertContains(t, summary.Results[0].Reason, "image 'missing/app' does not exist on the daemon")
+		})
+
+		it("keeps the order of the provided images", func() {
+			var names []string
+			for _, n := range []string{"a", "b", "c", "d", "e", "f"} {
+				addAppImage("some/"+n, "some/run")
+				names = append(names, "some/"+n)
+			}
+
+			summary, err := subject.RebaseAll(context.TODO(), RebaseAllOptions{
+				FromRunImage: "some/run",
+				Images:       append(names, "some/a"),
+				Concurrency:  3,
+			})
+			h.AssertNil(t, err)
+
+			var rebased []string
+			for _, result := range summary.Results {
+				h.AssertEq(t, result.Status, RebaseStatusRebased)
+				rebased = append(rebased, result.Image)
+			}
+			h.AssertEq(t, rebased, names)
+		})
+
+		when("the run image is a digest reference", func() {
+			it("only matches images built on that digest", func() {
+				addAppImage("some/app", "some/run")
+
+				summary, err := subject.RebaseAll(context.TODO(), RebaseAllOptions{
+					FromRunImage: "some/run@sha256:2222222222222222222222222222222222222222222222222222222222222222",
+					Images:       []string{"some/app"},
+					DryRun:       true,
+				})
+				h.AssertNil(t, err)
+				h.AssertEq(t, summary.Results[0].Status, RebaseStatusSkipped)
+
+				summary, err = subject.RebaseAll(context.TODO(), RebaseAllOptions{
+					FromRunImage: "some/run@sha256:1111111111111111111111111111111111111111111111111111111111111111",
+					Images:       []string{"some/app"},
+					DryRun:       true,
+				})
+				h.AssertNil(t, err)
+				h.AssertEq(t, summary.Results[0].Status, RebaseStatusWouldRebase)
+			})
+		})
+
+		when("dry run", func() {
+			it("does not modify matching images", func() {
+				appImage := addAppImage("some/app", "some/run")
+
+				summary, err := subject.RebaseAll(context.TODO(), RebaseAllOptions{
+					FromRunImage: "some/run",
+					Images:       []string{"some/app"},
+					DryRun:       true,
+				})
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, summary.Results, []RebaseResult{
+					{Image: "some/app", Status: RebaseStatusWouldRebase, PreviousDigest: "some/app-digest"},
+				})
+				h.AssertEq(t, appImage.Base(), "")
+			})
+		})
+
+		when("a registry prefix is provided", func() {
+			var server *httptest.Server
+
+			it.Before(func() {
+				server = httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+			})
+
+			it.After(func() {
+				server.Close()
+			})
+
+			it("considers every tag of every matching repository", func() {
+				host := strings.TrimPrefix(server.URL, "http://")
+				for _, ref := range []string{"team/app-one:latest", "team/app-one:v1", "team/app-two:latest", "other/app:latest"} {
+					img, err := random.Image(1, 1)
+					h.AssertNil(t, err)
+					tag, err := name.NewTag(host + "/" + ref)
+					h.AssertNil(t, err)
+					h.AssertNil(t, remote.Write(tag, img))
+				}
+
+				images, err := subject.listRegistryImages(context.TODO(), host+"/team/")
+				h.AssertNil(t, err)
+				h.AssertEq(t, images, []string{
+					host + "/team/app-one:latest",
+					host + "/team/app-one:v1",
+					host + "/team/app-two:latest",
+				})
+			})
+		})
+
+		it("requires images", func() {
+			_, err := subject.RebaseAll(context.TODO(), RebaseAllOptions{FromRunImage: "some/run"})
+			h.AssertError(t, err, "no images to rebase")
+		})
+
+		it("requires a run image", func() {
+			_, err := subject.RebaseAll(context.TODO(), RebaseAllOptions{Images: []string{"some/app"}})
+			h.AssertError(t, err, "run image to rebase from must be specified")
+		})
+	})
+}
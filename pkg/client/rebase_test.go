@@ -1,20 +1,30 @@
 package client
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"errors"
+	"net"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/buildpacks/imgutil/fakes"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/golang/mock/gomock"
 	"github.com/heroku/color"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
 
 	ifakes "github.com/buildpacks/pack/internal/fakes"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -289,6 +299,197 @@ func testRebase(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("verify", func() {
+				var fakeNewRunImage *fakes.Image
+
+				it.Before(func() {
+					h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.stack.mixins", `["run:curl","tzdata"]`))
+					h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.distro.name", "ubuntu"))
+					h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.distro.version", "22.04"))
+
+					fakeNewRunImage = fakes.NewImage("some/run", "new-top-layer-sha", &fakeIdentifier{name: "new-digest"})
+					h.AssertNil(t, fakeNewRunImage.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.jammy"))
+					h.AssertNil(t, fakeNewRunImage.SetLabel("io.buildpacks.stack.mixins", `["curl","tzdata"]`))
+					h.AssertNil(t, fakeNewRunImage.SetLabel("io.buildpacks.distro.name", "ubuntu"))
+					h.AssertNil(t, fakeNewRunImage.SetLabel("io.buildpacks.distro.version", "22.04"))
+					fakeImageFetcher.LocalImages["some/run"] = fakeNewRunImage
+				})
+
+				it.After(func() {
+					h.AssertNilE(t, fakeNewRunImage.Cleanup())
+				})
+
+				it("rebases when the run image matches the app image", func() {
+					h.AssertNil(t, subject.Rebase(context.TODO(), RebaseOptions{RepoName: "some/app", Verify: true}))
+					h.AssertEq(t, fakeAppImage.Base(), "some/run")
+				})
+
+				it("reports every mismatch", func() {
+					h.AssertNil(t, fakeNewRunImage.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.noble"))
+					h.AssertNil(t, fakeNewRunImage.SetLabel("io.buildpacks.stack.mixins", `["curl"]`))
+					h.AssertNil(t, fakeNewRunImage.SetLabel("io.buildpacks.distro.version", "24.04"))
+
+					err := subject.Rebase(context.TODO(), RebaseOptions{RepoName: "some/app", Verify: true, Force: true})
+					h.AssertError(t, err, "run image 'some/run' failed verification")
+					h.AssertError(t, err, "stack 'io.buildpacks.stacks.noble' does not match app image stack 'io.buildpacks.stacks.jammy'")
+					h.AssertError(t, err, "distro version '24.04' does not match app image distro version '22.04'")
+					h.AssertError(t, err, "missing required mixin(s): tzdata")
+					h.AssertEq(t, fakeAppImage.Base(), "")
+				})
+
+				it("checks the target", func() {
+					h.AssertNil(t, fakeNewRunImage.SetArchitecture("arm64"))
+
+					err := subject.Rebase(context.TODO(), RebaseOptions{RepoName: "some/app", Verify: true})
+					h.AssertError(t, err, "target 'linux/arm64' does not match app image target 'linux/amd64'")
+				})
+			})
+
+			when("rollback", func() {
+				var fakePreviousRunImage *fakes.Image
+
+				it.Before(func() {
+					h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.lifecycle.metadata",
+						`{"runImage":{"topLayer":"previous-top-layer-sha","reference":"some/run@sha256:0000000000000000000000000000000000000000000000000000000000000000","image":"some/run"}}`))
+
+					fakePreviousRunImage = fakes.NewImage("some/run@sha256:0000000000000000000000000000000000000000000000000000000000000000", "previous-top-layer-sha", &fakeIdentifier{name: "previous-digest"})
+					h.AssertNil(t, fakePreviousRunImage.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.jammy"))
+					fakeImageFetcher.LocalImages["some/run@sha256:0000000000000000000000000000000000000000000000000000000000000000"] = fakePreviousRunImage
+				})
+
+				it.After(func() {
+					h.AssertNilE(t, fakePreviousRunImage.Cleanup())
+				})
+
+				it("records the previous run image when rebasing", func() {
+					h.AssertNil(t, subject.Rebase(context.TODO(), RebaseOptions{RepoName: "some/app"}))
+
+					lbl, err := fakeAppImage.Label(PreviousRunImageLabel)
+					h.AssertNil(t, err)
+					h.AssertContains(t, lbl, `"topLayer":"previous-top-layer-sha"`)
+					h.AssertContains(t, lbl, `"reference":"some/run@sha256:0000000000000000000000000000000000000000000000000000000000000000"`)
+				})
+
+				it("restores the previous run image", func() {
+					h.AssertNil(t, subject.Rebase(context.TODO(), RebaseOptions{RepoName: "some/app"}))
+					h.AssertEq(t, fakeAppImage.Base(), "some/run")
+
+					h.AssertNil(t, subject.Rebase(context.TODO(), RebaseOptions{RepoName: "some/app", Rollback: true}))
+					h.AssertEq(t, fakeAppImage.Base(), "some/run@sha256:0000000000000000000000000000000000000000000000000000000000000000")
+					lbl, _ := fakeAppImage.Label("io.buildpacks.lifecycle.metadata")
+					h.AssertContains(t, lbl, `"runImage":{"topLayer":"previous-top-layer-sha","reference":"previous-digest"`)
+					lbl, _ = fakeAppImage.Label(PreviousRunImageLabel)
+					h.AssertContains(t, lbl, `"topLayer":"run-image-top-layer-sha"`)
+				})
+
+				it("fails if the previous run image changed", func() {
+					h.AssertNil(t, subject.Rebase(context.TODO(), RebaseOptions{RepoName: "some/app"}))
+					fakeImageFetcher.LocalImages["some/run@sha256:0000000000000000000000000000000000000000000000000000000000000000"] = fakeRunImage
+
+					err := subject.Rebase(context.TODO(), RebaseOptions{RepoName: "some/app", Rollback: true})
+					h.AssertError(t, err, "is no longer available: top layer 'run-image-top-layer-sha' does not match recorded top layer 'previous-top-layer-sha'")
+				})
+
+				it("fails if the image was never rebased", func() {
+					err := subject.Rebase(context.TODO(), RebaseOptions{RepoName: "some/app", Rollback: true})
+					h.AssertError(t, err, "no previous run image recorded on 'some/app'")
+				})
+			})
+
+			when("smoke test", func() {
+				var (
+					mockController *gomock.Controller
+					mockDocker     *testmocks.MockCommonAPIClient
+				)
+
+				it.Before(func() {
+					mockController = gomock.NewController(t)
+					mockDocker = testmocks.NewMockCommonAPIClient(mockController)
+					subject.docker = mockDocker
+				})
+
+				it.After(func() {
+					mockController.Finish()
+				})
+
+				it("discards the rebased image if the smoke test fails", func() {
+					mockDocker.EXPECT().
+						ContainerCreate(gomock.Any(), gomock.Any(), gomock.Any(), nil, nil, "").
+						DoAndReturn(func(_ context.Context, config *container.Config, _ *container.HostConfig, _ *network.NetworkingConfig, _ *specs.Platform, _ string) (container.CreateResponse, error) {
+							h.AssertContains(t, config.Image, "pack.local/rebase/")
+							h.AssertEq(t, []string(config.Cmd), []string{"/bin/sh", "-c", "exit 1"})
+							return container.CreateResponse{}, errors.New("create failed")
+						})
+					mockDocker.EXPECT().ImageRemove(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+					err := subject.Rebase(context.TODO(), RebaseOptions{RepoName: "some/app", SmokeTest: []string{"/bin/sh", "-c", "exit 1"}})
+					h.AssertError(t, err, "smoke test failed, 'some/app' was not updated")
+					h.AssertNotContains(t, strings.Join(fakeAppImage.SavedNames(), ","), "some/app")
+				})
+
+				it("runs the command through the launcher and discards the rebased image if it exits non-zero", func() {
+					mockDocker.EXPECT().
+						ContainerCreate(gomock.Any(), gomock.Any(), gomock.Any(), nil, nil, "").
+						DoAndReturn(func(_ context.Context, config *container.Config, _ *container.HostConfig, _ *network.NetworkingConfig, _ *specs.Platform, _ string) (container.CreateResponse, error) {
+							h.AssertEq(t, []string(config.Entrypoint), []string{"/cnb/lifecycle/launcher", "--"})
+							h.AssertEq(t, []string(config.Cmd), []string{"/bin/sh", "-c", "exit 3"})
+							return container.CreateResponse{ID: "some-container"}, nil
+						})
+					waitChan := make(chan container.WaitResponse, 1)
+					waitChan <- container.WaitResponse{StatusCode: 3}
+					mockDocker.EXPECT().
+						ContainerWait(gomock.Any(), "some-container", gomock.Any()).
+						Return(waitChan, make(chan error))
+					conn, peer := net.Pipe()
+					defer peer.Close()
+					mockDocker.EXPECT().
+						ContainerAttach(gomock.Any(), "some-container", gomock.Any()).
+						Return(types.HijackedResponse{Conn: conn, Reader: bufio.NewReader(strings.NewReader(""))}, nil)
+					mockDocker.EXPECT().ContainerStart(gomock.Any(), "some-container", gomock.Any()).Return(nil)
+					mockDocker.EXPECT().ContainerRemove(gomock.Any(), "some-container", gomock.Any()).Return(nil)
+					mockDocker.EXPECT().ImageRemove(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+					err := subject.Rebase(context.TODO(), RebaseOptions{RepoName: "some/app", SmokeTest: []string{"/bin/sh", "-c", "exit 3"}})
+					h.AssertError(t, err, "failed with status code: 3")
+					h.AssertError(t, err, "smoke test failed, 'some/app' was not updated")
+					h.AssertNotContains(t, strings.Join(fakeAppImage.SavedNames(), ","), "some/app")
+				})
+
+				when("the command is a shell command", func() {
+					var expectCommand = func(command []string) {
+						mockDocker.EXPECT().
+							ContainerCreate(gomock.Any(), gomock.Any(), gomock.Any(), nil, nil, "").
+							DoAndReturn(func(_ context.Context, config *container.Config, _ *container.HostConfig, _ *network.NetworkingConfig, _ *specs.Platform, _ string) (container.CreateResponse, error) {
+								h.AssertEq(t, []string(config.Cmd), command)
+								return container.CreateResponse{}, errors.New("create failed")
+							})
+						mockDocker.EXPECT().ImageRemove(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+					}
+
+					it("runs it with /bin/sh", func() {
+						expectCommand([]string{"/bin/sh", "-c", "curl -f localhost:8080"})
+
+						err := subject.Rebase(context.TODO(), RebaseOptions{RepoName: "some/app", SmokeTest: []string{"curl -f localhost:8080"}, SmokeTestShell: true})
+						h.AssertError(t, err, "smoke test failed")
+					})
+
+					it("runs it with cmd for Windows images", func() {
+						h.AssertNil(t, fakeAppImage.SetOS("windows"))
+						h.AssertNil(t, fakeRunImage.SetOS("windows"))
+						expectCommand([]string{"cmd", "/c", "curl -f localhost:8080"})
+
+						err := subject.Rebase(context.TODO(), RebaseOptions{RepoName: "some/app", SmokeTest: []string{"curl -f localhost:8080"}, SmokeTestShell: true})
+						h.AssertError(t, err, "smoke test failed")
+					})
+				})
+
+				it("is not supported when publishing", func() {
+					fakeImageFetcher.RemoteImages["some/app"] = fakeAppImage
+					err := subject.Rebase(context.TODO(), RebaseOptions{RepoName: "some/app", Publish: true, SmokeTest: []string{"true"}})
+					h.AssertError(t, err, "smoke tests are only supported when rebasing images in the daemon")
+				})
+			})
+
 			when("previous image is not provided", func() {
 				it("fetches the image using the repo name", func() {
 					h.AssertNil(t, subject.Rebase(context.TODO(), RebaseOptions{
@@ -0,0 +1,208 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// PreviousRunImageLabel records the run image an app image was based on before its last rebase.
+// It is used to roll back a rebase.
+const PreviousRunImageLabel = "io.buildpacks.pack.rebase.previous-run-image"
+
+// verifyRunImage checks that newBase provides everything the app image recorded about its current run image:
+// the stack, the target, the mixins and the OS distribution.
+func verifyRunImage(appImage, newBase imgutil.Image) error {
+	var problems []string
+
+	appStackID, err := appImage.Label(platform.StackIDLabel)
+	if err != nil {
+		return errors.Wrap(err, "reading app image stack")
+	}
+	baseStackID, err := newBase.Label(platform.StackIDLabel)
+	if err != nil {
+		return errors.Wrap(err, "reading run image stack")
+	}
+	if appStackID != "" && appStackID != baseStackID {
+		problems = append(problems, fmt.Sprintf("stack %s does not match app image stack %s", style.Symbol(baseStackID), style.Symbol(appStackID)))
+	}
+
+	appTarget, err := platform.GetTargetMetadata(appImage)
+	if err != nil {
+		return errors.Wrap(err, "reading app image target")
+	}
+	baseTarget, err := platform.GetTargetMetadata(newBase)
+	if err != nil {
+		return errors.Wrap(err, "reading run image target")
+	}
+	if baseTarget.OS != appTarget.OS || baseTarget.Arch != appTarget.Arch ||
+		(baseTarget.ArchVariant != "" && appTarget.ArchVariant != "" && baseTarget.ArchVariant != appTarget.ArchVariant) {
+		problems = append(problems, fmt.Sprintf("target %s does not match app image target %s",
+			style.Symbol(targetString(baseTarget)), style.Symbol(targetString(appTarget))))
+	}
+	if appTarget.ID != "" && baseTarget.ID != appTarget.ID {
+		problems = append(problems, fmt.Sprintf("target ID %s does not match app image target ID %s", style.Symbol(baseTarget.ID), style.Symbol(appTarget.ID)))
+	}
+
+	if appTarget.Distro != nil {
+		switch {
+		case baseTarget.Distro == nil:
+			problems = append(problems, fmt.Sprintf("distro is not defined, app image requires %s", style.Symbol(distroString(appTarget.Distro))))
+		case baseTarget.Distro.Name != appTarget.Distro.Name:
+			problems = append(problems, fmt.Sprintf("distro %s does not match app image distro %s",
+				style.Symbol(distroString(baseTarget.Distro)), style.Symbol(distroString(appTarget.Distro))))
+		case baseTarget.Distro.Version != appTarget.Distro.Version:
+			problems = append(problems, fmt.Sprintf("distro version %s does not match app image distro version %s",
+				style.Symbol(baseTarget.Distro.Version), style.Symbol(appTarget.Distro.Version)))
+		}
+	}
+
+	missing, err := missingMixins(appImage, newBase)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		problems = append(problems, fmt.Sprintf("missing required mixin(s): %s", strings.Join(missing, ", ")))
+	}
+
+	if len(problems) > 0 {
+		return errors.Errorf("run image %s failed verification:\n  - %s", style.Symbol(newBase.Name()), strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// missingMixins returns the mixins recorded on appImage that newBase does not provide.
+func missingMixins(appImage, newBase imgutil.Image) ([]string, error) {
+	var appMixins, baseMixins []string
+	if _, err := dist.GetLabel(appImage, platform.MixinsLabel, &appMixins); err != nil {
+		return nil, err
+	}
+	if _, err := dist.GetLabel(newBase, platform.MixinsLabel, &baseMixins); err != nil {
+		return nil, err
+	}
+
+	provided := map[string]bool{}
+	for _, mixin := range baseMixins {
+		provided[removeStagePrefix(mixin)] = true
+	}
+
+	var missing []string
+	for _, mixin := range appMixins {
+		if mixin = removeStagePrefix(mixin); !provided[mixin] {
+			missing = append(missing, mixin)
+		}
+	}
+	return missing, nil
+}
+
+func removeStagePrefix(mixin string) string {
+	_, name, found := strings.Cut(mixin, ":")
+	if !found {
+		return mixin
+	}
+	return name
+}
+
+func targetString(target *files.TargetMetadata) string {
+	s := target.OS + "/" + target.Arch
+	if target.ArchVariant != "" {
+		s += "/" + target.ArchVariant
+	}
+	return s
+}
+
+func distroString(distro *files.OSDistro) string {
+	return strings.TrimSpace(distro.Name + " " + distro.Version)
+}
+
+// recordPreviousRunImage stores the current run image of appImage in PreviousRunImageLabel so the rebase can be rolled back.
+func recordPreviousRunImage(appImage imgutil.Image, md files.LayersMetadataCompat) error {
+	previous := md.RunImage
+	if previous.Image == "" && md.Stack != nil {
+		previous.Image = md.Stack.RunImage.Image
+		previous.Mirrors = md.Stack.RunImage.Mirrors
+	}
+	if previous.TopLayer == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(previous)
+	if err != nil {
+		return err
+	}
+	return appImage.SetLabel(PreviousRunImageLabel, string(data))
+}
+
+// previousRunImage returns the run image appImage was based on before its last rebase.
+func previousRunImage(appImage imgutil.Image) (files.RunImageForRebase, error) {
+	var previous files.RunImageForRebase
+	if ok, err := dist.GetLabel(appImage, PreviousRunImageLabel, &previous); err != nil {
+		return previous, err
+	} else if !ok || previous.TopLayer == "" {
+		return previous, errors.Errorf("no previous run image recorded on %s; it has not been rebased by pack", style.Symbol(appImage.Name()))
+	}
+	return previous, nil
+}
+
+// previousRunImageName returns the name by which the previous run image should be fetched,
+// preferring the recorded digest reference over the (mutable) image name.
+func previousRunImageName(previous files.RunImageForRebase) string {
+	if strings.Contains(previous.Reference, "@") {
+		return previous.Reference
+	}
+	return previous.Image
+}
+
+// runSmokeTest runs command in a container of imageName and fails if the command does not exit successfully.
+// The command is run directly by the launcher, rather than as arguments to the default process, so that it sees the
+// environment of the app.
+func (c *Client) runSmokeTest(ctx context.Context, imageName, imageOS string, command []string) error {
+	c.logger.Infof("Running smoke test %s in %s", style.Symbol(strings.Join(command, " ")), style.Symbol(imageName))
+
+	launcher := launcherEntrypoint
+	if imageOS == "windows" {
+		launcher = windowsLauncherEntrypoint
+	}
+	config := &containertypes.Config{Image: imageName, Entrypoint: []string{launcher, "--"}, Cmd: command}
+	ctr, err := c.docker.ContainerCreate(ctx, config, &containertypes.HostConfig{}, nil, nil, "")
+	if err != nil {
+		return errors.Wrap(err, "creating smoke test container")
+	}
+	defer c.docker.ContainerRemove(context.Background(), ctr.ID, containertypes.RemoveOptions{Force: true})
+
+	return container.RunWithHandler(ctx, c.docker, ctr.ID, container.DefaultHandler(
+		logging.NewPrefixWriter(logging.GetWriterForLevel(c.logger, logging.InfoLevel), "smoke-test"),
+		logging.NewPrefixWriter(logging.GetWriterForLevel(c.logger, logging.ErrorLevel), "smoke-test"),
+	))
+}
+
+// smokeTestCommand returns the command to run for a smoke test, wrapped in the shell of imageOS if shell is true.
+func smokeTestCommand(command []string, shell bool, imageOS string) []string {
+	if !shell {
+		return command
+	}
+	if imageOS == "windows" {
+		return []string{"cmd", "/c", strings.Join(command, " ")}
+	}
+	return []string{"/bin/sh", "-c", strings.Join(command, " ")}
+}
+
+// removeImageTag removes a temporary tag created during rebase.
+func (c *Client) removeImageTag(ctx context.Context, imageName string) {
+	if _, err := c.docker.ImageRemove(ctx, imageName, types.ImageRemoveOptions{}); err != nil {
+		c.logger.Warnf("unable to remove temporary image %s: %s", style.Symbol(imageName), err)
+	}
+}
@@ -90,6 +90,13 @@ func (c *Client) RegisterBuildpack(ctx context.Context, opts RegisterBuildpackOp
 		if err := registry.GitCommit(buildpack, username, registryCache); err != nil {
 			return err
 		}
+	} else if isRemoteIndexType(opts.Type) {
+		index, err := newRemoteIndex(ctx, c.logger, c.keychain, opts.Type, opts.URL)
+		if err != nil {
+			return err
+		}
+
+		return index.Add(buildpack)
 	}
 
 	return nil
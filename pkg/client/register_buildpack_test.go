@@ -3,9 +3,11 @@ package client
 import (
 	"bytes"
 	"context"
+	"os"
 	"testing"
 
 	"github.com/buildpacks/imgutil/fakes"
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/heroku/color"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
@@ -120,6 +122,61 @@ func testRegisterBuildpack(t *testing.T, when spec.G, it spec.S) {
 				}))
 		})
 
+		when("the registry is stored as an OCI artifact", func() {
+			var (
+				tmpDir string
+				server *h.RegistryIndexServer
+			)
+
+			it.Before(func() {
+				var err error
+				tmpDir, err = os.MkdirTemp("", "register-buildpack")
+				h.AssertNil(t, err)
+				h.AssertNil(t, os.Setenv("PACK_HOME", tmpDir))
+
+				server = h.NewRegistryIndexServer("", "")
+				subject.keychain = authn.DefaultKeychain
+
+				fakeAppImage = fakes.NewImage("buildpack/image", "", &fakeIdentifier{name: "example.com/buildpack/image@sha256:8c27fe111c11b722081701dfed3bd55e039b9ce92865473cf4cdfa918071c566"})
+				h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.buildpackage.metadata", `{"id":"heroku/java-function","version":"1.1.1"}`))
+				fakeImageFetcher.RemoteImages["buildpack/image"] = fakeAppImage
+			})
+
+			it.After(func() {
+				server.Close()
+				os.Unsetenv("PACK_HOME")
+				_ = os.RemoveAll(tmpDir)
+			})
+
+			it("adds the buildpack to the index", func() {
+				indexRef := server.Host() + "/buildpacks/index:latest"
+				h.AssertNil(t, subject.RegisterBuildpack(context.TODO(),
+					RegisterBuildpackOptions{
+						ImageName: "buildpack/image",
+						Type:      "oci",
+						URL:       indexRef,
+						Name:      "some-registry",
+					}))
+
+				index, err := registry.NewRemoteIndex(context.TODO(), subject.logger, tmpDir, "oci", indexRef, authn.DefaultKeychain)
+				h.AssertNil(t, err)
+				bp, err := index.LocateBuildpack("heroku/java-function@1.1.1")
+				h.AssertNil(t, err)
+				h.AssertEq(t, bp.Address, "example.com/buildpack/image@sha256:8c27fe111c11b722081701dfed3bd55e039b9ce92865473cf4cdfa918071c566")
+
+				h.AssertNil(t, subject.YankBuildpack(YankBuildpackOptions{
+					ID:      "heroku/java-function",
+					Version: "1.1.1",
+					Type:    "oci",
+					URL:     indexRef,
+					Yank:    true,
+				}))
+				bp, err = index.LocateBuildpack("heroku/java-function@1.1.1")
+				h.AssertNil(t, err)
+				h.AssertTrue(t, bp.Yanked)
+			})
+		})
+
 		it("should return error for missing image label (git)", func() {
 			fakeAppImage = fakes.NewImage("missinglabel/image", "", &fakeIdentifier{name: "buildpack-image"})
 			h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.buildpackage.metadata", `{}`))
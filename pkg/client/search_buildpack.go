@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// SearchBuildpackOptions are options available for SearchBuildpacks
+type SearchBuildpackOptions struct {
+	// Term to match against the IDs of buildpacks, e.g. "nodejs". It may end with a version or semver range, e.g.
+	// "paketo-buildpacks/nodejs@^1.2", to only match those versions. Every buildpack matches an empty term.
+	Term string
+
+	// RegistryNames of the registries to search.
+	RegistryNames []string
+}
+
+// BuildpackSearchResult is a version of a buildpack found in a registry
+type BuildpackSearchResult struct {
+	// Registry the buildpack was found in.
+	Registry string
+
+	// ID of the buildpack, e.g. "<namespace>/<name>".
+	ID        string
+	Namespace string
+	Name      string
+	Version   string
+
+	// Address of the buildpack image.
+	Address string
+
+	// Yanked versions should no longer be used.
+	Yanked bool
+}
+
+// SearchBuildpacks returns the versions of buildpacks in the given registries that match a search term. Results are
+// ordered by registry, ID and version.
+func (c *Client) SearchBuildpacks(opts SearchBuildpackOptions) ([]BuildpackSearchResult, error) {
+	term, version, _ := strings.Cut(strings.ToLower(strings.TrimSpace(opts.Term)), "@")
+
+	var versionRange *semver.Constraints
+	if version != "" {
+		var err error
+		if versionRange, err = registry.ParseVersionRange(version); err != nil {
+			return nil, errors.Wrapf(err, "parsing version %s", style.Symbol(version))
+		}
+	}
+
+	var results []BuildpackSearchResult
+	for _, registryName := range opts.RegistryNames {
+		index, err := getRegistryIndex(context.Background(), c.logger, c.keychain, registryName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid registry %s", style.Symbol(registryName))
+		}
+
+		buildpacks, err := index.Buildpacks()
+		if err != nil {
+			c.logger.Warnf("Unable to search registry %s: %s", style.Symbol(registryName), err)
+			continue
+		}
+
+		var registryResults []BuildpackSearchResult
+		for _, bp := range buildpacks {
+			id := bp.Namespace + "/" + bp.Name
+			if !strings.Contains(id, term) || !inVersionRange(bp.Version, versionRange) {
+				continue
+			}
+
+			registryResults = append(registryResults, BuildpackSearchResult{
+				Registry:  registryName,
+				ID:        id,
+				Namespace: bp.Namespace,
+				Name:      bp.Name,
+				Version:   bp.Version,
+				Address:   bp.Address,
+				Yanked:    bp.Yanked,
+			})
+		}
+
+		sort.SliceStable(registryResults, func(i, j int) bool {
+			if registryResults[i].ID != registryResults[j].ID {
+				return registryResults[i].ID < registryResults[j].ID
+			}
+			return versionLess(registryResults[i].Version, registryResults[j].Version)
+		})
+		results = append(results, registryResults...)
+	}
+
+	return results, nil
+}
+
+func inVersionRange(version string, versionRange *semver.Constraints) bool {
+	if versionRange == nil {
+		return true
+	}
+
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return false
+	}
+	return versionRange.Check(v)
+}
+
+func versionLess(a, b string) bool {
+	va, errA := semver.NewVersion(a)
+	vb, errB := semver.NewVersion(b)
+	if errA != nil || errB != nil {
+		return a < b
+	}
+	return va.LessThan(vb)
+}
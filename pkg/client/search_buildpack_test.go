@@ -0,0 +1,113 @@
+package client_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	cfg "github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSearchBuildpacks(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "SearchBuildpacks", testSearchBuildpacks, spec.Sequential(), spec.Report(report.Terminal{}))
+}
+
+func testSearchBuildpacks(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject *client.Client
+		tmpDir  string
+		server  *h.RegistryIndexServer
+		out     bytes.Buffer
+	)
+
+	it.Before(func() {
+		var err error
+		subject, err = client.NewClient(client.WithLogger(logging.NewLogWithWriters(&out, &out)))
+		h.AssertNil(t, err)
+
+		tmpDir, err = os.MkdirTemp("", "search-buildpacks")
+		h.AssertNil(t, err)
+		registryFixture := h.CreateRegistryFixture(t, tmpDir, filepath.Join("testdata", "registry"))
+
+		server = h.NewRegistryIndexServer("", "")
+		server.SetIndex(`{"buildpacks": [
+  {"ns": "other", "name": "foo", "version": "2.0.0", "addr": "example.com/other/foo@sha256:2560f05307e8de9d830f144d09556e19dd1eb7d928aee900ed02208ae9727e7a"},
+  {"ns": "other", "name": "foo", "version": "1.10.0", "yanked": true, "addr": "example.com/other/foo@sha256:74eb48882e835d8767f62940d453eb96ed2737de3a16573881dcea7dea769df7"}
+]}`)
+
+		packHome := filepath.Join(tmpDir, "packHome")
+		h.AssertNil(t, os.Setenv("PACK_HOME", packHome))
+		h.AssertNil(t, cfg.Write(cfg.Config{
+			Registries: []cfg.Registry{
+				{Name: "some-registry", Type: "github", URL: registryFixture},
+				{Name: "http-registry", Type: "http", URL: server.IndexURL()},
+			},
+		}, filepath.Join(packHome, "config.toml")))
+	})
+
+	it.After(func() {
+		server.Close()
+		os.Unsetenv("PACK_HOME")
+		_ = os.RemoveAll(tmpDir)
+	})
+
+	it("returns matching buildpacks from every registry", func() {
+		results, err := subject.SearchBuildpacks(client.SearchBuildpackOptions{
+			Term:          "foo",
+			RegistryNames: []string{"some-registry", "http-registry"},
+		})
+		h.AssertNil(t, err)
+
+		var found []string
+		for _, result := range results {
+			found = append(found, result.Registry+" "+result.ID+"@"+result.Version)
+		}
+		h.AssertEq(t, found, []string{
+			"some-registry example/foo@1.0.0",
+			"some-registry example/foo@1.1.0",
+			"some-registry example/foo@1.2.0",
+			"http-registry other/foo@1.10.0",
+			"http-registry other/foo@2.0.0",
+		})
+		h.AssertEq(t, results[3].Yanked, true)
+		h.AssertEq(t, results[3].Address, "example.com/other/foo@sha256:74eb48882e835d8767f62940d453eb96ed2737de3a16573881dcea7dea769df7")
+	})
+
+	it("only returns versions in the range", func() {
+		results, err := subject.SearchBuildpacks(client.SearchBuildpackOptions{
+			Term:          "example/foo@>=1.0 <1.2",
+			RegistryNames: []string{"some-registry", "http-registry"},
+		})
+		h.AssertNil(t, err)
+
+		h.AssertEq(t, len(results), 2)
+		h.AssertEq(t, results[0].Version, "1.0.0")
+		h.AssertEq(t, results[1].Version, "1.1.0")
+	})
+
+	it("fails for an invalid range", func() {
+		_, err := subject.SearchBuildpacks(client.SearchBuildpackOptions{
+			Term:          "example/foo@latest",
+			RegistryNames: []string{"some-registry"},
+		})
+		h.AssertError(t, err, "parsing version 'latest'")
+	})
+
+	it("fails for an unknown registry", func() {
+		_, err := subject.SearchBuildpacks(client.SearchBuildpackOptions{
+			Term:          "foo",
+			RegistryNames: []string{"unknown-registry"},
+		})
+		h.AssertError(t, err, "invalid registry 'unknown-registry'")
+	})
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+
+	"github.com/buildpacks/imgutil"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/tracing"
+)
+
+// startSpan starts the root span of a client operation. Spans of nested operations,
+// including lifecycle phase containers, are created as children of the returned context.
+func (c *Client) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if c.tracerProvider == nil {
+		return tracing.Start(ctx, name, attrs...)
+	}
+	return tracing.StartWithProvider(ctx, c.tracerProvider, name, attrs...)
+}
+
+// traceSave runs save within a span recording the image being written to the daemon or pushed to a registry.
+func traceSave(ctx context.Context, imageName string, publish bool, save func() error) error {
+	_, span := tracing.Start(ctx, "image.save",
+		attribute.String("pack.image.name", imageName),
+		attribute.Bool("pack.publish", publish),
+	)
+	err := save()
+	tracing.End(span, err)
+	return err
+}
+
+type tracingImageFetcher struct {
+	fetcher ImageFetcher
+}
+
+func (f *tracingImageFetcher) Fetch(ctx context.Context, name string, options image.FetchOptions) (imgutil.Image, error) {
+	ctx, span := tracing.Start(ctx, "image.fetch",
+		attribute.String("pack.image.name", name),
+		attribute.Bool("pack.image.daemon", options.Daemon),
+		attribute.String("pack.image.pull_policy", options.PullPolicy.String()),
+		attribute.String("pack.image.platform", options.Platform),
+	)
+	img, err := f.fetcher.Fetch(ctx, name, options)
+	tracing.End(span, err)
+	return img, err
+}
+
+type tracingBlobDownloader struct {
+	downloader BlobDownloader
+}
+
+func (d *tracingBlobDownloader) Download(ctx context.Context, pathOrURI string) (blob.Blob, error) {
+	ctx, span := tracing.Start(ctx, "blob.download", attribute.String("pack.blob.uri", pathOrURI))
+	b, err := d.downloader.Download(ctx, pathOrURI)
+	tracing.End(span, err)
+	return b, err
+}
+
+type tracingBuildpackDownloader struct {
+	downloader BuildpackDownloader
+}
+
+func (d *tracingBuildpackDownloader) Download(ctx context.Context, buildpackURI string, opts buildpack.DownloadOptions) (buildpack.BuildModule, []buildpack.BuildModule, error) {
+	ctx, span := tracing.Start(ctx, "buildpack.download",
+		attribute.String("pack.buildpack.uri", buildpackURI),
+		attribute.String("pack.buildpack.registry", opts.RegistryName),
+	)
+	mainBP, deps, err := d.downloader.Download(ctx, buildpackURI, opts)
+	if err == nil {
+		info := mainBP.Descriptor().Info()
+		span.SetAttributes(
+			attribute.String("pack.buildpack.id", info.ID),
+			attribute.String("pack.buildpack.version", info.Version),
+			attribute.Int("pack.buildpack.dependencies", len(deps)),
+		)
+	}
+	tracing.End(span, err)
+	return mainBP, deps, err
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestTracing(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "tracing", testTracing, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testTracing(t *testing.T, when spec.G, it spec.S) {
+	var (
+		recorder         *tracetest.SpanRecorder
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		fakeAppImage     *fakes.Image
+		fakeRunImage     *fakes.Image
+		subject          *Client
+		out              bytes.Buffer
+	)
+
+	it.Before(func() {
+		recorder = tracetest.NewSpanRecorder()
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+
+		fakeAppImage = fakes.NewImage("some/app", "", &fakeIdentifier{name: "app-image"})
+		h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.lifecycle.metadata",
+			`{"stack":{"runImage":{"image":"some/run"}}}`))
+		h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.jammy"))
+		fakeImageFetcher.LocalImages["some/app"] = fakeAppImage
+
+		fakeRunImage = fakes.NewImage("some/run", "run-image-top-layer-sha", &fakeIdentifier{name: "run-image-digest"})
+		h.AssertNil(t, fakeRunImage.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.jammy"))
+		fakeImageFetcher.LocalImages["some/run"] = fakeRunImage
+
+		subject = &Client{
+			logger:         logging.NewLogWithWriters(&out, &out),
+			imageFetcher:   &tracingImageFetcher{fetcher: fakeImageFetcher},
+			accessChecker:  ifakes.NewFakeAccessChecker(),
+			tracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+		}
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, fakeAppImage.Cleanup())
+		h.AssertNilE(t, fakeRunImage.Cleanup())
+	})
+
+	when("a tracer provider is configured", func() {
+		it("emits spans for the operation, image fetches and image save", func() {
+			h.AssertNil(t, subject.Rebase(context.TODO(), RebaseOptions{RepoName: "some/app"}))
+
+			var names []string
+			var root sdktrace.ReadOnlySpan
+			for _, span := range recorder.Ended() {
+				names = append(names, span.Name())
+				if span.Name() == "pack.rebase" {
+					root = span
+				}
+			}
+			h.AssertEq(t, names, []string{"image.fetch", "image.fetch", "image.save", "pack.rebase"})
+
+			for _, span := range recorder.Ended() {
+				h.AssertEq(t, span.SpanContext().TraceID(), root.SpanContext().TraceID())
+				if span != root {
+					h.AssertEq(t, span.Parent().SpanID(), root.SpanContext().SpanID())
+				}
+			}
+		})
+
+		it("records errors on the operation span", func() {
+			h.AssertNotNil(t, subject.Rebase(context.TODO(), RebaseOptions{RepoName: "some/missing-app"}))
+
+			spans := recorder.Ended()
+			h.AssertEq(t, spans[len(spans)-1].Name(), "pack.rebase")
+			h.AssertEq(t, spans[len(spans)-1].Status().Code.String(), "Error")
+		})
+	})
+}
@@ -0,0 +1,323 @@
+package client
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// TrustScope is what a trusted builder is trusted with
+type TrustScope string
+
+const (
+	// TrustScopeNone means the builder is not trusted. Its lifecycle runs without registry credentials, and the
+	// phases that need them run in separate containers using the lifecycle image.
+	TrustScopeNone TrustScope = "none"
+
+	// TrustScopeBuildpacks means the buildpacks of the builder are trusted, but its lifecycle is not. The build runs
+	// as for an untrusted builder, using the lifecycle image for the phases that need registry credentials.
+	TrustScopeBuildpacks TrustScope = "buildpacks"
+
+	// TrustScopeBuilder means the builder, including its lifecycle, is trusted. All phases run in a single container
+	// that is given registry credentials.
+	TrustScopeBuilder TrustScope = "builder"
+
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	trustReportFile = "trust.toml"
+)
+
+// TrustPolicy decides which builders are trusted
+type TrustPolicy struct {
+	// Rules are evaluated in order. The first rule that matches and is satisfied by the builder decides its trust.
+	Rules []TrustRule
+}
+
+// TrustRule trusts the builders matching a pattern, optionally only when they are pinned to a digest or signed
+type TrustRule struct {
+	// Source of the rule, used when reporting the trust decision, e.g. "trusted-builders entry 'gcr.io/my-org/*'".
+	Source string
+
+	// Pattern the builder image name must match. It is either an image name, e.g. "cnbs/sample-builder:jammy", or a
+	// glob matching repositories, e.g. "gcr.io/my-org/*".
+	Pattern string
+
+	// Digests the builder must resolve to. Any digest is accepted when empty.
+	Digests []string
+
+	// SignatureKeys are paths to PEM encoded public keys. When set, the builder must have a cosign signature made with
+	// one of the keys.
+	SignatureKeys []string
+
+	// Scope of the trust. Defaults to TrustScopeBuilder.
+	Scope TrustScope
+}
+
+// TrustDecision records whether a builder was trusted and why
+type TrustDecision struct {
+	Builder string     `toml:"builder"`
+	Digest  string     `toml:"digest,omitempty"`
+	Scope   TrustScope `toml:"scope"`
+	Reason  string     `toml:"reason"`
+}
+
+// TrustsLifecycle returns true if the lifecycle of the builder may be given registry credentials
+func (d TrustDecision) TrustsLifecycle() bool {
+	return d.Scope == TrustScopeBuilder
+}
+
+// MatchesTrustPattern returns true if the builder image name matches the pattern of a trust rule. Patterns without a
+// glob must name the builder exactly, where e.g. "cnbs/sample-builder" is the same name as
+// "index.docker.io/cnbs/sample-builder:latest". Glob patterns are matched against the name with and without its tag
+// or digest, so "gcr.io/my-org/*" and "cnbs/sample-builder:*" match across tags. The registry and repository before
+// the glob are normalized like the builder name, so "docker.io/cnbs/*" matches "index.docker.io/cnbs/sample-builder".
+func MatchesTrustPattern(pattern, builderName string) bool {
+	if pattern == builderName {
+		return true
+	}
+
+	ref, err := name.ParseReference(builderName, name.WeakValidation)
+	if !strings.ContainsAny(pattern, "*?[") {
+		patternRef, patternErr := name.ParseReference(pattern, name.WeakValidation)
+		return err == nil && patternErr == nil && patternRef.Name() == ref.Name()
+	}
+
+	candidates := []string{builderName}
+	if err == nil {
+		candidates = append(candidates, ref.Name(), ref.Context().Name())
+	}
+	for _, p := range []string{pattern, normalizeTrustPattern(pattern)} {
+		for _, candidate := range candidates {
+			if matched, err := path.Match(p, candidate); err == nil && matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizeTrustPattern qualifies the part of a glob pattern before the path segment holding the first glob with its
+// registry, the way name.ParseReference qualifies builder names. Patterns it can't parse are returned as is.
+func normalizeTrustPattern(pattern string) string {
+	slash := strings.LastIndex(pattern[:strings.IndexAny(pattern, "*?[")], "/")
+	if slash < 0 {
+		return pattern
+	}
+	// the repository is parsed with a placeholder in place of the segment holding the glob
+	repo, err := name.NewRepository(pattern[:slash]+"/placeholder", name.WeakValidation)
+	if err != nil {
+		return pattern
+	}
+	return strings.TrimSuffix(repo.Name(), "placeholder") + pattern[slash+1:]
+}
+
+// EvaluateTrust decides whether the builder is trusted by the policy. Rules that require a digest or signature resolve
+// the builder to a digest; the decision then only holds for that digest. When the builder is pinned to a digest, e.g.
+// by a project lock, pinnedDigest is used instead of resolving the builder name.
+func (c *Client) EvaluateTrust(ctx context.Context, builderName, pinnedDigest string, policy TrustPolicy) (TrustDecision, error) {
+	untrusted := TrustDecision{
+		Builder: builderName,
+		Scope:   TrustScopeNone,
+		Reason:  "no trust rule matches the builder",
+	}
+
+	digest := pinnedDigest
+	for _, rule := range policy.Rules {
+		if !MatchesTrustPattern(rule.Pattern, builderName) {
+			continue
+		}
+
+		scope := rule.Scope
+		if scope == "" {
+			scope = TrustScopeBuilder
+		}
+		if scope != TrustScopeBuilder && scope != TrustScopeBuildpacks {
+			return TrustDecision{}, errors.Errorf("invalid trust scope %s in %s, must be one of %s or %s", style.Symbol(string(scope)), rule.Source, TrustScopeBuilder, TrustScopeBuildpacks)
+		}
+
+		if len(rule.Digests) == 0 && len(rule.SignatureKeys) == 0 {
+			return TrustDecision{Builder: builderName, Scope: scope, Reason: "matches " + rule.Source}, nil
+		}
+
+		if digest == "" {
+			var err error
+			if digest, err = c.imageDigest(ctx, builderName); err != nil {
+				untrusted.Reason = fmt.Sprintf("matches %s, but its digest could not be resolved: %s", rule.Source, err)
+				continue
+			}
+		}
+
+		var reasons []string
+		if len(rule.Digests) > 0 {
+			if !contains(rule.Digests, digest) {
+				untrusted.Reason = fmt.Sprintf("matches %s, but its digest %s is not one of the trusted digests", rule.Source, digest)
+				continue
+			}
+			reasons = append(reasons, "is pinned to digest "+digest)
+		}
+
+		if len(rule.SignatureKeys) > 0 {
+			key, err := c.verifySignature(ctx, builderName, digest, rule.SignatureKeys)
+			if err != nil {
+				untrusted.Reason = fmt.Sprintf("matches %s, but its signature could not be verified: %s", rule.Source, err)
+				continue
+			}
+			reasons = append(reasons, "is signed with key "+key)
+		}
+
+		return TrustDecision{
+			Builder: builderName,
+			Digest:  digest,
+			Scope:   scope,
+			Reason:  fmt.Sprintf("matches %s and %s", rule.Source, strings.Join(reasons, " and ")),
+		}, nil
+	}
+
+	return untrusted, nil
+}
+
+// recordTrustDecision logs the trust decision and writes it to the report directory, if any
+func (c *Client) recordTrustDecision(decision TrustDecision, reportDir string) error {
+	switch decision.Scope {
+	case TrustScopeBuilder:
+		c.logger.Infof("Builder %s is trusted: %s", style.Symbol(decision.Builder), decision.Reason)
+	case TrustScopeBuildpacks:
+		c.logger.Infof("Buildpacks of builder %s are trusted, but its lifecycle is not: %s", style.Symbol(decision.Builder), decision.Reason)
+	default:
+		c.logger.Infof("Builder %s is untrusted: %s", style.Symbol(decision.Builder), decision.Reason)
+	}
+
+	if reportDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(reportDir, 0755); err != nil {
+		return errors.Wrap(err, "creating report directory")
+	}
+	f, err := os.Create(filepath.Join(reportDir, trustReportFile))
+	if err != nil {
+		return errors.Wrap(err, "writing trust report")
+	}
+	defer f.Close()
+	return errors.Wrap(toml.NewEncoder(f).Encode(decision), "writing trust report")
+}
+
+// cosignPayload is the part of a cosign simple signing payload that identifies the signed image
+type cosignPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// verifySignature checks that the image with the given digest has a cosign signature made with one of the keys, and
+// returns the path of that key
+func (c *Client) verifySignature(ctx context.Context, imageName, digest string, keyPaths []string) (string, error) {
+	var keys []crypto.PublicKey
+	for _, keyPath := range keyPaths {
+		key, err := readPublicKey(keyPath)
+		if err != nil {
+			return "", err
+		}
+		keys = append(keys, key)
+	}
+
+	ref, err := name.ParseReference(imageName, name.WeakValidation)
+	if err != nil {
+		return "", err
+	}
+	sigRef := ref.Context().Tag(strings.Replace(digest, ":", "-", 1) + ".sig")
+
+	sigImage, err := remote.Image(sigRef, c.remoteOptions(ctx)...)
+	if err != nil {
+		return "", errors.Wrapf(err, "fetching signature %s", style.Symbol(sigRef.Name()))
+	}
+	manifest, err := sigImage.Manifest()
+	if err != nil {
+		return "", errors.Wrapf(err, "reading signature %s", style.Symbol(sigRef.Name()))
+	}
+
+	for _, layer := range manifest.Layers {
+		signature, err := base64.StdEncoding.DecodeString(layer.Annotations[cosignSignatureAnnotation])
+		if err != nil || len(signature) == 0 {
+			continue
+		}
+
+		blob, err := sigImage.LayerByDigest(layer.Digest)
+		if err != nil {
+			return "", errors.Wrapf(err, "reading signature %s", style.Symbol(sigRef.Name()))
+		}
+		rc, err := blob.Compressed()
+		if err != nil {
+			return "", errors.Wrapf(err, "reading signature %s", style.Symbol(sigRef.Name()))
+		}
+		payload, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return "", errors.Wrapf(err, "reading signature %s", style.Symbol(sigRef.Name()))
+		}
+
+		var signed cosignPayload
+		if err := json.Unmarshal(payload, &signed); err != nil || signed.Critical.Image.DockerManifestDigest != digest {
+			continue
+		}
+
+		for i, key := range keys {
+			if verifyPayload(key, payload, signature) {
+				return keyPaths[i], nil
+			}
+		}
+	}
+
+	return "", errors.Errorf("no signature of %s was made with a trusted key", style.Symbol(digest))
+}
+
+func readPublicKey(keyPath string) (crypto.PublicKey, error) {
+	contents, err := os.ReadFile(filepath.Clean(keyPath))
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading public key %s", style.Symbol(keyPath))
+	}
+
+	block, _ := pem.Decode(contents)
+	if block == nil {
+		return nil, errors.Errorf("public key %s is not PEM encoded", style.Symbol(keyPath))
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing public key %s", style.Symbol(keyPath))
+	}
+	return key, nil
+}
+
+func verifyPayload(key crypto.PublicKey, payload, signature []byte) bool {
+	hash := sha256.Sum256(payload)
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, hash[:], signature)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, payload, signature)
+	}
+	return false
+}
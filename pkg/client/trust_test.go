@@ -0,0 +1,237 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestTrust(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Trust", testTrust, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testTrust(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject *Client
+		tmpDir  string
+		out     bytes.Buffer
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "trust")
+		h.AssertNil(t, err)
+
+		subject = &Client{
+			logger:   logging.NewLogWithWriters(&out, &out),
+			keychain: authn.DefaultKeychain,
+		}
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#MatchesTrustPattern", func() {
+		for _, tc := range []struct {
+			pattern, builder string
+			matches          bool
+		}{
+			{"cnbs/sample-builder:jammy", "cnbs/sample-builder:jammy", true},
+			{"cnbs/sample-builder:jammy", "cnbs/sample-builder:bionic", false},
+			{"cnbs/sample-builder", "cnbs/sample-builder:jammy", false},
+			{"cnbs/sample-builder", "cnbs/sample-builder:latest", true},
+			{"cnbs/sample-builder:*", "cnbs/sample-builder:jammy", true},
+			{"cnbs/*", "cnbs/sample-builder:jammy", true},
+			{"gcr.io/my-org/*", "gcr.io/my-org/builder@sha256:" + fmt.Sprintf("%064d", 0), true},
+			{"gcr.io/my-org/*", "gcr.io/other-org/builder", false},
+			{"gcr.io/my-org/*", "gcr.io/my-org/team/builder", false},
+			{"index.docker.io/cnbs/sample-builder:jammy", "cnbs/sample-builder:jammy", true},
+			{"docker.io/cnbs/*", "index.docker.io/cnbs/sample-builder:jammy", true},
+			{"docker.io/cnbs/*", "cnbs/sample-builder:jammy", true},
+			{"index.docker.io/cnbs/sample-builder:*", "cnbs/sample-builder:jammy", true},
+			{"docker.io/cnbs/*", "docker.io/other/sample-builder:jammy", false},
+			{"cnbs/sample-builder:jammy", "cnbs/sample-builder@sha256:" + fmt.Sprintf("%064d", 0), false},
+		} {
+			tc := tc
+			it(fmt.Sprintf("returns %t for %s and %s", tc.matches, tc.pattern, tc.builder), func() {
+				h.AssertEq(t, MatchesTrustPattern(tc.pattern, tc.builder), tc.matches)
+			})
+		}
+	})
+
+	when("#EvaluateTrust", func() {
+		it("trusts builders matching a rule", func() {
+			decision, err := subject.EvaluateTrust(context.TODO(), "cnbs/sample-builder:jammy", "", TrustPolicy{Rules: []TrustRule{
+				{Source: "rule-1", Pattern: "other/*"},
+				{Source: "rule-2", Pattern: "cnbs/*", Scope: TrustScopeBuildpacks},
+			}})
+			h.AssertNil(t, err)
+			h.AssertEq(t, decision, TrustDecision{
+				Builder: "cnbs/sample-builder:jammy",
+				Scope:   TrustScopeBuildpacks,
+				Reason:  "matches rule-2",
+			})
+			h.AssertEq(t, decision.TrustsLifecycle(), false)
+		})
+
+		it("does not trust builders no rule matches", func() {
+			decision, err := subject.EvaluateTrust(context.TODO(), "cnbs/sample-builder:jammy", "", TrustPolicy{Rules: []TrustRule{
+				{Source: "rule-1", Pattern: "other/*"},
+			}})
+			h.AssertNil(t, err)
+			h.AssertEq(t, decision.Scope, TrustScopeNone)
+			h.AssertEq(t, decision.Reason, "no trust rule matches the builder")
+		})
+
+		it("fails for an invalid scope", func() {
+			_, err := subject.EvaluateTrust(context.TODO(), "cnbs/sample-builder:jammy", "", TrustPolicy{Rules: []TrustRule{
+				{Source: "rule-1", Pattern: "cnbs/*", Scope: "everything"},
+			}})
+			h.AssertError(t, err, "invalid trust scope 'everything' in rule-1")
+		})
+
+		when("the builder is in a registry", func() {
+			var (
+				server      *h.RegistryIndexServer
+				builderName string
+				digest      v1.Hash
+				keyPath     string
+				privateKey  *ecdsa.PrivateKey
+			)
+
+			sign := func(key *ecdsa.PrivateKey, signedDigest string) {
+				payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"%s"},"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`, builderName, signedDigest))
+				hash := sha256.Sum256(payload)
+				signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+				h.AssertNil(t, err)
+
+				sigImage, err := mutate.Append(empty.Image, mutate.Addendum{
+					Layer:       static.NewLayer(payload, "application/vnd.dev.cosign.simplesigning.v1+json"),
+					Annotations: map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+				})
+				h.AssertNil(t, err)
+				sigImage = mutate.MediaType(sigImage, types.OCIManifestSchema1)
+
+				sigRef, err := name.NewTag(fmt.Sprintf("%s/some/builder:%s-%s.sig", server.Host(), digest.Algorithm, digest.Hex))
+				h.AssertNil(t, err)
+				h.AssertNil(t, remote.Write(sigRef, sigImage))
+			}
+
+			it.Before(func() {
+				server = h.NewRegistryIndexServer("", "")
+				builderName = server.Host() + "/some/builder:latest"
+
+				ref, err := name.ParseReference(builderName)
+				h.AssertNil(t, err)
+				img, err := random.Image(1024, 1)
+				h.AssertNil(t, err)
+				h.AssertNil(t, remote.Write(ref, img))
+				digest, err = img.Digest()
+				h.AssertNil(t, err)
+
+				privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				h.AssertNil(t, err)
+				publicKey, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+				h.AssertNil(t, err)
+				keyPath = filepath.Join(tmpDir, "cosign.pub")
+				h.AssertNil(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}), 0600))
+			})
+
+			it.After(func() {
+				server.Close()
+			})
+
+			it("trusts builders pinned to a trusted digest", func() {
+				decision, err := subject.EvaluateTrust(context.TODO(), builderName, "", TrustPolicy{Rules: []TrustRule{
+					{Source: "rule-1", Pattern: server.Host() + "/some/*", Digests: []string{digest.String()}},
+				}})
+				h.AssertNil(t, err)
+				h.AssertEq(t, decision.Scope, TrustScopeBuilder)
+				h.AssertEq(t, decision.Digest, digest.String())
+				h.AssertEq(t, decision.Reason, "matches rule-1 and is pinned to digest "+digest.String())
+			})
+
+			it("does not trust builders with another digest", func() {
+				decision, err := subject.EvaluateTrust(context.TODO(), builderName, "", TrustPolicy{Rules: []TrustRule{
+					{Source: "rule-1", Pattern: server.Host() + "/some/*", Digests: []string{"sha256:" + fmt.Sprintf("%064d", 0)}},
+				}})
+				h.AssertNil(t, err)
+				h.AssertEq(t, decision.Scope, TrustScopeNone)
+				h.AssertContains(t, decision.Reason, "is not one of the trusted digests")
+			})
+
+			it("checks the pinned digest instead of resolving the builder", func() {
+				pinned := "sha256:" + fmt.Sprintf("%064d", 0)
+				decision, err := subject.EvaluateTrust(context.TODO(), builderName, pinned, TrustPolicy{Rules: []TrustRule{
+					{Source: "rule-1", Pattern: builderName, Digests: []string{digest.String()}},
+				}})
+				h.AssertNil(t, err)
+				h.AssertEq(t, decision.Scope, TrustScopeNone)
+				h.AssertContains(t, decision.Reason, "its digest "+pinned+" is not one of the trusted digests")
+			})
+
+			it("trusts builders signed with a trusted key", func() {
+				sign(privateKey, digest.String())
+
+				decision, err := subject.EvaluateTrust(context.TODO(), builderName, "", TrustPolicy{Rules: []TrustRule{
+					{Source: "rule-1", Pattern: server.Host() + "/some/*", SignatureKeys: []string{keyPath}},
+				}})
+				h.AssertNil(t, err)
+				h.AssertEq(t, decision.Scope, TrustScopeBuilder)
+				h.AssertEq(t, decision.Reason, "matches rule-1 and is signed with key "+keyPath)
+			})
+
+			it("does not trust builders signed with another key", func() {
+				otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				h.AssertNil(t, err)
+				sign(otherKey, digest.String())
+
+				decision, err := subject.EvaluateTrust(context.TODO(), builderName, "", TrustPolicy{Rules: []TrustRule{
+					{Source: "rule-1", Pattern: server.Host() + "/some/*", SignatureKeys: []string{keyPath}},
+				}})
+				h.AssertNil(t, err)
+				h.AssertEq(t, decision.Scope, TrustScopeNone)
+				h.AssertContains(t, decision.Reason, "no signature of '"+digest.String()+"' was made with a trusted key")
+			})
+
+			it("does not trust unsigned builders", func() {
+				decision, err := subject.EvaluateTrust(context.TODO(), builderName, "", TrustPolicy{Rules: []TrustRule{
+					{Source: "rule-1", Pattern: server.Host() + "/some/*", SignatureKeys: []string{keyPath}},
+					{Source: "rule-2", Pattern: server.Host() + "/some/*", Scope: TrustScopeBuildpacks},
+				}})
+				h.AssertNil(t, err)
+				h.AssertEq(t, decision.Scope, TrustScopeBuildpacks)
+				h.AssertEq(t, decision.Reason, "matches rule-2")
+			})
+		})
+	})
+}
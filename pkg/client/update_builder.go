@@ -0,0 +1,264 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/tracing"
+)
+
+// UpdateBuilderOptions is a configuration object used to change the behavior of
+// UpdateBuilder.
+type UpdateBuilderOptions struct {
+	// The base directory to use to resolve relative assets
+	RelativeBaseDir string
+
+	// Name of the builder to update.
+	BuilderName string
+
+	// Buildpacks to add to the builder. Other versions of the same buildpacks are removed from the builder, unless
+	// composite buildpacks on the builder still use them, and references to them in the builder order are updated.
+	Buildpacks []string
+
+	// Lifecycle to replace the lifecycle of the builder with. The lifecycle is kept when empty.
+	Lifecycle pubbldr.LifecycleConfig
+
+	// Run images to replace the run images of the builder with. The first one is the default. Mirrors of run images
+	// already on the builder are kept.
+	RunImages []string
+
+	// Update the builder in the registry instead of the daemon.
+	Publish bool
+
+	// Buildpack registry name. Defines where all registry buildpacks will be pulled from.
+	Registry string
+
+	// Strategy for updating images before an update.
+	PullPolicy image.PullPolicy
+}
+
+// UpdateBuilder updates the buildpacks, lifecycle or run images of an existing builder. Only the layers whose contents
+// changed are added to the builder image; all other layers are reused. Replaced buildpacks are hidden by whiteout
+// layers, so their layers stay in the image and it does not get smaller.
+func (c *Client) UpdateBuilder(ctx context.Context, opts UpdateBuilderOptions) (err error) {
+	ctx, span := c.startSpan(ctx, "pack.update_builder",
+		attribute.String("pack.builder", opts.BuilderName),
+		attribute.Bool("pack.publish", opts.Publish),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	img, err := c.imageFetcher.Fetch(ctx, opts.BuilderName, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy})
+	if err != nil {
+		return errors.Wrapf(err, "fetching builder image %s", style.Symbol(opts.BuilderName))
+	}
+
+	bldr, err := builder.FromImage(img)
+	if err != nil {
+		return errors.Wrapf(err, "invalid builder %s", style.Symbol(opts.BuilderName))
+	}
+
+	createOpts := CreateBuilderOptions{
+		RelativeBaseDir: opts.RelativeBaseDir,
+		BuilderName:     opts.BuilderName,
+		Publish:         opts.Publish,
+		Registry:        opts.Registry,
+		PullPolicy:      opts.PullPolicy,
+	}
+
+	if opts.Lifecycle.Version != "" || opts.Lifecycle.URI != "" {
+		if err := c.updateLifecycle(ctx, opts, bldr); err != nil {
+			return err
+		}
+	}
+
+	if len(opts.Buildpacks) > 0 {
+		if err := c.updateBuildpacks(ctx, opts.Buildpacks, createOpts, bldr); err != nil {
+			return errors.Wrap(err, "failed to update buildpacks")
+		}
+	}
+
+	if len(opts.RunImages) > 0 {
+		if err := c.updateRunImages(ctx, opts.RunImages, createOpts, bldr); err != nil {
+			return err
+		}
+	}
+
+	return traceSave(ctx, opts.BuilderName, opts.Publish, func() error {
+		return bldr.Save(c.logger, builder.CreatorMetadata{Version: c.version})
+	})
+}
+
+func (c *Client) updateLifecycle(ctx context.Context, opts UpdateBuilderOptions, bldr *builder.Builder) error {
+	os, err := bldr.Image().OS()
+	if err != nil {
+		return errors.Wrap(err, "lookup image OS")
+	}
+	architecture, err := bldr.Image().Architecture()
+	if err != nil {
+		return errors.Wrap(err, "lookup image Architecture")
+	}
+
+	lifecycle, err := c.fetchLifecycle(ctx, opts.Lifecycle, opts.RelativeBaseDir, os, architecture)
+	if err != nil {
+		return errors.Wrap(err, "fetch lifecycle")
+	}
+
+	c.logger.Infof("Updating lifecycle to %s", style.Symbol(lifecycle.Descriptor().Info.Version.String()))
+	bldr.SetLifecycle(lifecycle)
+	return nil
+}
+
+// updateBuildpacks adds the buildpacks to the builder in place of the versions already on it, and points the order of
+// the builder at the new versions. Versions still used by the order of a composite buildpack are kept.
+func (c *Client) updateBuildpacks(ctx context.Context, uris []string, opts CreateBuilderOptions, bldr *builder.Builder) error {
+	bpLayers := dist.ModuleLayers{}
+	if _, err := dist.GetLabel(bldr.Image(), dist.BuildpackLayersLabel, &bpLayers); err != nil {
+		return errors.Wrapf(err, "getting label %s", dist.BuildpackLayersLabel)
+	}
+
+	var (
+		replaced []dist.ModuleInfo
+		added    []buildpack.BuildModule
+		updated  = map[string]string{}
+	)
+	for _, uri := range uris {
+		mainBP, depBPs, err := c.downloadModule(ctx, buildpack.KindBuildpack, pubbldr.ModuleConfig{
+			ImageOrURI: dist.ImageOrURI{BuildpackURI: dist.BuildpackURI{URI: uri}},
+		}, opts, bldr)
+		if err != nil {
+			return err
+		}
+
+		info := mainBP.Descriptor().Info()
+		var found bool
+		for _, existing := range bldr.Buildpacks() {
+			if existing.ID != info.ID {
+				continue
+			}
+			found = true
+			if existing.Version == info.Version {
+				// the same version is added again below
+				bldr.RemoveBuildpack(existing)
+				continue
+			}
+			c.logger.Infof("Updating buildpack %s from %s to %s", style.Symbol(info.ID), style.Symbol(existing.Version), style.Symbol(info.Version))
+			replaced = append(replaced, existing)
+		}
+		if !found {
+			c.logger.Infof("Adding buildpack %s", style.Symbol(info.FullName()))
+		}
+
+		bldr.AddBuildpacks(mainBP, depBPs)
+		added = append(added, mainBP)
+		added = append(added, depBPs...)
+		updated[info.ID] = info.Version
+	}
+
+	used := usedByComposites(bpLayers, replaced, added)
+	for _, info := range replaced {
+		if composite, ok := used[info.FullName()]; ok {
+			c.logger.Infof("Keeping buildpack %s, used by %s", style.Symbol(info.FullName()), style.Symbol(composite))
+			continue
+		}
+		if hasModule(added, info) {
+			continue
+		}
+		bldr.RemoveBuildpack(info)
+	}
+
+	order := bldr.Order()
+	for _, entry := range order {
+		for i, ref := range entry.Group {
+			if version, ok := updated[ref.ID]; ok && ref.Version != "" {
+				entry.Group[i].Version = version
+			}
+		}
+	}
+	bldr.SetOrder(order)
+	return nil
+}
+
+// usedByComposites maps the buildpacks referenced by the order of a composite buildpack that stays on the builder to
+// the name of that composite buildpack
+func usedByComposites(bpLayers dist.ModuleLayers, replaced []dist.ModuleInfo, added []buildpack.BuildModule) map[string]string {
+	used := map[string]string{}
+	addOrder := func(composite string, order dist.Order) {
+		for _, entry := range order {
+			for _, ref := range entry.Group {
+				if _, ok := used[ref.FullName()]; !ok {
+					used[ref.FullName()] = composite
+				}
+			}
+		}
+	}
+
+	for id, versions := range bpLayers {
+		for version, layer := range versions {
+			info := dist.ModuleInfo{ID: id, Version: version}
+			if containsModuleInfo(replaced, info) {
+				continue
+			}
+			addOrder(info.FullName(), layer.Order)
+		}
+	}
+	for _, module := range added {
+		addOrder(module.Descriptor().Info().FullName(), module.Descriptor().Order())
+	}
+	return used
+}
+
+func containsModuleInfo(modules []dist.ModuleInfo, info dist.ModuleInfo) bool {
+	for _, module := range modules {
+		if module.ID == info.ID && module.Version == info.Version {
+			return true
+		}
+	}
+	return false
+}
+
+func hasModule(modules []buildpack.BuildModule, info dist.ModuleInfo) bool {
+	for _, module := range modules {
+		if bpInfo := module.Descriptor().Info(); bpInfo.ID == info.ID && bpInfo.Version == info.Version {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) updateRunImages(ctx context.Context, runImages []string, opts CreateBuilderOptions, bldr *builder.Builder) error {
+	mirrors := map[string][]string{}
+	for _, runImage := range bldr.RunImages() {
+		if _, ok := mirrors[runImage.Image]; !ok {
+			mirrors[runImage.Image] = runImage.Mirrors
+		}
+	}
+
+	var runConfig pubbldr.RunConfig
+	for _, runImage := range runImages {
+		runConfig.Images = append(runConfig.Images, pubbldr.RunImageConfig{Image: runImage, Mirrors: mirrors[runImage]})
+	}
+
+	opts.Config = pubbldr.Config{Run: runConfig, Stack: pubbldr.StackConfig{ID: bldr.StackID}}
+	if err := c.validateRunImageConfig(ctx, opts); err != nil {
+		return errors.Wrap(err, "invalid run image config")
+	}
+
+	c.logger.Infof("Updating run image to %s", style.Symbol(runImages[0]))
+	bldr.SetRunImage(runConfig)
+	if bldr.Stack().RunImage.Image != "" {
+		bldr.SetStack(pubbldr.StackConfig{
+			ID:              bldr.StackID,
+			RunImage:        runConfig.Images[0].Image,
+			RunImageMirrors: runConfig.Images[0].Mirrors,
+		})
+	}
+	return nil
+}
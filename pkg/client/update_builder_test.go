@@ -0,0 +1,213 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/builder"
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestUpdateBuilder(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "update_builder", testUpdateBuilder, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testUpdateBuilder(t *testing.T, when spec.G, it spec.S) {
+	when("#UpdateBuilder", func() {
+		var (
+			mockController          *gomock.Controller
+			mockDownloader          *testmocks.MockBlobDownloader
+			mockBuildpackDownloader *testmocks.MockBuildpackDownloader
+			mockImageFetcher        *testmocks.MockImageFetcher
+			fakeBuilderImage        *fakes.Image
+			subject                 *client.Client
+			out                     bytes.Buffer
+		)
+
+		it.Before(func() {
+			logger := logging.NewLogWithWriters(&out, &out, logging.WithVerbose())
+			mockController = gomock.NewController(t)
+			mockDownloader = testmocks.NewMockBlobDownloader(mockController)
+			mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+			mockBuildpackDownloader = testmocks.NewMockBuildpackDownloader(mockController)
+
+			fakeBuilderImage = fakes.NewImage("some/builder", "", nil)
+			h.AssertNil(t, fakeBuilderImage.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+			h.AssertNil(t, fakeBuilderImage.SetLabel("io.buildpacks.stack.mixins", `["mixinX", "build:mixinY"]`))
+			h.AssertNil(t, fakeBuilderImage.SetEnv("CNB_USER_ID", "1234"))
+			h.AssertNil(t, fakeBuilderImage.SetEnv("CNB_GROUP_ID", "4321"))
+
+			// create the builder that is updated
+			bldr, err := builder.New(fakeBuilderImage, "some/builder")
+			h.AssertNil(t, err)
+			lifecycle, err := builder.NewLifecycle(blob.NewBlob(filepath.Join("testdata", "lifecycle", "platform-0.4")))
+			h.AssertNil(t, err)
+			bldr.SetLifecycle(lifecycle)
+			bp, err := buildpack.FromBuildpackRootBlob(blob.NewBlob(filepath.Join("testdata", "buildpack")), archive.DefaultTarWriterFactory())
+			h.AssertNil(t, err)
+			bldr.AddBuildpack(bp)
+			bldr.SetOrder(dist.Order{{Group: []dist.ModuleRef{{ModuleInfo: dist.ModuleInfo{ID: "bp.one", Version: "1.2.3"}}}}})
+			bldr.SetRunImage(pubbldr.RunConfig{Images: []pubbldr.RunImageConfig{{
+				Image:   "some/run-image",
+				Mirrors: []string{"localhost:5000/some/run-image"},
+			}}})
+			h.AssertNil(t, bldr.Save(logger, builder.CreatorMetadata{}))
+
+			mockImageFetcher.EXPECT().
+				Fetch(gomock.Any(), "some/builder", image.FetchOptions{Daemon: true, PullPolicy: image.PullAlways}).
+				Return(fakeBuilderImage, nil)
+
+			subject, err = client.NewClient(
+				client.WithLogger(logger),
+				client.WithDownloader(mockDownloader),
+				client.WithFetcher(mockImageFetcher),
+				client.WithBuildpackDownloader(mockBuildpackDownloader),
+			)
+			h.AssertNil(t, err)
+		})
+
+		it.After(func() {
+			mockController.Finish()
+			h.AssertNilE(t, fakeBuilderImage.Cleanup())
+		})
+
+		it("replaces buildpacks with their new versions", func() {
+			newBP, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+				WithAPI:    api.MustParse("0.3"),
+				WithInfo:   dist.ModuleInfo{ID: "bp.one", Version: "1.3.0"},
+				WithStacks: []dist.Stack{{ID: "some.stack.id"}},
+			}, 0644)
+			h.AssertNil(t, err)
+			mockBuildpackDownloader.EXPECT().Download(gomock.Any(), "bp.one@1.3.0", gomock.Any()).Return(newBP, nil, nil)
+
+			h.AssertNil(t, subject.UpdateBuilder(context.TODO(), client.UpdateBuilderOptions{
+				BuilderName: "some/builder",
+				Buildpacks:  []string{"bp.one@1.3.0"},
+				PullPolicy:  image.PullAlways,
+			}))
+			h.AssertContains(t, out.String(), "Updating buildpack 'bp.one' from '1.2.3' to '1.3.0'")
+
+			bldr, err := builder.FromImage(fakeBuilderImage)
+			h.AssertNil(t, err)
+			h.AssertEq(t, bldr.Buildpacks(), []dist.ModuleInfo{{ID: "bp.one", Version: "1.3.0"}})
+			h.AssertEq(t, bldr.Order()[0].Group[0].ModuleInfo, dist.ModuleInfo{ID: "bp.one", Version: "1.3.0"})
+
+			_, err = fakeBuilderImage.FindLayerWithPath("/cnb/buildpacks/bp.one/.wh.1.2.3")
+			h.AssertNil(t, err)
+			_, err = fakeBuilderImage.FindLayerWithPath("/cnb/buildpacks/bp.one/1.3.0")
+			h.AssertNil(t, err)
+		})
+
+		it("keeps the versions used by composite buildpacks", func() {
+			bldr, err := builder.FromImage(fakeBuilderImage)
+			h.AssertNil(t, err)
+			compositeBP, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+				WithAPI:  api.MustParse("0.3"),
+				WithInfo: dist.ModuleInfo{ID: "some.composite", Version: "1.0.0"},
+				WithOrder: dist.Order{{Group: []dist.ModuleRef{
+					{ModuleInfo: dist.ModuleInfo{ID: "bp.one", Version: "1.2.3"}},
+				}}},
+			}, 0644)
+			h.AssertNil(t, err)
+			bldr.AddBuildpack(compositeBP)
+			h.AssertNil(t, bldr.Save(logging.NewSimpleLogger(&out), builder.CreatorMetadata{}))
+
+			newBP, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+				WithAPI:    api.MustParse("0.3"),
+				WithInfo:   dist.ModuleInfo{ID: "bp.one", Version: "1.3.0"},
+				WithStacks: []dist.Stack{{ID: "some.stack.id"}},
+			}, 0644)
+			h.AssertNil(t, err)
+			mockBuildpackDownloader.EXPECT().Download(gomock.Any(), "bp.one@1.3.0", gomock.Any()).Return(newBP, nil, nil)
+
+			h.AssertNil(t, subject.UpdateBuilder(context.TODO(), client.UpdateBuilderOptions{
+				BuilderName: "some/builder",
+				Buildpacks:  []string{"bp.one@1.3.0"},
+				PullPolicy:  image.PullAlways,
+			}))
+			h.AssertContains(t, out.String(), "Keeping buildpack 'bp.one@1.2.3', used by 'some.composite@1.0.0'")
+
+			bldr, err = builder.FromImage(fakeBuilderImage)
+			h.AssertNil(t, err)
+			h.AssertEq(t, bldr.Buildpacks(), []dist.ModuleInfo{
+				{ID: "bp.one", Version: "1.2.3", Homepage: "http://one.buildpack"},
+				{ID: "some.composite", Version: "1.0.0"},
+				{ID: "bp.one", Version: "1.3.0"},
+			})
+			h.AssertEq(t, bldr.Order()[0].Group[0].ModuleInfo, dist.ModuleInfo{ID: "bp.one", Version: "1.3.0"})
+
+			_, err = fakeBuilderImage.FindLayerWithPath("/cnb/buildpacks/bp.one/.wh.1.2.3")
+			h.AssertNotNil(t, err)
+		})
+
+		it("replaces the lifecycle", func() {
+			mockDownloader.EXPECT().
+				Download(gomock.Any(), "https://github.com/buildpacks/lifecycle/releases/download/v0.18.5/lifecycle-v0.18.5+linux.x86-64.tgz").
+				Return(blob.NewBlob(filepath.Join("testdata", "lifecycle", "platform-0.4")), nil)
+
+			h.AssertNil(t, subject.UpdateBuilder(context.TODO(), client.UpdateBuilderOptions{
+				BuilderName: "some/builder",
+				Lifecycle:   pubbldr.LifecycleConfig{Version: "0.18.5"},
+				PullPolicy:  image.PullAlways,
+			}))
+			h.AssertContains(t, out.String(), "Updating lifecycle to '0.0.0'")
+		})
+
+		it("replaces the run images and keeps their mirrors", func() {
+			for _, name := range []string{"some/other-run-image", "some/run-image"} {
+				runImage := fakes.NewImage(name, "", nil)
+				h.AssertNil(t, runImage.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+				mockImageFetcher.EXPECT().Fetch(gomock.Any(), name, gomock.Any()).Return(runImage, nil)
+			}
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "localhost:5000/some/run-image", gomock.Any()).Return(nil, image.ErrNotFound).Times(2)
+
+			h.AssertNil(t, subject.UpdateBuilder(context.TODO(), client.UpdateBuilderOptions{
+				BuilderName: "some/builder",
+				RunImages:   []string{"some/other-run-image", "some/run-image"},
+				PullPolicy:  image.PullAlways,
+			}))
+
+			bldr, err := builder.FromImage(fakeBuilderImage)
+			h.AssertNil(t, err)
+			h.AssertEq(t, bldr.DefaultRunImage().Image, "some/other-run-image")
+			h.AssertEq(t, bldr.RunImages()[1], builder.RunImageMetadata{
+				Image:   "some/run-image",
+				Mirrors: []string{"localhost:5000/some/run-image"},
+			})
+		})
+
+		it("keeps the layers of unchanged parts", func() {
+			layersBefore := fakeBuilderImage.NumberOfAddedLayers()
+
+			h.AssertNil(t, subject.UpdateBuilder(context.TODO(), client.UpdateBuilderOptions{
+				BuilderName: "some/builder",
+				PullPolicy:  image.PullAlways,
+			}))
+
+			_, err := fakeBuilderImage.FindLayerWithPath("/cnb/buildpacks/bp.one/1.2.3")
+			h.AssertNil(t, err)
+			h.AssertEq(t, fakeBuilderImage.NumberOfAddedLayers()-layersBefore, 0)
+		})
+	})
+}
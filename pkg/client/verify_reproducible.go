@@ -0,0 +1,337 @@
+package client
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/docker/docker/api/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// ReproducibilityReport describes the differences between two builds of the same source.
+type ReproducibilityReport struct {
+	// Names of the compared images.
+	ImageA string
+	ImageB string
+
+	// Fields of the image config that differ.
+	Config []ConfigDiff
+
+	// Layers contributed by buildpacks or the lifecycle that differ.
+	Layers []LayerReproducibility
+}
+
+// ConfigDiff describes a field of the image config that differs between two images.
+type ConfigDiff struct {
+	// Name of the field, e.g. "created" or "label io.buildpacks.build.metadata".
+	Field string
+
+	Before string
+	After  string
+}
+
+// LayerReproducibility describes a named layer that differs between two builds, and the files that differ within it.
+type LayerReproducibility struct {
+	LayerDiff
+
+	// Files that differ within the layer. Only set if the layer is present in both images.
+	Files []FileDiff
+}
+
+// FileDiff describes a file that differs between two versions of a layer.
+type FileDiff struct {
+	Path   string
+	Status DiffStatus
+
+	// The attributes of the file that changed, e.g. "mtime 1980-01-01T00:00:01Z -> 2024-01-01T00:00:00Z".
+	Changes []string
+}
+
+// Reproducible returns true if the builds produced identical images.
+func (r *ReproducibilityReport) Reproducible() bool {
+	return len(r.Config) == 0 && len(r.Layers) == 0
+}
+
+// VerifyReproducible builds the app twice, each time with new, empty caches and no previous image, and compares the
+// config and layers of the resulting images. The first build is saved as the requested image.
+//
+// Only builds saved to the daemon are supported.
+func (c *Client) VerifyReproducible(ctx context.Context, opts BuildOptions) (*ReproducibilityReport, error) {
+	if opts.Publish {
+		return nil, errors.New("verifying reproducibility is not supported when publishing")
+	}
+	if opts.Layout() {
+		return nil, errors.New("verifying reproducibility is not supported when exporting to OCI layout")
+	}
+
+	var imageNames []string
+	for i := 0; i < 2; i++ {
+		id := randString(10)
+		buildOpts := opts
+		buildOpts.Image = fmt.Sprintf("pack.local/reproducible/%s:latest", id)
+		buildOpts.PreviousImage = ""
+		buildOpts.CacheImage = ""
+		buildOpts.ClearCache = true
+		buildOpts.Cache = reproducibilityCache("pack-reproducible-" + id)
+		if i > 0 {
+			buildOpts.AdditionalTags = nil
+			buildOpts.ReportDestinationDir = ""
+			buildOpts.SBOMDestinationDir = ""
+		}
+
+		defer c.removeReproducibilityBuild(buildOpts.Image, buildOpts.Cache)
+
+		c.logger.Infof("Building %s for reproducibility check (%d of 2)", style.Symbol(opts.Image), i+1)
+		if err := c.Build(ctx, buildOpts); err != nil {
+			return nil, errors.Wrapf(err, "build %d of 2", i+1)
+		}
+		imageNames = append(imageNames, buildOpts.Image)
+	}
+
+	report, err := c.compareBuilds(ctx, imageNames[0], imageNames[1])
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.docker.ImageTag(ctx, imageNames[0], opts.Image); err != nil {
+		return nil, errors.Wrapf(err, "tagging image %s", style.Symbol(opts.Image))
+	}
+	return report, nil
+}
+
+// reproducibilityCache returns cache options using volumes named after prefix, so that each build is isolated from
+// other builds of the same image.
+func reproducibilityCache(prefix string) cache.CacheOpts {
+	return cache.CacheOpts{
+		Build:  cache.CacheInfo{Format: cache.CacheVolume, Source: prefix + ".build"},
+		Launch: cache.CacheInfo{Format: cache.CacheVolume, Source: prefix + ".launch"},
+		Kaniko: cache.CacheInfo{Format: cache.CacheVolume, Source: prefix + ".kaniko"},
+	}
+}
+
+// removeReproducibilityBuild removes the image and caches of a build made for a reproducibility check, on a best
+// effort basis.
+func (c *Client) removeReproducibilityBuild(imageName string, cacheOpts cache.CacheOpts) {
+	ctx := context.Background()
+	if _, err := c.docker.ImageRemove(ctx, imageName, types.ImageRemoveOptions{PruneChildren: true}); err != nil {
+		c.logger.Debugf("Failed to remove image %s: %s", style.Symbol(imageName), err)
+	}
+	for _, volume := range []string{cacheOpts.Build.Source, cacheOpts.Launch.Source, cacheOpts.Kaniko.Source} {
+		if err := c.docker.VolumeRemove(ctx, volume, true); err != nil {
+			c.logger.Debugf("Failed to remove volume %s: %s", style.Symbol(volume), err)
+		}
+	}
+}
+
+// compareBuilds compares the config and named layers of two images in the daemon.
+func (c *Client) compareBuilds(ctx context.Context, imageA, imageB string) (*ReproducibilityReport, error) {
+	before, err := c.imageFetcher.Fetch(ctx, imageA, image.FetchOptions{Daemon: true, PullPolicy: image.PullNever})
+	if err != nil {
+		return nil, err
+	}
+	after, err := c.imageFetcher.Fetch(ctx, imageB, image.FetchOptions{Daemon: true, PullPolicy: image.PullNever})
+	if err != nil {
+		return nil, err
+	}
+
+	configDiffs, err := diffConfig(before, after)
+	if err != nil {
+		return nil, err
+	}
+
+	beforeLayers, err := namedLayers(before)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading layers of %s", style.Symbol(imageA))
+	}
+	afterLayers, err := namedLayers(after)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading layers of %s", style.Symbol(imageB))
+	}
+
+	report := &ReproducibilityReport{ImageA: imageA, ImageB: imageB, Config: configDiffs}
+	for _, layerDiff := range diffLayers(beforeLayers, afterLayers) {
+		layer := LayerReproducibility{LayerDiff: layerDiff}
+		if layerDiff.Status == DiffChanged {
+			if layer.Files, err = diffLayerFiles(before, after, layerDiff.Before, layerDiff.After); err != nil {
+				return nil, errors.Wrapf(err, "comparing layer %s", style.Symbol(layerDiff.Name))
+			}
+		}
+		report.Layers = append(report.Layers, layer)
+	}
+	return report, nil
+}
+
+// diffConfig compares the image config of two images. The lifecycle metadata label is not compared, as the layers it
+// describes are compared individually.
+func diffConfig(before, after imgutil.Image) ([]ConfigDiff, error) {
+	var diffs []ConfigDiff
+	compare := func(field string, getter func(imgutil.Image) (interface{}, error)) error {
+		beforeValue, err := getter(before)
+		if err != nil {
+			return errors.Wrapf(err, "reading %s", field)
+		}
+		afterValue, err := getter(after)
+		if err != nil {
+			return errors.Wrapf(err, "reading %s", field)
+		}
+		if !reflect.DeepEqual(beforeValue, afterValue) {
+			diffs = append(diffs, ConfigDiff{Field: field, Before: configValue(beforeValue), After: configValue(afterValue)})
+		}
+		return nil
+	}
+
+	for field, getter := range map[string]func(imgutil.Image) (interface{}, error){
+		"created":      func(img imgutil.Image) (interface{}, error) { return img.CreatedAt() },
+		"os":           func(img imgutil.Image) (interface{}, error) { return img.OS() },
+		"architecture": func(img imgutil.Image) (interface{}, error) { return img.Architecture() },
+		"entrypoint":   func(img imgutil.Image) (interface{}, error) { return img.Entrypoint() },
+		"working dir":  func(img imgutil.Image) (interface{}, error) { return img.WorkingDir() },
+		"history":      func(img imgutil.Image) (interface{}, error) { return img.History() },
+	} {
+		if err := compare(field, getter); err != nil {
+			return nil, err
+		}
+	}
+
+	beforeLabels, err := before.Labels()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading labels")
+	}
+	afterLabels, err := after.Labels()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading labels")
+	}
+	for _, key := range unionKeys(beforeLabels, afterLabels) {
+		if key != platform.LifecycleMetadataLabel && beforeLabels[key] != afterLabels[key] {
+			diffs = append(diffs, ConfigDiff{Field: "label " + key, Before: beforeLabels[key], After: afterLabels[key]})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Field < diffs[j].Field })
+	return diffs, nil
+}
+
+func configValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+type layerFile struct {
+	typeflag byte
+	mode     int64
+	uid      int
+	gid      int
+	size     int64
+	modTime  time.Time
+	linkname string
+	digest   string
+}
+
+// diffLayerFiles compares the files of a layer in two images.
+func diffLayerFiles(before, after imgutil.Image, beforeDiffID, afterDiffID string) ([]FileDiff, error) {
+	beforeFiles, err := readLayerFiles(before, beforeDiffID)
+	if err != nil {
+		return nil, err
+	}
+	afterFiles, err := readLayerFiles(after, afterDiffID)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []FileDiff
+	for _, filePath := range unionKeys(beforeFiles, afterFiles) {
+		beforeFile, inBefore := beforeFiles[filePath]
+		afterFile, inAfter := afterFiles[filePath]
+		status, differs := diffStatus(inBefore, inAfter, beforeFile == afterFile)
+		if !differs {
+			continue
+		}
+
+		fileDiff := FileDiff{Path: filePath, Status: status}
+		if status == DiffChanged {
+			fileDiff.Changes = fileChanges(beforeFile, afterFile)
+		}
+		diffs = append(diffs, fileDiff)
+	}
+	return diffs, nil
+}
+
+func readLayerFiles(img imgutil.Image, diffID string) (map[string]layerFile, error) {
+	rc, err := img.GetLayer(diffID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading layer %s", diffID)
+	}
+	defer rc.Close()
+
+	layerFiles := map[string]layerFile{}
+	tr := tar.NewReader(rc)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading layer %s", diffID)
+		}
+
+		file := layerFile{
+			typeflag: header.Typeflag,
+			mode:     header.Mode,
+			uid:      header.Uid,
+			gid:      header.Gid,
+			size:     header.Size,
+			modTime:  header.ModTime.UTC(),
+			linkname: header.Linkname,
+		}
+		if header.Typeflag == tar.TypeReg {
+			hash := sha256.New()
+			if _, err := io.Copy(hash, tr); err != nil {
+				return nil, errors.Wrapf(err, "reading layer %s", diffID)
+			}
+			file.digest = fmt.Sprintf("sha256:%x", hash.Sum(nil))
+		}
+		layerFiles[path.Clean("/"+header.Name)] = file
+	}
+	return layerFiles, nil
+}
+
+func fileChanges(before, after layerFile) []string {
+	var changes []string
+	add := func(attribute string, beforeValue, afterValue interface{}) {
+		if beforeValue != afterValue {
+			changes = append(changes, fmt.Sprintf("%s %v -> %v", attribute, beforeValue, afterValue))
+		}
+	}
+	add("type", string(before.typeflag), string(after.typeflag))
+	add("mode", fmt.Sprintf("%#o", before.mode), fmt.Sprintf("%#o", after.mode))
+	add("uid", before.uid, after.uid)
+	add("gid", before.gid, after.gid)
+	add("size", before.size, after.size)
+	add("mtime", before.modTime.Format(time.RFC3339), after.modTime.Format(time.RFC3339))
+	add("link", before.linkname, after.linkname)
+	add("content", before.digest, after.digest)
+	return changes
+}
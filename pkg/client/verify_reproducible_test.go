@@ -0,0 +1,185 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestVerifyReproducible(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "VerifyReproducible", testVerifyReproducible, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testVerifyReproducible(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		imageA           *fakes.Image
+		imageB           *fakes.Image
+		tmpDir           string
+		out              bytes.Buffer
+	)
+
+	type file struct {
+		name    string
+		content string
+		modTime time.Time
+	}
+
+	writeLayer := func(name string, files ...file) string {
+		layerPath := filepath.Join(tmpDir, name+".tar")
+		f, err := os.Create(layerPath)
+		h.AssertNil(t, err)
+		defer f.Close()
+
+		tw := tar.NewWriter(f)
+		for _, file := range files {
+			h.AssertNil(t, tw.WriteHeader(&tar.Header{
+				Name:     file.name,
+				Typeflag: tar.TypeReg,
+				Mode:     0644,
+				Size:     int64(len(file.content)),
+				ModTime:  file.modTime,
+			}))
+			_, err := tw.Write([]byte(file.content))
+			h.AssertNil(t, err)
+		}
+		h.AssertNil(t, tw.Close())
+		return layerPath
+	}
+
+	newAppImage := func(name, nodeDiffID, nodeLayer string) *fakes.Image {
+		img := fakes.NewImage(name, "", nil)
+		h.AssertNil(t, img.SetCreatedAt(time.Unix(315532801, 0)))
+		h.AssertNil(t, img.SetLabel("io.buildpacks.lifecycle.metadata", `{
+  "app": [{"sha": "sha256:app"}],
+  "buildpacks": [{"key": "some/bp", "layers": {"node": {"sha": "`+nodeDiffID+`"}}}]
+}`))
+		h.AssertNil(t, img.AddLayerWithDiffID(nodeLayer, nodeDiffID))
+		return img
+	}
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "verify-reproducible-test")
+		h.AssertNil(t, err)
+
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: fakeImageFetcher,
+		}
+	})
+
+	it.After(func() {
+		if imageA != nil {
+			h.AssertNilE(t, imageA.Cleanup())
+		}
+		if imageB != nil {
+			h.AssertNilE(t, imageB.Cleanup())
+		}
+		h.AssertNilE(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#compareBuilds", func() {
+		when("the builds are identical", func() {
+			it("reports the builds as reproducible", func() {
+				layer := writeLayer("node", file{name: "layers/some_bp/node/bin/node", content: "node", modTime: time.Unix(315532801, 0)})
+				imageA = newAppImage("some/app-a", "sha256:node", layer)
+				imageB = newAppImage("some/app-b", "sha256:node", layer)
+				fakeImageFetcher.LocalImages[imageA.Name()] = imageA
+				fakeImageFetcher.LocalImages[imageB.Name()] = imageB
+
+				report, err := subject.compareBuilds(context.TODO(), "some/app-a", "some/app-b")
+				h.AssertNil(t, err)
+				h.AssertTrue(t, report.Reproducible())
+			})
+		})
+
+		when("a layer differs", func() {
+			it.Before(func() {
+				normalized := time.Unix(315532801, 0)
+				imageA = newAppImage("some/app-a", "sha256:node-a", writeLayer("node-a",
+					file{name: "layers/some_bp/node/bin/node", content: "node", modTime: normalized},
+					file{name: "layers/some_bp/node/build-id", content: "1234", modTime: normalized},
+				))
+				imageB = newAppImage("some/app-b", "sha256:node-b", writeLayer("node-b",
+					file{name: "layers/some_bp/node/bin/node", content: "node", modTime: time.Unix(1700000000, 0)},
+					file{name: "layers/some_bp/node/build-id", content: "5678", modTime: normalized},
+					file{name: "layers/some_bp/node/extra", content: "extra", modTime: normalized},
+				))
+				h.AssertNil(t, imageA.SetLabel("io.buildpacks.build.metadata", `{"launcher": {"version": "1"}}`))
+				h.AssertNil(t, imageB.SetLabel("io.buildpacks.build.metadata", `{"launcher": {"version": "2"}}`))
+				fakeImageFetcher.LocalImages[imageA.Name()] = imageA
+				fakeImageFetcher.LocalImages[imageB.Name()] = imageB
+			})
+
+			it("maps the layer to its buildpack and reports the files that differ", func() {
+				report, err := subject.compareBuilds(context.TODO(), "some/app-a", "some/app-b")
+				h.AssertNil(t, err)
+				h.AssertFalse(t, report.Reproducible())
+
+				h.AssertEq(t, report.Layers, []LayerReproducibility{{
+					LayerDiff: LayerDiff{
+						Name:      "some/bp:node",
+						Buildpack: "some/bp",
+						Status:    DiffChanged,
+						Before:    "sha256:node-a",
+						After:     "sha256:node-b",
+					},
+					Files: []FileDiff{
+						{
+							Path:    "/layers/some_bp/node/bin/node",
+							Status:  DiffChanged,
+							Changes: []string{"mtime 1980-01-01T00:00:01Z -> 2023-11-14T22:13:20Z"},
+						},
+						{
+							Path:   "/layers/some_bp/node/build-id",
+							Status: DiffChanged,
+							Changes: []string{
+								"content sha256:03ac674216f3e15c761ee1a5e255f067953623c8b388b4459e13f978d7c846f4 -> sha256:f8638b979b2f4f793ddb6dbd197e0ee25a7a6ea32b0ae22f5e3c5d119d839e75",
+							},
+						},
+						{
+							Path:   "/layers/some_bp/node/extra",
+							Status: DiffAdded,
+						},
+					},
+				}})
+			})
+
+			it("reports labels that differ, ignoring the lifecycle metadata", func() {
+				report, err := subject.compareBuilds(context.TODO(), "some/app-a", "some/app-b")
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, report.Config, []ConfigDiff{{
+					Field:  "label io.buildpacks.build.metadata",
+					Before: `{"launcher": {"version": "1"}}`,
+					After:  `{"launcher": {"version": "2"}}`,
+				}})
+			})
+		})
+	})
+
+	when("#VerifyReproducible", func() {
+		it("does not support publishing", func() {
+			_, err := subject.VerifyReproducible(context.TODO(), BuildOptions{Image: "some/app", Publish: true})
+			h.AssertError(t, err, "verifying reproducibility is not supported when publishing")
+		})
+	})
+}
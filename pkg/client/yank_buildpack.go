@@ -1,10 +1,14 @@
 package client
 
 import (
+	"context"
 	"net/url"
 	"runtime"
 
+	"github.com/pkg/errors"
+
 	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/internal/style"
 )
 
 // YankBuildpackOptions is a configuration struct that controls the Yanking a buildpack
@@ -24,6 +28,11 @@ func (c *Client) YankBuildpack(opts YankBuildpackOptions) error {
 	if err != nil {
 		return err
 	}
+
+	if isRemoteIndexType(opts.Type) {
+		return c.yankFromRemoteIndex(namespace, name, opts)
+	}
+
 	issueURL, err := registry.GetIssueURL(opts.URL)
 	if err != nil {
 		return err
@@ -54,3 +63,24 @@ func (c *Client) YankBuildpack(opts YankBuildpackOptions) error {
 
 	return cmd.Start()
 }
+
+// yankFromRemoteIndex adds an entry to the index marking the buildpack version as yanked, or not
+func (c *Client) yankFromRemoteIndex(namespace, name string, opts YankBuildpackOptions) error {
+	index, err := newRemoteIndex(context.Background(), c.logger, c.keychain, opts.Type, opts.URL)
+	if err != nil {
+		return err
+	}
+
+	buildpacks, err := index.Buildpacks()
+	if err != nil {
+		return err
+	}
+
+	for _, bp := range buildpacks {
+		if bp.Namespace == namespace && bp.Name == name && bp.Version == opts.Version {
+			bp.Yanked = opts.Yank
+			return index.Add(bp)
+		}
+	}
+	return errors.Errorf("buildpack %s is not in the registry", style.Symbol(opts.ID+"@"+opts.Version))
+}
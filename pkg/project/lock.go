@@ -0,0 +1,101 @@
+package project
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+)
+
+// LockFileName is the name of the lock file stored next to the project descriptor
+const LockFileName = "project.lock"
+
+// LockVersion is the schema version of lock files written by pack
+const LockVersion = "1"
+
+// Lock records the exact inputs resolved for a build, so that later builds use the same inputs
+type Lock struct {
+	Version    string            `toml:"version"`
+	Builder    LockedImage       `toml:"builder"`
+	RunImage   LockedImage       `toml:"run-image"`
+	Buildpacks []LockedBuildpack `toml:"buildpacks,omitempty"`
+}
+
+// LockedImage is an image name and the digest it resolved to
+type LockedImage struct {
+	// Name of the image as requested, e.g. "paketobuildpacks/builder:base".
+	Name string `toml:"name"`
+
+	// Digest of the image manifest (or manifest list), e.g. "sha256:...".
+	Digest string `toml:"digest"`
+}
+
+// LockedBuildpack is a declared buildpack locator and what it resolved to
+type LockedBuildpack struct {
+	// Locator as declared on the command line or in the project descriptor, e.g. "urn:cnb:registry:example/foo@^1.2".
+	Locator string `toml:"locator"`
+
+	ID      string `toml:"id,omitempty"`
+	Version string `toml:"version,omitempty"`
+
+	// Image the buildpack is packaged in, pinned to a digest. Set for registry and package locators.
+	Image string `toml:"image,omitempty"`
+
+	// URI of the buildpack and the sha256 checksum of its contents. Set for URI locators.
+	URI    string `toml:"uri,omitempty"`
+	SHA256 string `toml:"sha256,omitempty"`
+}
+
+// Reference returns the name of the image pinned to its digest
+func (i LockedImage) Reference() string {
+	if i.Digest == "" {
+		return i.Name
+	}
+	return repository(i.Name) + "@" + i.Digest
+}
+
+// FindBuildpack returns the locked buildpack declared with the given locator
+func (l *Lock) FindBuildpack(locator string) (LockedBuildpack, bool) {
+	for _, bp := range l.Buildpacks {
+		if bp.Locator == locator {
+			return bp, true
+		}
+	}
+	return LockedBuildpack{}, false
+}
+
+// ReadLock reads the lock file at path
+func ReadLock(path string) (Lock, error) {
+	var lock Lock
+	if _, err := toml.DecodeFile(filepath.Clean(path), &lock); err != nil {
+		return Lock{}, errors.Wrapf(err, "reading lock file %s", path)
+	}
+	if lock.Version != LockVersion {
+		return Lock{}, errors.Errorf("unsupported lock file version %s in %s", lock.Version, path)
+	}
+	return lock, nil
+}
+
+// WriteLock writes lock to path
+func WriteLock(path string, lock Lock) error {
+	lock.Version = LockVersion
+
+	var buf bytes.Buffer
+	buf.WriteString("# This file is generated by `pack lock`. Do not edit it by hand.\n")
+	if err := toml.NewEncoder(&buf).Encode(lock); err != nil {
+		return errors.Wrap(err, "encoding lock file")
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// repository strips the tag or digest from an image name
+func repository(name string) string {
+	name, _, _ = strings.Cut(name, "@")
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+	return name
+}
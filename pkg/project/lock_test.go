@@ -0,0 +1,81 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLock(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "Lock", testLock, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLock(t *testing.T, when spec.G, it spec.S) {
+	var tmpDir string
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "project-lock")
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#WriteLock and #ReadLock", func() {
+		it("round trips the lock", func() {
+			lock := Lock{
+				Builder:  LockedImage{Name: "some/builder:base", Digest: "sha256:aaaa"},
+				RunImage: LockedImage{Name: "localhost:5000/some/run", Digest: "sha256:bbbb"},
+				Buildpacks: []LockedBuildpack{
+					{Locator: "urn:cnb:registry:example/foo@^1.2", ID: "example/foo", Version: "1.2.3", Image: "example.com/foo@sha256:cccc"},
+					{Locator: "https://example.com/bp.tgz", URI: "https://example.com/bp.tgz", SHA256: "sha256:dddd"},
+				},
+			}
+			path := filepath.Join(tmpDir, LockFileName)
+			h.AssertNil(t, WriteLock(path, lock))
+
+			actual, err := ReadLock(path)
+			h.AssertNil(t, err)
+			lock.Version = LockVersion
+			h.AssertEq(t, actual, lock)
+
+			bp, ok := actual.FindBuildpack("https://example.com/bp.tgz")
+			h.AssertTrue(t, ok)
+			h.AssertEq(t, bp.SHA256, "sha256:dddd")
+		})
+
+		it("fails for unsupported versions", func() {
+			path := filepath.Join(tmpDir, LockFileName)
+			h.AssertNil(t, os.WriteFile(path, []byte(`version = "99"`), 0600))
+
+			_, err := ReadLock(path)
+			h.AssertError(t, err, "unsupported lock file version 99")
+		})
+	})
+
+	when("LockedImage#Reference", func() {
+		it("pins the repository to the digest", func() {
+			for imageName, expected := range map[string]string{
+				"some/builder":                  "some/builder@sha256:aaaa",
+				"some/builder:base":             "some/builder@sha256:aaaa",
+				"localhost:5000/some/run":       "localhost:5000/some/run@sha256:aaaa",
+				"localhost:5000/some/run:1.0":   "localhost:5000/some/run@sha256:aaaa",
+				"some/builder@sha256:bbbb":      "some/builder@sha256:aaaa",
+				"some/builder:base@sha256:bbbb": "some/builder@sha256:aaaa",
+			} {
+				h.AssertEq(t, LockedImage{Name: imageName, Digest: "sha256:aaaa"}.Reference(), expected)
+			}
+		})
+	})
+}
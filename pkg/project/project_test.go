@@ -12,6 +12,7 @@ import (
 	"github.com/sclevine/spec/report"
 
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/project/types"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -142,6 +143,74 @@ value = "this-should-get-overridden-because-its-deprecated"
 					expected, projectDescriptor.Metadata["pipeline"])
 			}
 		})
+		it("should parse container settings from a v0.2 project.toml file", func() {
+			projectToml := `
+[_]
+name = "gallant 0.2"
+schema-version="0.2"
+[io.buildpacks.build.container]
+memory = "2g"
+cpus = 1.5
+pids-limit = 512
+ulimits = ["nofile=1024:2048"]
+extra-hosts = ["some-host:127.0.0.1"]
+dns = ["8.8.8.8"]
+security-opt = ["no-new-privileges"]
+cap-drop = ["ALL"]
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			projectDescriptor, err := ReadProjectDescriptor(tmpProjectToml.Name(), logger)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := types.Container{
+				Memory:      "2g",
+				CPUs:        1.5,
+				PidsLimit:   512,
+				Ulimits:     []string{"nofile=1024:2048"},
+				ExtraHosts:  []string{"some-host:127.0.0.1"},
+				DNS:         []string{"8.8.8.8"},
+				SecurityOpt: []string{"no-new-privileges"},
+				CapDrop:     []string{"ALL"},
+			}
+			if !reflect.DeepEqual(expected, projectDescriptor.Build.Container) {
+				t.Fatalf("Expected\n-----\n%#v\n-----\nbut got\n-----\n%#v\n",
+					expected, projectDescriptor.Build.Container)
+			}
+			h.AssertNotContains(t, readStdout(), "Warning")
+		})
+
+		it("should parse the egress policy from a v0.2 project.toml file", func() {
+			projectToml := `
+[_]
+name = "gallant 0.2"
+schema-version="0.2"
+[io.buildpacks.build.egress]
+policy = "allowlist"
+allow = ["registry.npmjs.org", "*.github.com"]
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			projectDescriptor, err := ReadProjectDescriptor(tmpProjectToml.Name(), logger)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h.AssertEq(t, projectDescriptor.Build.Egress, types.Egress{
+				Policy: "allowlist",
+				Allow:  []string{"registry.npmjs.org", "*.github.com"},
+			})
+			h.AssertNotContains(t, readStdout(), "Warning")
+		})
+
 		it("should be backwards compatible with older v0.2 project.toml file", func() {
 			projectToml := `
 [_]
@@ -28,10 +28,30 @@ type Build struct {
 	Buildpacks []Buildpack `toml:"buildpacks"`
 	Env        []EnvVar    `toml:"env"`
 	Builder    string      `toml:"builder"`
+	Container  Container   `toml:"container"`
+	Egress     Egress      `toml:"egress"`
 	Pre        GroupAddition
 	Post       GroupAddition
 }
 
+// Container configures the containers in which buildpacks run
+type Container struct {
+	Memory      string   `toml:"memory"`
+	CPUs        float64  `toml:"cpus"`
+	PidsLimit   int64    `toml:"pids-limit"`
+	Ulimits     []string `toml:"ulimits"`
+	ExtraHosts  []string `toml:"extra-hosts"`
+	DNS         []string `toml:"dns"`
+	SecurityOpt []string `toml:"security-opt"`
+	CapDrop     []string `toml:"cap-drop"`
+}
+
+// Egress restricts the hosts buildpacks may contact during the build
+type Egress struct {
+	Policy string   `toml:"policy"`
+	Allow  []string `toml:"allow"`
+}
+
 type Project struct {
 	Name      string    `toml:"name"`
 	Version   string    `toml:"version"`
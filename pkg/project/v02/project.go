@@ -19,7 +19,9 @@ type Buildpacks struct {
 }
 
 type Build struct {
-	Env []types.EnvVar `toml:"env"`
+	Env       []types.EnvVar  `toml:"env"`
+	Container types.Container `toml:"container"`
+	Egress    types.Egress    `toml:"egress"`
 }
 
 // Deprecated: use `[[io.buildpacks.build.env]]` instead. see https://github.com/buildpacks/pack/pull/1479
@@ -67,6 +69,8 @@ func NewDescriptor(projectTomlContents string) (types.Descriptor, toml.MetaData,
 			Buildpacks: versionedDescriptor.IO.Buildpacks.Group,
 			Env:        env,
 			Builder:    versionedDescriptor.IO.Buildpacks.Builder,
+			Container:  versionedDescriptor.IO.Buildpacks.Build.Container,
+			Egress:     versionedDescriptor.IO.Buildpacks.Build.Egress,
 			Pre:        versionedDescriptor.IO.Buildpacks.Pre,
 			Post:       versionedDescriptor.IO.Buildpacks.Post,
 		},
@@ -0,0 +1,135 @@
+package sbom
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// Property names used to record buildpack information on CycloneDX components.
+const (
+	cdxBuildpackProperty = "io.buildpacks.buildpack"
+	cdxScopeProperty     = "io.buildpacks.scope"
+)
+
+// cdxComponentTypes are the component types defined by the CycloneDX specification.
+var cdxComponentTypes = map[string]bool{
+	"application": true, "framework": true, "library": true, "container": true,
+	"operating-system": true, "device": true, "firmware": true, "file": true,
+}
+
+type cdxDocument struct {
+	BOMFormat   string         `json:"bomFormat"`
+	SpecVersion string         `json:"specVersion"`
+	Version     int            `json:"version"`
+	Metadata    *cdxMetadata   `json:"metadata,omitempty"`
+	Components  []cdxComponent `json:"components"`
+}
+
+type cdxMetadata struct {
+	Tools     []cdxTool     `json:"tools,omitempty"`
+	Component *cdxComponent `json:"component,omitempty"`
+}
+
+type cdxTool struct {
+	Vendor string `json:"vendor,omitempty"`
+	Name   string `json:"name"`
+}
+
+type cdxComponent struct {
+	Type       string         `json:"type"`
+	Name       string         `json:"name"`
+	Version    string         `json:"version,omitempty"`
+	PURL       string         `json:"purl,omitempty"`
+	Licenses   []cdxLicense   `json:"licenses,omitempty"`
+	Properties []cdxProperty  `json:"properties,omitempty"`
+	Components []cdxComponent `json:"components,omitempty"`
+}
+
+type cdxLicense struct {
+	License    *cdxLicenseID `json:"license,omitempty"`
+	Expression string        `json:"expression,omitempty"`
+}
+
+type cdxLicenseID struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func decodeCycloneDX(data []byte) (*Document, error) {
+	var cdx cdxDocument
+	if err := json.Unmarshal(data, &cdx); err != nil {
+		return nil, errors.Wrap(err, "parsing CycloneDX document")
+	}
+
+	doc := &Document{}
+	if cdx.Metadata != nil && cdx.Metadata.Component != nil {
+		doc.Name = cdx.Metadata.Component.Name
+	}
+
+	var walk func(components []cdxComponent)
+	walk = func(components []cdxComponent) {
+		for _, c := range components {
+			pkg := Package{Name: c.Name, Version: c.Version, Type: c.Type, PURL: c.PURL}
+			for _, l := range c.Licenses {
+				switch {
+				case l.Expression != "":
+					pkg.Licenses = append(pkg.Licenses, l.Expression)
+				case l.License != nil && l.License.ID != "":
+					pkg.Licenses = append(pkg.Licenses, l.License.ID)
+				case l.License != nil && l.License.Name != "":
+					pkg.Licenses = append(pkg.Licenses, l.License.Name)
+				}
+			}
+			for _, p := range c.Properties {
+				switch p.Name {
+				case cdxBuildpackProperty:
+					pkg.Buildpack = p.Value
+				case cdxScopeProperty:
+					pkg.Scope = p.Value
+				}
+			}
+			doc.Packages = append(doc.Packages, pkg)
+			walk(c.Components)
+		}
+	}
+	walk(cdx.Components)
+
+	return doc, nil
+}
+
+func encodeCycloneDX(doc *Document) cdxDocument {
+	cdx := cdxDocument{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.4",
+		Version:     1,
+		Metadata:    &cdxMetadata{Tools: []cdxTool{{Vendor: "Cloud Native Buildpacks", Name: "pack"}}},
+		Components:  []cdxComponent{},
+	}
+	if doc.Name != "" {
+		cdx.Metadata.Component = &cdxComponent{Type: "container", Name: doc.Name}
+	}
+
+	for _, pkg := range doc.Packages {
+		c := cdxComponent{Type: pkg.Type, Name: pkg.Name, Version: pkg.Version, PURL: pkg.PURL}
+		if !cdxComponentTypes[c.Type] {
+			c.Type = "library"
+		}
+		for _, license := range pkg.Licenses {
+			c.Licenses = append(c.Licenses, cdxLicense{Expression: license})
+		}
+		if pkg.Buildpack != "" {
+			c.Properties = append(c.Properties, cdxProperty{Name: cdxBuildpackProperty, Value: pkg.Buildpack})
+		}
+		if pkg.Scope != "" {
+			c.Properties = append(c.Properties, cdxProperty{Name: cdxScopeProperty, Value: pkg.Scope})
+		}
+		cdx.Components = append(cdx.Components, c)
+	}
+	return cdx
+}
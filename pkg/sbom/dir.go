@@ -0,0 +1,121 @@
+package sbom
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// ReadDir reads the SBOM files in the directory layout written by the lifecycle, e.g. by `pack sbom download`
+// or `pack build --sbom-output-dir`:
+//
+//	<dir>/[layers/sbom/]<launch|build>/<escaped-buildpack-id>/[<layer-name>/]sbom.<cdx|spdx|syft>.json
+//
+// Packages are annotated with the buildpack and scope they were recorded for. The lifecycle escapes buildpack IDs in
+// directory names, so a directory is attributed to the buildpack of buildpackIDs, e.g. those in the lifecycle metadata
+// of the image, that it is the escaped ID of. Other directories are attributed to their escaped name. When the same
+// SBOM is provided in several formats, only the first one in Formats is read.
+func ReadDir(dir string, buildpackIDs ...string) ([]*Document, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, errors.Wrapf(err, "reading SBOM directory %s", style.Symbol(dir))
+	}
+
+	// directory containing the SBOM -> format -> path
+	found := map[string]map[Format]string{}
+	var dirs []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		format, ok := formatFromFilename(d.Name())
+		if !ok {
+			return nil
+		}
+		parent := filepath.Dir(path)
+		if found[parent] == nil {
+			found[parent] = map[Format]string{}
+			dirs = append(dirs, parent)
+		}
+		found[parent][format] = path
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading SBOM directory %s", style.Symbol(dir))
+	}
+
+	var docs []*Document
+	for _, parent := range dirs {
+		for _, format := range Formats {
+			path, ok := found[parent][format]
+			if !ok {
+				continue
+			}
+
+			doc, err := readFile(path, format)
+			if err != nil {
+				return nil, err
+			}
+
+			rel, err := filepath.Rel(dir, parent)
+			if err != nil {
+				return nil, err
+			}
+			bp, scope := buildpackFromPath(rel, buildpackIDs)
+			for i := range doc.Packages {
+				if doc.Packages[i].Buildpack == "" {
+					doc.Packages[i].Buildpack = bp
+				}
+				if doc.Packages[i].Scope == "" {
+					doc.Packages[i].Scope = scope
+				}
+			}
+			docs = append(docs, doc)
+			break
+		}
+	}
+	return docs, nil
+}
+
+// ReadFile reads an SBOM document, detecting its format from its content.
+func ReadFile(path string) (*Document, error) {
+	return readFile(path, "")
+}
+
+func readFile(path string, format Format) (*Document, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading SBOM %s", style.Symbol(path))
+	}
+	doc, err := Decode(data, format)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading SBOM %s", style.Symbol(path))
+	}
+	return doc, nil
+}
+
+// buildpackFromPath returns the buildpack ID and scope of the SBOM in the directory rel.
+func buildpackFromPath(rel string, buildpackIDs []string) (bp, scope string) {
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	for i, part := range parts {
+		if (part == ScopeLaunch || part == ScopeBuild) && i+1 < len(parts) {
+			return unescapeBuildpackID(parts[i+1], buildpackIDs), part
+		}
+	}
+	return "", ""
+}
+
+// unescapeBuildpackID returns the buildpack ID the lifecycle escaped to name, by replacing '/' with '_'. As IDs may
+// also contain '_', name is only unescaped if it is the escaped form of one of buildpackIDs.
+func unescapeBuildpackID(name string, buildpackIDs []string) string {
+	for _, id := range buildpackIDs {
+		if strings.ReplaceAll(id, "/", "_") == name {
+			return id
+		}
+	}
+	return name
+}
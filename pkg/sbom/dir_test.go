@@ -0,0 +1,107 @@
+package sbom_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/sbom"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestReadDir(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ReadDir", testReadDir, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testReadDir(t *testing.T, when spec.G, it spec.S) {
+	var tmpDir string
+
+	writeFile := func(path, contents string) {
+		path = filepath.Join(tmpDir, filepath.FromSlash(path))
+		h.AssertNil(t, os.MkdirAll(filepath.Dir(path), 0755))
+		h.AssertNil(t, os.WriteFile(path, []byte(contents), 0600))
+	}
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "pack.sbom.dir.test.")
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#ReadDir", func() {
+		it("annotates packages with their buildpack and scope", func() {
+			writeFile("layers/sbom/launch/some-org_some-bp/node/sbom.cdx.json",
+				`{"bomFormat": "CycloneDX", "components": [{"type": "library", "name": "node", "version": "20.0.0"}]}`)
+			writeFile("layers/sbom/build/other-bp/sbom.syft.json",
+				`{"artifacts": [{"name": "npm", "version": "10.0.0"}]}`)
+
+			docs, err := sbom.ReadDir(tmpDir, "some-org/some-bp", "other-bp")
+			h.AssertNil(t, err)
+
+			merged := sbom.Merge("", docs...)
+			h.AssertEq(t, merged.Packages, []sbom.Package{
+				{Name: "node", Version: "20.0.0", Type: "library", Buildpack: "some-org/some-bp", Scope: sbom.ScopeLaunch},
+				{Name: "npm", Version: "10.0.0", Buildpack: "other-bp", Scope: sbom.ScopeBuild},
+			})
+		})
+
+		it("keeps underscores of buildpack IDs", func() {
+			writeFile("launch/some-org_some_bp/sbom.syft.json", `{"artifacts": [{"name": "node"}]}`)
+
+			docs, err := sbom.ReadDir(tmpDir, "some-org/some_bp")
+			h.AssertNil(t, err)
+			h.AssertEq(t, docs[0].Packages[0].Buildpack, "some-org/some_bp")
+		})
+
+		it("uses the escaped name of unknown buildpacks", func() {
+			writeFile("launch/some-org_some_bp/sbom.syft.json", `{"artifacts": [{"name": "node"}]}`)
+
+			docs, err := sbom.ReadDir(tmpDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, docs[0].Packages[0].Buildpack, "some-org_some_bp")
+		})
+
+		it("reads one format when an SBOM is provided in several", func() {
+			writeFile("launch/some-bp/sbom.cdx.json",
+				`{"bomFormat": "CycloneDX", "components": [{"type": "library", "name": "from-cdx"}]}`)
+			writeFile("launch/some-bp/sbom.spdx.json",
+				`{"spdxVersion": "SPDX-2.3", "packages": [{"name": "from-spdx"}]}`)
+
+			docs, err := sbom.ReadDir(tmpDir)
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(docs), 1)
+			h.AssertEq(t, docs[0].Packages[0].Name, "from-cdx")
+		})
+
+		it("ignores other files", func() {
+			writeFile("launch/some-bp/sbom.legacy.json", `[]`)
+
+			docs, err := sbom.ReadDir(tmpDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(docs), 0)
+		})
+
+		it("fails on invalid documents", func() {
+			writeFile("launch/some-bp/sbom.cdx.json", `not json`)
+
+			_, err := sbom.ReadDir(tmpDir)
+			h.AssertError(t, err, "parsing CycloneDX document")
+		})
+
+		it("fails when the directory does not exist", func() {
+			_, err := sbom.ReadDir(filepath.Join(tmpDir, "missing"))
+			h.AssertError(t, err, "reading SBOM directory")
+		})
+	})
+}
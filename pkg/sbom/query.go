@@ -0,0 +1,52 @@
+package sbom
+
+import (
+	"path"
+	"strings"
+)
+
+// Query selects packages of a document. Each non-empty field must match; fields may contain shell patterns
+// such as "lib*". Matching is case-insensitive.
+type Query struct {
+	Name      string
+	Version   string
+	License   string
+	Buildpack string
+}
+
+// Find returns the packages of doc matching q.
+func (d *Document) Find(q Query) []Package {
+	var result []Package
+	for _, pkg := range d.Packages {
+		if q.matches(pkg) {
+			result = append(result, pkg)
+		}
+	}
+	return result
+}
+
+func (q Query) matches(pkg Package) bool {
+	if !matchPattern(q.Name, pkg.Name) || !matchPattern(q.Version, pkg.Version) || !matchPattern(q.Buildpack, pkg.Buildpack) {
+		return false
+	}
+	if q.License == "" {
+		return true
+	}
+	for _, license := range pkg.Licenses {
+		if matchPattern(q.License, license) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPattern(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	pattern, value = strings.ToLower(pattern), strings.ToLower(value)
+	if matched, err := path.Match(pattern, value); err == nil && matched {
+		return true
+	}
+	return pattern == value
+}
@@ -0,0 +1,215 @@
+// Package sbom reads, converts, merges and queries the Software Bill of Materials (SBOM) documents
+// produced by buildpacks in CycloneDX, SPDX and Syft JSON formats.
+package sbom
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// Format is an SBOM document format.
+type Format string
+
+const (
+	FormatCycloneDX Format = "cyclonedx"
+	FormatSPDX      Format = "spdx"
+	FormatSyft      Format = "syft"
+)
+
+// Formats lists every supported format, in order of preference.
+var Formats = []Format{FormatCycloneDX, FormatSPDX, FormatSyft}
+
+// ParseFormat parses the name of a format. "cdx" is accepted as an alias of "cyclonedx".
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "cyclonedx", "cdx":
+		return FormatCycloneDX, nil
+	case "spdx":
+		return FormatSPDX, nil
+	case "syft":
+		return FormatSyft, nil
+	}
+	return "", errors.Errorf("unsupported SBOM format %s, must be one of cyclonedx, spdx or syft", style.Symbol(s))
+}
+
+// MediaType returns the media type of documents in this format.
+func (f Format) MediaType() string {
+	switch f {
+	case FormatCycloneDX:
+		return buildpack.MediaTypeCycloneDX
+	case FormatSPDX:
+		return buildpack.MediaTypeSPDX
+	case FormatSyft:
+		return buildpack.MediaTypeSyft
+	}
+	return ""
+}
+
+// Filename returns the name the lifecycle gives SBOM files in this format, e.g. sbom.cdx.json.
+func (f Format) Filename() string {
+	switch f {
+	case FormatCycloneDX:
+		return buildpack.ExtensionCycloneDX
+	case FormatSPDX:
+		return buildpack.ExtensionSPDX
+	case FormatSyft:
+		return buildpack.ExtensionSyft
+	}
+	return ""
+}
+
+// FormatFromMediaType returns the format of documents with the given media type.
+func FormatFromMediaType(mediaType string) (Format, bool) {
+	for _, format := range Formats {
+		if format.MediaType() == mediaType {
+			return format, true
+		}
+	}
+	return "", false
+}
+
+// formatFromFilename returns the format of an SBOM file written by the lifecycle, e.g. sbom.cdx.json.
+func formatFromFilename(name string) (Format, bool) {
+	for _, format := range Formats {
+		if format.Filename() == name {
+			return format, true
+		}
+	}
+	return "", false
+}
+
+// DetectFormat detects the format of an SBOM document from its content.
+func DetectFormat(data []byte) (Format, error) {
+	var probe struct {
+		BOMFormat   string          `json:"bomFormat"`
+		SPDXVersion string          `json:"spdxVersion"`
+		Artifacts   json.RawMessage `json:"artifacts"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return "", errors.Wrap(err, "parsing SBOM document")
+	}
+
+	switch {
+	case probe.BOMFormat == "CycloneDX":
+		return FormatCycloneDX, nil
+	case probe.SPDXVersion != "":
+		return FormatSPDX, nil
+	case probe.Artifacts != nil:
+		return FormatSyft, nil
+	}
+	return "", errors.New("unable to detect SBOM format, expected a CycloneDX, SPDX or Syft JSON document")
+}
+
+// Scopes of the packages recorded in the SBOMs of an app image.
+const (
+	ScopeLaunch = "launch"
+	ScopeBuild  = "build"
+)
+
+// Document is a format-independent SBOM.
+type Document struct {
+	// Name of the described artifact, e.g. the app image.
+	Name string
+
+	Packages []Package
+}
+
+// Package is a software package listed in an SBOM.
+type Package struct {
+	Name     string
+	Version  string
+	Type     string
+	PURL     string
+	Licenses []string
+
+	// ID of the buildpack whose SBOM listed the package, if known.
+	Buildpack string
+
+	// Whether the package is present at launch or only during the build, if known.
+	Scope string
+}
+
+func (p Package) key() string {
+	return strings.Join([]string{p.Name, p.Version, p.PURL, p.Buildpack, p.Scope}, "\x00")
+}
+
+// Merge combines documents into one, dropping duplicate packages.
+func Merge(name string, docs ...*Document) *Document {
+	merged := &Document{Name: name}
+	seen := map[string]bool{}
+	for _, doc := range docs {
+		for _, pkg := range doc.Packages {
+			if seen[pkg.key()] {
+				continue
+			}
+			seen[pkg.key()] = true
+			merged.Packages = append(merged.Packages, pkg)
+		}
+	}
+
+	sort.SliceStable(merged.Packages, func(i, j int) bool {
+		a, b := merged.Packages[i], merged.Packages[j]
+		if a.Scope != b.Scope {
+			return a.Scope > b.Scope // launch before build
+		}
+		if a.Buildpack != b.Buildpack {
+			return a.Buildpack < b.Buildpack
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Version < b.Version
+	})
+	return merged
+}
+
+// Decode parses an SBOM document. If format is empty, it is detected from the content.
+func Decode(data []byte, format Format) (*Document, error) {
+	if format == "" {
+		var err error
+		if format, err = DetectFormat(data); err != nil {
+			return nil, err
+		}
+	}
+
+	switch format {
+	case FormatCycloneDX:
+		return decodeCycloneDX(data)
+	case FormatSPDX:
+		return decodeSPDX(data)
+	case FormatSyft:
+		return decodeSyft(data)
+	}
+	return nil, errors.Errorf("unsupported SBOM format %s", style.Symbol(string(format)))
+}
+
+// Encode writes doc as an indented JSON document in the given format.
+func Encode(doc *Document, format Format) ([]byte, error) {
+	var v interface{}
+	switch format {
+	case FormatCycloneDX:
+		v = encodeCycloneDX(doc)
+	case FormatSPDX:
+		v = encodeSPDX(doc)
+	case FormatSyft:
+		v = encodeSyft(doc)
+	default:
+		return nil, errors.Errorf("unsupported SBOM format %s", style.Symbol(string(format)))
+	}
+
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, errors.Wrapf(err, "encoding %s SBOM", format)
+	}
+	return buf.Bytes(), nil
+}
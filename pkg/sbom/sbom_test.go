@@ -0,0 +1,169 @@
+package sbom_test
+
+import (
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/sbom"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSBOM(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "SBOM", testSBOM, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testSBOM(t *testing.T, when spec.G, it spec.S) {
+	var doc *sbom.Document
+
+	it.Before(func() {
+		doc = &sbom.Document{
+			Name: "some/app",
+			Packages: []sbom.Package{
+				{Name: "openssl", Version: "3.0.2", Type: "library", PURL: "pkg:deb/ubuntu/openssl@3.0.2", Licenses: []string{"Apache-2.0"}, Buildpack: "some/bp", Scope: sbom.ScopeLaunch},
+				{Name: "node", Version: "20.0.0", Type: "library", Licenses: []string{"MIT", "ISC"}, Buildpack: "other/bp", Scope: sbom.ScopeBuild},
+			},
+		}
+	})
+
+	when("#ParseFormat", func() {
+		it("accepts known formats", func() {
+			for input, expected := range map[string]sbom.Format{
+				"cyclonedx": sbom.FormatCycloneDX,
+				"CDX":       sbom.FormatCycloneDX,
+				"spdx":      sbom.FormatSPDX,
+				"syft":      sbom.FormatSyft,
+			} {
+				format, err := sbom.ParseFormat(input)
+				h.AssertNil(t, err)
+				h.AssertEq(t, format, expected)
+			}
+		})
+
+		it("rejects unknown formats", func() {
+			_, err := sbom.ParseFormat("xml")
+			h.AssertError(t, err, "unsupported SBOM format 'xml', must be one of cyclonedx, spdx or syft")
+		})
+	})
+
+	when("#FormatFromMediaType", func() {
+		it("maps media types to formats", func() {
+			for _, format := range sbom.Formats {
+				found, ok := sbom.FormatFromMediaType(format.MediaType())
+				h.AssertEq(t, ok, true)
+				h.AssertEq(t, found, format)
+			}
+
+			_, ok := sbom.FormatFromMediaType("application/json")
+			h.AssertEq(t, ok, false)
+		})
+	})
+
+	when("#DetectFormat", func() {
+		it("detects the format from the content", func() {
+			for _, format := range sbom.Formats {
+				data, err := sbom.Encode(doc, format)
+				h.AssertNil(t, err)
+
+				detected, err := sbom.DetectFormat(data)
+				h.AssertNil(t, err)
+				h.AssertEq(t, detected, format)
+			}
+		})
+
+		it("fails on unknown documents", func() {
+			_, err := sbom.DetectFormat([]byte(`{"some": "document"}`))
+			h.AssertError(t, err, "unable to detect SBOM format")
+		})
+	})
+
+	when("converting", func() {
+		it("keeps buildpack information in CycloneDX", func() {
+			data, err := sbom.Encode(doc, sbom.FormatCycloneDX)
+			h.AssertNil(t, err)
+			h.AssertContains(t, string(data), `"bomFormat": "CycloneDX"`)
+
+			decoded, err := sbom.Decode(data, "")
+			h.AssertNil(t, err)
+			h.AssertEq(t, decoded, doc)
+		})
+
+		it("keeps buildpack information in SPDX", func() {
+			data, err := sbom.Encode(doc, sbom.FormatSPDX)
+			h.AssertNil(t, err)
+			h.AssertContains(t, string(data), `"spdxVersion": "SPDX-2.3"`)
+			h.AssertContains(t, string(data), `"licenseDeclared": "MIT AND ISC"`)
+
+			decoded, err := sbom.Decode(data, sbom.FormatSPDX)
+			h.AssertNil(t, err)
+			h.AssertEq(t, decoded.Name, "some/app")
+			h.AssertEq(t, decoded.Packages[0].PURL, "pkg:deb/ubuntu/openssl@3.0.2")
+			h.AssertEq(t, decoded.Packages[0].Buildpack, "some/bp")
+			h.AssertEq(t, decoded.Packages[0].Scope, sbom.ScopeLaunch)
+			h.AssertEq(t, decoded.Packages[1].Licenses, []string{"MIT AND ISC"})
+		})
+
+		it("converts Syft documents", func() {
+			data, err := sbom.Encode(doc, sbom.FormatSyft)
+			h.AssertNil(t, err)
+
+			decoded, err := sbom.Decode(data, sbom.FormatSyft)
+			h.AssertNil(t, err)
+			h.AssertEq(t, decoded.Packages[0].Name, "openssl")
+			h.AssertEq(t, decoded.Packages[1].Licenses, []string{"MIT", "ISC"})
+		})
+
+		it("reads licenses recorded as strings by older Syft schemas", func() {
+			decoded, err := sbom.Decode([]byte(`{"artifacts": [{"name": "zlib", "version": "1.2.13", "licenses": ["Zlib"]}]}`), "")
+			h.AssertNil(t, err)
+			h.AssertEq(t, decoded.Packages, []sbom.Package{{Name: "zlib", Version: "1.2.13", Licenses: []string{"Zlib"}}})
+		})
+
+		it("reads nested CycloneDX components", func() {
+			decoded, err := sbom.Decode([]byte(`{
+  "bomFormat": "CycloneDX",
+  "components": [
+    {"type": "library", "name": "parent", "components": [{"type": "library", "name": "child", "licenses": [{"license": {"id": "MIT"}}]}]}
+  ]
+}`), "")
+			h.AssertNil(t, err)
+			h.AssertEq(t, decoded.Packages, []sbom.Package{
+				{Name: "parent", Type: "library"},
+				{Name: "child", Type: "library", Licenses: []string{"MIT"}},
+			})
+		})
+	})
+
+	when("#Merge", func() {
+		it("drops duplicates and lists launch packages first", func() {
+			other := &sbom.Document{Packages: []sbom.Package{
+				{Name: "curl", Version: "7.81.0", Buildpack: "some/bp", Scope: sbom.ScopeLaunch},
+				doc.Packages[0],
+			}}
+
+			merged := sbom.Merge("some/app", doc, other)
+			h.AssertEq(t, merged.Name, "some/app")
+
+			var names []string
+			for _, pkg := range merged.Packages {
+				names = append(names, pkg.Name)
+			}
+			h.AssertEq(t, names, []string{"curl", "openssl", "node"})
+		})
+	})
+
+	when("#Find", func() {
+		it("filters by name, version, license and buildpack", func() {
+			h.AssertEq(t, len(doc.Find(sbom.Query{})), 2)
+			h.AssertEq(t, doc.Find(sbom.Query{Name: "OpenSSL"})[0].Name, "openssl")
+			h.AssertEq(t, doc.Find(sbom.Query{Version: "20.*"})[0].Name, "node")
+			h.AssertEq(t, doc.Find(sbom.Query{License: "isc"})[0].Name, "node")
+			h.AssertEq(t, doc.Find(sbom.Query{Buildpack: "some/*"})[0].Name, "openssl")
+			h.AssertEq(t, len(doc.Find(sbom.Query{Name: "node", Buildpack: "some/bp"})), 0)
+		})
+	})
+}
@@ -0,0 +1,154 @@
+package sbom
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const spdxNoAssertion = "NOASSERTION"
+
+type spdxDocument struct {
+	SPDXVersion       string           `json:"spdxVersion"`
+	DataLicense       string           `json:"dataLicense"`
+	SPDXID            string           `json:"SPDXID"`
+	Name              string           `json:"name"`
+	DocumentNamespace string           `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo `json:"creationInfo"`
+	Packages          []spdxPackage    `json:"packages"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name             string            `json:"name"`
+	SPDXID           string            `json:"SPDXID"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	LicenseConcluded string            `json:"licenseConcluded,omitempty"`
+	LicenseDeclared  string            `json:"licenseDeclared,omitempty"`
+	SourceInfo       string            `json:"sourceInfo,omitempty"`
+	PrimaryPurpose   string            `json:"primaryPackagePurpose,omitempty"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+func decodeSPDX(data []byte) (*Document, error) {
+	var spdx spdxDocument
+	if err := json.Unmarshal(data, &spdx); err != nil {
+		return nil, errors.Wrap(err, "parsing SPDX document")
+	}
+
+	doc := &Document{Name: spdx.Name}
+	for _, p := range spdx.Packages {
+		pkg := Package{Name: p.Name, Version: p.VersionInfo, Type: strings.ToLower(p.PrimaryPurpose)}
+		for _, license := range []string{p.LicenseDeclared, p.LicenseConcluded} {
+			if license != "" && license != spdxNoAssertion && license != "NONE" {
+				pkg.Licenses = []string{license}
+				break
+			}
+		}
+		for _, ref := range p.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				pkg.PURL = ref.ReferenceLocator
+			}
+		}
+		pkg.Buildpack, pkg.Scope = parseSPDXSourceInfo(p.SourceInfo)
+		doc.Packages = append(doc.Packages, pkg)
+	}
+	return doc, nil
+}
+
+func encodeSPDX(doc *Document) spdxDocument {
+	name := doc.Name
+	if name == "" {
+		name = "sbom"
+	}
+
+	spdx := spdxDocument{
+		SPDXVersion: "SPDX-2.3",
+		DataLicense: "CC0-1.0",
+		SPDXID:      "SPDXRef-DOCUMENT",
+		Name:        name,
+		CreationInfo: spdxCreationInfo{
+			Created:  time.Now().UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: pack"},
+		},
+		Packages: []spdxPackage{},
+	}
+
+	hash := sha256.New()
+	for i, pkg := range doc.Packages {
+		p := spdxPackage{
+			Name:             pkg.Name,
+			SPDXID:           fmt.Sprintf("SPDXRef-Package-%d", i+1),
+			VersionInfo:      pkg.Version,
+			DownloadLocation: spdxNoAssertion,
+			LicenseConcluded: spdxNoAssertion,
+			LicenseDeclared:  spdxNoAssertion,
+			SourceInfo:       spdxSourceInfo(pkg),
+		}
+		if len(pkg.Licenses) > 0 {
+			p.LicenseDeclared = spdxLicenseExpression(pkg.Licenses)
+		}
+		if pkg.PURL != "" {
+			p.ExternalRefs = []spdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: pkg.PURL}}
+		}
+		spdx.Packages = append(spdx.Packages, p)
+		fmt.Fprintln(hash, pkg.key())
+	}
+	spdx.DocumentNamespace = fmt.Sprintf("https://buildpacks.io/spdx/%s-%x", strings.ReplaceAll(name, "/", "-"), hash.Sum(nil)[:8])
+
+	return spdx
+}
+
+func spdxLicenseExpression(licenses []string) string {
+	if len(licenses) == 1 {
+		return licenses[0]
+	}
+	var parts []string
+	for _, license := range licenses {
+		if strings.Contains(license, " ") {
+			license = "(" + license + ")"
+		}
+		parts = append(parts, license)
+	}
+	return strings.Join(parts, " AND ")
+}
+
+// spdxSourceInfo records the buildpack information of a package, which SPDX has no dedicated field for.
+func spdxSourceInfo(pkg Package) string {
+	if pkg.Buildpack == "" {
+		return ""
+	}
+	info := "buildpack: " + pkg.Buildpack
+	if pkg.Scope != "" {
+		info += ", scope: " + pkg.Scope
+	}
+	return info
+}
+
+func parseSPDXSourceInfo(info string) (bp, scope string) {
+	for _, field := range strings.Split(info, ", ") {
+		key, value, _ := strings.Cut(field, ": ")
+		switch key {
+		case "buildpack":
+			bp = value
+		case "scope":
+			scope = value
+		}
+	}
+	return bp, scope
+}
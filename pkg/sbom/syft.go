@@ -0,0 +1,114 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	syftSchemaVersion = "16.0.1"
+	syftSchemaURL     = "https://raw.githubusercontent.com/anchore/syft/main/schema/json/schema-16.0.1.json"
+)
+
+type syftDocument struct {
+	Artifacts  []syftArtifact `json:"artifacts"`
+	Source     syftSource     `json:"source"`
+	Descriptor syftDescriptor `json:"descriptor"`
+	Schema     syftSchema     `json:"schema"`
+}
+
+type syftArtifact struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	Version   string            `json:"version"`
+	Type      string            `json:"type"`
+	FoundBy   string            `json:"foundBy"`
+	Locations []json.RawMessage `json:"locations"`
+	Licenses  []json.RawMessage `json:"licenses"`
+	Language  string            `json:"language"`
+	CPEs      []json.RawMessage `json:"cpes"`
+	PURL      string            `json:"purl"`
+}
+
+type syftLicense struct {
+	Value          string `json:"value"`
+	SPDXExpression string `json:"spdxExpression"`
+	Type           string `json:"type"`
+}
+
+type syftSource struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+	Type string `json:"type"`
+}
+
+type syftDescriptor struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type syftSchema struct {
+	Version string `json:"version"`
+	URL     string `json:"url"`
+}
+
+func decodeSyft(data []byte) (*Document, error) {
+	var syft syftDocument
+	if err := json.Unmarshal(data, &syft); err != nil {
+		return nil, errors.Wrap(err, "parsing Syft document")
+	}
+
+	doc := &Document{Name: syft.Source.Name}
+	for _, a := range syft.Artifacts {
+		pkg := Package{Name: a.Name, Version: a.Version, Type: a.Type, PURL: a.PURL}
+		for _, raw := range a.Licenses {
+			// older schemas record licenses as plain strings
+			var license string
+			if err := json.Unmarshal(raw, &license); err != nil {
+				var l syftLicense
+				if err := json.Unmarshal(raw, &l); err != nil {
+					return nil, errors.Wrapf(err, "parsing licenses of %s", a.Name)
+				}
+				license = l.SPDXExpression
+				if license == "" {
+					license = l.Value
+				}
+			}
+			if license != "" {
+				pkg.Licenses = append(pkg.Licenses, license)
+			}
+		}
+		doc.Packages = append(doc.Packages, pkg)
+	}
+	return doc, nil
+}
+
+func encodeSyft(doc *Document) syftDocument {
+	syft := syftDocument{
+		Artifacts:  []syftArtifact{},
+		Source:     syftSource{ID: doc.Name, Name: doc.Name, Type: "image"},
+		Descriptor: syftDescriptor{Name: "pack"},
+		Schema:     syftSchema{Version: syftSchemaVersion, URL: syftSchemaURL},
+	}
+
+	for i, pkg := range doc.Packages {
+		a := syftArtifact{
+			ID:        fmt.Sprintf("%d", i+1),
+			Name:      pkg.Name,
+			Version:   pkg.Version,
+			Type:      pkg.Type,
+			Locations: []json.RawMessage{},
+			Licenses:  []json.RawMessage{},
+			CPEs:      []json.RawMessage{},
+			PURL:      pkg.PURL,
+		}
+		for _, license := range pkg.Licenses {
+			data, _ := json.Marshal(syftLicense{Value: license, SPDXExpression: license, Type: "declared"})
+			a.Licenses = append(a.Licenses, data)
+		}
+		syft.Artifacts = append(syft.Artifacts, a)
+	}
+	return syft
+}
@@ -0,0 +1,138 @@
+// Package tracing provides the OpenTelemetry plumbing shared by the pack client and the lifecycle containers it runs.
+package tracing
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	// InstrumentationName is the name of the tracer used for all spans emitted by pack.
+	InstrumentationName = "github.com/buildpacks/pack"
+
+	// ServiceName is the default `service.name` resource attribute of spans emitted by the pack CLI.
+	ServiceName = "pack"
+
+	envOTLPEndpoint       = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	envOTLPTracesEndpoint = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	envSDKDisabled        = "OTEL_SDK_DISABLED"
+
+	// EnvTraceParent and EnvTraceState carry the W3C trace context into lifecycle containers.
+	EnvTraceParent = "TRACEPARENT"
+	EnvTraceState  = "TRACESTATE"
+)
+
+var propagator = propagation.TraceContext{}
+
+// ShutdownFunc flushes and stops a tracer provider.
+type ShutdownFunc func(context.Context) error
+
+// EnabledFromEnv returns true when the standard OTLP environment variables request traces to be exported.
+func EnabledFromEnv() bool {
+	if disabled, err := strconv.ParseBool(os.Getenv(envSDKDisabled)); err == nil && disabled {
+		return false
+	}
+	return os.Getenv(envOTLPEndpoint) != "" || os.Getenv(envOTLPTracesEndpoint) != ""
+}
+
+// NewProviderFromEnv creates a tracer provider exporting spans over OTLP/HTTP.
+// The exporter is configured through the standard `OTEL_EXPORTER_OTLP_*` environment variables.
+// When tracing is not enabled through the environment a no-op provider is returned.
+func NewProviderFromEnv(ctx context.Context, version string) (trace.TracerProvider, ShutdownFunc, error) {
+	if !EnabledFromEnv() {
+		return noop.NewTracerProvider(), func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "creating OTLP trace exporter")
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewSchemaless(
+			semconv.ServiceName(ServiceName),
+			semconv.ServiceVersion(version),
+		),
+	)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "creating trace resource")
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	return provider, provider.Shutdown, nil
+}
+
+// Start creates a span as a child of the span in ctx, using the tracer provider that created that span.
+// If ctx carries no span, ctx is returned unchanged along with a no-op span.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	parent := trace.SpanFromContext(ctx)
+	if !parent.SpanContext().IsValid() {
+		return ctx, parent
+	}
+	return StartWithProvider(ctx, parent.TracerProvider(), name, attrs...)
+}
+
+// StartWithProvider creates a span using the given tracer provider.
+func StartWithProvider(ctx context.Context, provider trace.TracerProvider, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return provider.Tracer(InstrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err, if any, on span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Env returns the environment variables propagating the trace context of ctx, in the form `KEY=VALUE`.
+// It returns nothing when ctx carries no sampled span.
+func Env(ctx context.Context) []string {
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+
+	var env []string
+	if v := carrier.Get("traceparent"); v != "" {
+		env = append(env, EnvTraceParent+"="+v)
+	}
+	if v := carrier.Get("tracestate"); v != "" {
+		env = append(env, EnvTraceState+"="+v)
+	}
+	return env
+}
+
+// ContextFromEnv extracts the trace context propagated through env, as produced by Env.
+func ContextFromEnv(ctx context.Context, env []string) context.Context {
+	carrier := propagation.MapCarrier{}
+	for _, e := range env {
+		parts := strings.SplitN(e, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		switch parts[0] {
+		case EnvTraceParent:
+			carrier.Set("traceparent", parts[1])
+		case EnvTraceState:
+			carrier.Set("tracestate", parts[1])
+		}
+	}
+	return propagator.Extract(ctx, carrier)
+}
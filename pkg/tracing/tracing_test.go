@@ -0,0 +1,157 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/buildpacks/pack/pkg/tracing"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestTracing(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "tracing", testTracing, spec.Sequential(), spec.Report(report.Terminal{}))
+}
+
+func testTracing(t *testing.T, when spec.G, it spec.S) {
+	var (
+		recorder *tracetest.SpanRecorder
+		provider *sdktrace.TracerProvider
+	)
+
+	it.Before(func() {
+		recorder = tracetest.NewSpanRecorder()
+		provider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	})
+
+	when("#Start", func() {
+		it("creates child spans using the provider of the parent span", func() {
+			ctx, parent := tracing.StartWithProvider(context.Background(), provider, "parent")
+			_, child := tracing.Start(ctx, "child")
+			child.End()
+			parent.End()
+
+			spans := recorder.Ended()
+			h.AssertEq(t, len(spans), 2)
+			h.AssertEq(t, spans[0].Name(), "child")
+			h.AssertEq(t, spans[0].Parent().SpanID(), parent.SpanContext().SpanID())
+		})
+
+		it("returns a no-op span when the context carries no span", func() {
+			_, span := tracing.Start(context.Background(), "orphan")
+			span.End()
+
+			h.AssertFalse(t, span.SpanContext().IsValid())
+			h.AssertEq(t, len(recorder.Ended()), 0)
+		})
+	})
+
+	when("#End", func() {
+		it("records errors on the span", func() {
+			_, span := tracing.StartWithProvider(context.Background(), provider, "failing")
+			tracing.End(span, errors.New("some-error"))
+
+			spans := recorder.Ended()
+			h.AssertEq(t, len(spans), 1)
+			h.AssertEq(t, spans[0].Status().Code, codes.Error)
+			h.AssertEq(t, spans[0].Status().Description, "some-error")
+		})
+	})
+
+	when("#Env", func() {
+		it("propagates the trace context through environment variables", func() {
+			ctx, span := tracing.StartWithProvider(context.Background(), provider, "parent")
+			defer span.End()
+
+			env := tracing.Env(ctx)
+			h.AssertEq(t, len(env), 1)
+			h.AssertTrue(t, strings.HasPrefix(env[0], "TRACEPARENT=00-"+span.SpanContext().TraceID().String()))
+
+			extracted := trace.SpanContextFromContext(tracing.ContextFromEnv(context.Background(), append([]string{"OTHER=value"}, env...)))
+			h.AssertEq(t, extracted.TraceID(), span.SpanContext().TraceID())
+			h.AssertEq(t, extracted.SpanID(), span.SpanContext().SpanID())
+		})
+
+		it("returns nothing without a span", func() {
+			h.AssertEq(t, len(tracing.Env(context.Background())), 0)
+		})
+	})
+
+	when("#NewProviderFromEnv", func() {
+		var (
+			collector *httptest.Server
+			mu        sync.Mutex
+			requests  []string
+		)
+
+		it.Before(func() {
+			requests = nil
+			collector = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				mu.Lock()
+				requests = append(requests, r.Method+" "+r.URL.Path+" "+string(body))
+				mu.Unlock()
+				w.Header().Set("Content-Type", "application/x-protobuf")
+				w.WriteHeader(http.StatusOK)
+			}))
+		})
+
+		it.After(func() {
+			collector.Close()
+			h.AssertNil(t, os.Unsetenv("OTEL_EXPORTER_OTLP_ENDPOINT"))
+			h.AssertNil(t, os.Unsetenv("OTEL_SDK_DISABLED"))
+		})
+
+		it("exports spans to the configured OTLP endpoint", func() {
+			h.AssertNil(t, os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", collector.URL))
+			h.AssertTrue(t, tracing.EnabledFromEnv())
+
+			tp, shutdown, err := tracing.NewProviderFromEnv(context.Background(), "1.2.3")
+			h.AssertNil(t, err)
+
+			_, span := tracing.StartWithProvider(context.Background(), tp, "pack.build")
+			span.End()
+			h.AssertNil(t, shutdown(context.Background()))
+
+			mu.Lock()
+			defer mu.Unlock()
+			h.AssertEq(t, len(requests), 1)
+			h.AssertContains(t, requests[0], "POST /v1/traces")
+			h.AssertContains(t, requests[0], "pack.build")
+		})
+
+		it("is disabled without an endpoint", func() {
+			h.AssertFalse(t, tracing.EnabledFromEnv())
+
+			tp, shutdown, err := tracing.NewProviderFromEnv(context.Background(), "1.2.3")
+			h.AssertNil(t, err)
+
+			_, span := tracing.StartWithProvider(context.Background(), tp, "pack.build")
+			span.End()
+			h.AssertNil(t, shutdown(context.Background()))
+			h.AssertFalse(t, span.SpanContext().IsValid())
+		})
+
+		it("is disabled when the SDK is disabled", func() {
+			h.AssertNil(t, os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", collector.URL))
+			h.AssertNil(t, os.Setenv("OTEL_SDK_DISABLED", "true"))
+			h.AssertFalse(t, tracing.EnabledFromEnv())
+		})
+	})
+}
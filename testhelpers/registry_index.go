@@ -0,0 +1,90 @@
+package testhelpers
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+)
+
+// RegistryIndexServer is a local stand-in for a buildpack registry index. It serves a JSON index over HTTP at
+// IndexURL, honouring conditional requests, and hosts an OCI registry for indexes stored as OCI artifacts.
+type RegistryIndexServer struct {
+	*httptest.Server
+
+	username string
+	password string
+
+	mu        sync.Mutex
+	index     string
+	downloads int
+}
+
+// NewRegistryIndexServer starts a RegistryIndexServer. If username is not empty, every request must use basic auth
+// with the given credentials. The server must be closed by the caller.
+func NewRegistryIndexServer(username, password string) *RegistryIndexServer {
+	s := &RegistryIndexServer{username: username, password: password, index: `{"buildpacks":[]}`}
+	ociRegistry := registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.username != "" {
+			if user, pass, ok := r.BasicAuth(); !ok || user != s.username || pass != s.password {
+				w.Header().Set("WWW-Authenticate", `Basic realm="registry-index"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+
+		if strings.HasPrefix(r.URL.Path, "/v2") {
+			ociRegistry.ServeHTTP(w, r)
+			return
+		}
+		if r.URL.Path != "/index.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		etag := fmt.Sprintf(`"%x"`, sha256.Sum256([]byte(s.index)))
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		s.downloads++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(s.index))
+	}))
+	return s
+}
+
+// SetIndex sets the JSON index served at IndexURL.
+func (s *RegistryIndexServer) SetIndex(index string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.index = index
+}
+
+// IndexURL returns the URL of the JSON index.
+func (s *RegistryIndexServer) IndexURL() string {
+	return s.URL + "/index.json"
+}
+
+// Host returns the host and port of the OCI registry, for use in image references.
+func (s *RegistryIndexServer) Host() string {
+	return strings.TrimPrefix(s.URL, "http://")
+}
+
+// Downloads returns the number of times the JSON index was served in full.
+func (s *RegistryIndexServer) Downloads() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.downloads
+}